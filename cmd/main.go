@@ -4,17 +4,24 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
 	handler "github.com/qthuy2k1/task-management-app/internal/handlers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
 	"github.com/joho/godotenv"
@@ -40,7 +47,121 @@ func main() {
 	}
 	defer database.Conn.Close()
 
-	httpHandler := handler.NewHandler(database)
+	// Items are purged from the trash after TRASH_RETENTION_DAYS days, 30 days by default
+	retentionDays := 30
+	if value := os.Getenv("TRASH_RETENTION_DAYS"); value != "" {
+		retentionDays, err = strconv.Atoi(value)
+		if err != nil || retentionDays < 0 {
+			log.Fatalf("Invalid TRASH_RETENTION_DAYS: %s", value)
+		}
+	}
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	// The emails are queued as send_mail jobs, which the workers send with the mailer of the configuration
+	mailer, err := utils.NewMailerFromEnv()
+	if err != nil {
+		log.Fatalf("Could not set up the mailer: %v", err)
+	}
+	jobRegistry := handler.NewJobRegistry(database)
+	// The purge, the archiving and the SLA escalation run as recurring jobs, which are queued again with the current settings,
+	// so a single worker runs them whatever the number of servers
+	jobController := controllers.NewJobController(repositories.NewJobRepository(database), jobRegistry)
+	mailQueue := controllers.NewMailQueue(jobController, mailer)
+	mailQueue.RegisterJobs(jobRegistry)
+	purgeJob := internalModels.NewJob{
+		Type:    controllers.JobTypePurgeTrash,
+		Payload: internalModels.PurgeTrashJob{RetentionDays: retentionDays},
+	}
+	if _, err := jobController.ScheduleJob(purgeJob, backgroundCtx); err != nil {
+		log.Fatalf("Could not schedule the purge of the trash: %v", err)
+	}
+
+	// Tasks completed more than ARCHIVE_COMPLETED_AFTER_DAYS days ago are archived, the policy is off when it is not set or 0
+	archiveAfterDays := 0
+	if value := os.Getenv("ARCHIVE_COMPLETED_AFTER_DAYS"); value != "" {
+		archiveAfterDays, err = strconv.Atoi(value)
+		if err != nil || archiveAfterDays < 0 {
+			log.Fatalf("Invalid ARCHIVE_COMPLETED_AFTER_DAYS: %s", value)
+		}
+	}
+	if archiveAfterDays > 0 {
+		archiveJob := internalModels.NewJob{
+			Type:    controllers.JobTypeArchiveCompletedTasks,
+			Payload: internalModels.ArchiveCompletedTasksJob{AfterDays: archiveAfterDays},
+		}
+		if _, err := jobController.ScheduleJob(archiveJob, backgroundCtx); err != nil {
+			log.Fatalf("Could not schedule the archiving of the completed tasks: %v", err)
+		}
+	} else if _, err := jobController.UnscheduleJobs(controllers.JobTypeArchiveCompletedTasks, backgroundCtx); err != nil {
+		log.Fatalf("Could not unschedule the archiving of the completed tasks: %v", err)
+	}
+
+	if _, err := jobController.ScheduleJob(internalModels.NewJob{Type: controllers.JobTypeEscalateSLABreaches, Payload: struct{}{}}, backgroundCtx); err != nil {
+		log.Fatalf("Could not schedule the escalation of the SLA breaches: %v", err)
+	}
+
+	// JOB_WORKERS background job workers run alongside the server, 4 by default and none when 0
+	workers := 4
+	if value := os.Getenv("JOB_WORKERS"); value != "" {
+		workers, err = strconv.Atoi(value)
+		if err != nil {
+			log.Fatalf("Invalid JOB_WORKERS: %v", err)
+		}
+	}
+	if workers > 0 {
+		pool := controllers.NewJobWorkerPool(repositories.NewJobRepository(database), jobRegistry, workers)
+		pool.Start()
+		// Deferred before the server is stopped, so the workers drain after the last request
+		defer pool.Stop(30 * time.Second)
+	}
+
+	tokenIssuer, err := NewTokenIssuer()
+	if err != nil {
+		log.Fatalf("Could not set up the tokens: %v", err)
+	}
+
+	// A session lasts REFRESH_TOKEN_TTL without being refreshed, 7 days by default
+	refreshTTL, err := time.ParseDuration(envOrDefault("REFRESH_TOKEN_TTL", "168h"))
+	if err != nil || refreshTTL <= 0 {
+		log.Fatalf("Invalid REFRESH_TOKEN_TTL: %v", os.Getenv("REFRESH_TOKEN_TTL"))
+	}
+	userSessionController := controllers.NewUserSessionController(repositories.NewUserSessionRepository(database), refreshTTL)
+
+	// Password reset links can be used for PASSWORD_RESET_TTL, 1 hour by default
+	resetTTL, err := time.ParseDuration(envOrDefault("PASSWORD_RESET_TTL", "1h"))
+	if err != nil || resetTTL <= 0 {
+		log.Fatalf("Invalid PASSWORD_RESET_TTL: %v", os.Getenv("PASSWORD_RESET_TTL"))
+	}
+	passwordResetController := controllers.NewPasswordResetController(
+		repositories.NewUserRepository(database),
+		repositories.NewPasswordResetRepository(database),
+		userSessionController,
+		mailQueue,
+		resetTTL,
+		envOrDefault("PASSWORD_RESET_URL", "http://localhost:3000/password/reset"),
+	)
+
+	// Users who have not verified their email cannot be assigned tasks, and cannot log in either with
+	// EMAIL_VERIFICATION_POLICY=block_login. Verification links can be used for EMAIL_VERIFICATION_TTL, 3 days by default.
+	verificationPolicy := internalModels.EmailVerificationPolicy(envOrDefault("EMAIL_VERIFICATION_POLICY", string(internalModels.EmailVerificationRestrictAssignment)))
+	if verificationPolicy != internalModels.EmailVerificationRestrictAssignment && verificationPolicy != internalModels.EmailVerificationBlockLogin {
+		log.Fatalf("Invalid EMAIL_VERIFICATION_POLICY: %s", verificationPolicy)
+	}
+	verificationTTL, err := time.ParseDuration(envOrDefault("EMAIL_VERIFICATION_TTL", "72h"))
+	if err != nil || verificationTTL <= 0 {
+		log.Fatalf("Invalid EMAIL_VERIFICATION_TTL: %v", os.Getenv("EMAIL_VERIFICATION_TTL"))
+	}
+	emailVerificationController := controllers.NewEmailVerificationController(
+		repositories.NewUserRepository(database),
+		tokenIssuer,
+		mailQueue,
+		verificationPolicy,
+		verificationTTL,
+		envOrDefault("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email"),
+	)
+
+	httpHandler := handler.NewHandler(database, tokenIssuer, userSessionController, passwordResetController, emailVerificationController)
 	server := &http.Server{
 		Handler: httpHandler,
 	}
@@ -63,3 +184,70 @@ func Stop(server *http.Server) {
 		os.Exit(1)
 	}
 }
+
+// Makes the issuer of the tokens from the configuration. The tokens are signed with JWT_SECRET for HS256, or
+// with the PEM key of JWT_PRIVATE_KEY_FILE for RS256 and EdDSA. The keys used before a rotation are listed in
+// JWT_PREVIOUS_KEYS as comma separated kid:secret pairs for HS256 and kid:path-to-PEM-key pairs otherwise.
+func NewTokenIssuer() (*controllers.TokenIssuer, error) {
+	config := controllers.TokenConfig{
+		Algorithm: envOrDefault("JWT_ALGORITHM", controllers.TokenAlgorithmHS256),
+		Issuer:    envOrDefault("JWT_ISSUER", "task-management-app"),
+		Audience:  envOrDefault("JWT_AUDIENCE", "task-management-app"),
+	}
+	// Access tokens expire after JWT_TTL, 15 minutes by default, and are renewed with the refresh token
+	ttl, err := time.ParseDuration(envOrDefault("JWT_TTL", "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT_TTL: %v", err)
+	}
+	config.TTL = ttl
+
+	signingKey, err := readTokenKey(config.Algorithm, os.Getenv("JWT_KEY_ID"), os.Getenv("JWT_SECRET"), os.Getenv("JWT_PRIVATE_KEY_FILE"))
+	if err != nil {
+		return nil, err
+	}
+	previousKeys := []jwk.Key{}
+	if value := os.Getenv("JWT_PREVIOUS_KEYS"); value != "" {
+		for _, entry := range strings.Split(value, ",") {
+			keyID, material, ok := strings.Cut(strings.TrimSpace(entry), ":")
+			if !ok || keyID == "" {
+				return nil, fmt.Errorf("invalid JWT_PREVIOUS_KEYS entry %q, expected kid:key", entry)
+			}
+			var key jwk.Key
+			if config.Algorithm == controllers.TokenAlgorithmHS256 {
+				key, err = readTokenKey(config.Algorithm, keyID, material, "")
+			} else {
+				key, err = readTokenKey(config.Algorithm, keyID, "", material)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid previous key %q: %v", keyID, err)
+			}
+			previousKeys = append(previousKeys, key)
+		}
+	}
+	return controllers.NewTokenIssuer(config, signingKey, previousKeys...)
+}
+
+// Reads a key from a secret for HS256, or from a PEM file for the other algorithms
+func readTokenKey(algorithm, keyID, secret, path string) (jwk.Key, error) {
+	if algorithm == controllers.TokenAlgorithmHS256 {
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET is required for HS256")
+		}
+		return controllers.NewTokenKey(algorithm, keyID, []byte(secret))
+	}
+	if path == "" {
+		return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE is required for %s", algorithm)
+	}
+	material, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return controllers.NewTokenKey(algorithm, keyID, material)
+}
+
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	handler "github.com/qthuy2k1/task-management-app/internal/handlers"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/joho/godotenv"
+)
+
+// Runs the background job workers without the HTTP server
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatalf("Some error occured. Err: %s", err)
+	}
+
+	dbUrl := os.Getenv("DB_URL")
+
+	database, err := repositories.Initialize(dbUrl)
+	boil.SetDB(database.Conn)
+	if err != nil {
+		log.Fatalf("Could not set up database: %v", err)
+	}
+	defer database.Conn.Close()
+
+	workers := 4
+	if value := os.Getenv("JOB_WORKERS"); value != "" {
+		workers, err = strconv.Atoi(value)
+		if err != nil || workers <= 0 {
+			log.Fatalf("Invalid JOB_WORKERS: %s", value)
+		}
+	}
+	mailer, err := utils.NewMailerFromEnv()
+	if err != nil {
+		log.Fatalf("Could not set up the mailer: %v", err)
+	}
+	jobRegistry := handler.NewJobRegistry(database)
+	controllers.NewMailQueue(controllers.NewJobController(repositories.NewJobRepository(database), jobRegistry), mailer).RegisterJobs(jobRegistry)
+	pool := controllers.NewJobWorkerPool(repositories.NewJobRepository(database), jobRegistry, workers)
+	pool.Start()
+	defer pool.Stop(30 * time.Second)
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+	log.Println(fmt.Sprint(<-ch))
+	log.Println("Stopping job workers.")
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS users_deleted_at_idx;
+DROP INDEX IF EXISTS task_categories_deleted_at_idx;
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+
+ALTER TABLE users DROP COLUMN deleted_by, DROP COLUMN deleted_at;
+ALTER TABLE task_categories DROP COLUMN deleted_by, DROP COLUMN deleted_at;
+ALTER TABLE tasks DROP COLUMN deleted_by, DROP COLUMN deleted_at;
@@ -0,0 +1,15 @@
+ALTER TABLE tasks
+    ADD COLUMN deleted_at TIMESTAMP NULL,
+    ADD COLUMN deleted_by INTEGER NULL REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE task_categories
+    ADD COLUMN deleted_at TIMESTAMP NULL,
+    ADD COLUMN deleted_by INTEGER NULL REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE users
+    ADD COLUMN deleted_at TIMESTAMP NULL,
+    ADD COLUMN deleted_by INTEGER NULL REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_deleted_at_idx ON tasks (deleted_at);
+CREATE INDEX task_categories_deleted_at_idx ON task_categories (deleted_at);
+CREATE INDEX users_deleted_at_idx ON users (deleted_at);
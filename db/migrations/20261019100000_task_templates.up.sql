@@ -0,0 +1,14 @@
+CREATE TABLE task_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    task_name VARCHAR(255) NOT NULL,
+    task_description TEXT NOT NULL DEFAULT '',
+    task_category_id INTEGER NULL REFERENCES task_categories(id) ON DELETE SET NULL,
+    start_offset_hours INTEGER NOT NULL DEFAULT 0,
+    end_offset_hours INTEGER NOT NULL DEFAULT 24,
+    assignee_ids INTEGER[] NOT NULL DEFAULT '{}',
+    subtasks JSONB NOT NULL DEFAULT '[]',
+    created_by INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
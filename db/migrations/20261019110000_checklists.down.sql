@@ -0,0 +1,3 @@
+ALTER TABLE task_categories DROP COLUMN require_checklist_completion;
+
+DROP TABLE IF EXISTS checklist_items;
@@ -0,0 +1,16 @@
+CREATE TABLE checklist_items (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    text TEXT NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    assignee_id INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    due_date TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX checklist_items_task_id_idx ON checklist_items (task_id, position);
+
+ALTER TABLE task_categories
+    ADD COLUMN require_checklist_completion BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,10 @@
+CREATE TABLE calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    task_category_id INTEGER NULL REFERENCES task_categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP NULL
+);
+
+CREATE INDEX calendar_feeds_user_id_idx ON calendar_feeds (user_id);
@@ -0,0 +1,5 @@
+CREATE TABLE caldav_resources (
+    task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL UNIQUE,
+    uid VARCHAR(255) NOT NULL
+);
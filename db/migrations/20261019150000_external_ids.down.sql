@@ -0,0 +1,2 @@
+ALTER TABLE task_categories DROP COLUMN external_id, DROP COLUMN external_source;
+ALTER TABLE tasks DROP COLUMN external_id, DROP COLUMN external_source;
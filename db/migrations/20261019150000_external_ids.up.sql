@@ -0,0 +1,11 @@
+ALTER TABLE tasks
+    ADD COLUMN external_source VARCHAR(255) NULL,
+    ADD COLUMN external_id VARCHAR(255) NULL,
+    ADD CONSTRAINT tasks_external_key UNIQUE (external_source, external_id),
+    ADD CONSTRAINT tasks_external_key_check CHECK ((external_source IS NULL) = (external_id IS NULL));
+
+ALTER TABLE task_categories
+    ADD COLUMN external_source VARCHAR(255) NULL,
+    ADD COLUMN external_id VARCHAR(255) NULL,
+    ADD CONSTRAINT task_categories_external_key UNIQUE (external_source, external_id),
+    ADD CONSTRAINT task_categories_external_key_check CHECK ((external_source IS NULL) = (external_id IS NULL));
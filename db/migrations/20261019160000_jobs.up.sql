@@ -0,0 +1,24 @@
+CREATE TABLE jobs (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 5,
+    timeout_seconds INTEGER NOT NULL DEFAULT 300,
+    run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    locked_at TIMESTAMP NULL,
+    locked_by VARCHAR(255) NULL,
+    progress INTEGER NOT NULL DEFAULT 0,
+    result JSONB NULL,
+    last_error TEXT NULL,
+    created_by INTEGER NULL REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMP NULL
+);
+
+-- Workers only look for queued jobs that are due and running jobs whose worker stopped
+CREATE INDEX jobs_queued_run_at_idx ON jobs (run_at) WHERE status = 'queued';
+CREATE INDEX jobs_running_locked_at_idx ON jobs (locked_at) WHERE status = 'running';
+CREATE INDEX jobs_status_idx ON jobs (status);
@@ -0,0 +1,19 @@
+CREATE TABLE task_status_transitions (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_status VARCHAR(50) NULL,
+    to_status VARCHAR(50) NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX task_status_transitions_task_id_idx ON task_status_transitions (task_id, changed_at);
+CREATE INDEX task_status_transitions_changed_at_idx ON task_status_transitions (changed_at, to_status);
+
+-- The history of existing tasks is unknown: they start as not started when they were created
+-- and move to their current status at their last update
+INSERT INTO task_status_transitions (task_id, from_status, to_status, changed_at)
+SELECT id, NULL, 'Not Started', created_at FROM tasks;
+
+INSERT INTO task_status_transitions (task_id, from_status, to_status, changed_at)
+SELECT id, 'Not Started', status, GREATEST(updated_at, created_at) FROM tasks
+WHERE status IS DISTINCT FROM 'Not Started';
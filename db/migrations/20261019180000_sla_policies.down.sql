@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS task_sla_escalations;
+
+ALTER TABLE task_categories
+    DROP COLUMN IF EXISTS sla_start_within_hours,
+    DROP COLUMN IF EXISTS sla_complete_within_business_hours,
+    DROP COLUMN IF EXISTS sla_escalate_to_user_id;
@@ -0,0 +1,13 @@
+ALTER TABLE task_categories
+    ADD COLUMN sla_start_within_hours INTEGER NULL CHECK (sla_start_within_hours > 0),
+    ADD COLUMN sla_complete_within_business_hours INTEGER NULL CHECK (sla_complete_within_business_hours > 0),
+    ADD COLUMN sla_escalate_to_user_id INTEGER NULL REFERENCES users(id) ON DELETE SET NULL;
+
+-- A breach of a task is escalated once, even when several servers run the escalation
+CREATE TABLE task_sla_escalations (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    breach VARCHAR(20) NOT NULL,
+    escalated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (task_id, breach)
+);
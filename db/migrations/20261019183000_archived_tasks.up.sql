@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMP NULL;
+
+CREATE INDEX tasks_archived_at_idx ON tasks (archived_at);
@@ -0,0 +1 @@
+ALTER TABLE task_categories DROP COLUMN IF EXISTS parent_id;
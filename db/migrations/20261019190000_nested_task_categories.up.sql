@@ -0,0 +1,5 @@
+ALTER TABLE task_categories
+    ADD COLUMN parent_id INTEGER NULL REFERENCES task_categories(id) ON DELETE SET NULL,
+    ADD CONSTRAINT task_categories_parent_id_check CHECK (parent_id <> id);
+
+CREATE INDEX task_categories_parent_id_idx ON task_categories (parent_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_category_members;
+
+ALTER TABLE task_categories
+    DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,14 @@
+-- Public categories are seen by every user, private ones only by their members and the managers
+ALTER TABLE task_categories
+    ADD COLUMN visibility VARCHAR(10) NOT NULL DEFAULT 'public' CHECK (visibility IN ('public', 'private'));
+
+-- Owners act as managers of the category, members can see and work on its tasks
+CREATE TABLE task_category_members (
+    task_category_id INTEGER NOT NULL REFERENCES task_categories(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(10) NOT NULL CHECK (role IN ('owner', 'member')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_category_id, user_id)
+);
+
+CREATE INDEX task_category_members_user_id_idx ON task_category_members (user_id);
@@ -0,0 +1,24 @@
+-- A session is started by a login and kept alive by refreshing its access token
+CREATE TABLE user_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NULL,
+    revoked_reason VARCHAR(30) NULL
+);
+
+CREATE INDEX user_sessions_user_id_idx ON user_sessions (user_id) WHERE revoked_at IS NULL;
+
+-- Every refresh token of a session is kept once it is used, so a used token sent again is detected as stolen
+CREATE TABLE user_session_refresh_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    session_id INTEGER NOT NULL REFERENCES user_sessions(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMP NULL
+);
+
+CREATE INDEX user_session_refresh_tokens_session_id_idx ON user_session_refresh_tokens (session_id);
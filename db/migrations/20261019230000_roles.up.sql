@@ -0,0 +1,35 @@
+-- Named sets of permissions, users.role holds the name of the role of a user
+CREATE TABLE roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    built_in BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE role_permissions (
+    role VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+-- Custom roles can have longer names than the built-in ones
+ALTER TABLE users ALTER COLUMN role TYPE VARCHAR(50);
+
+-- The built-in roles keep the behavior of the manager and user roles
+INSERT INTO roles(name, description, built_in) VALUES
+    ('manager', 'Manages every task, task category and user', TRUE),
+    ('user', 'Works on the tasks of the task categories they can see', TRUE);
+
+INSERT INTO role_permissions(role, permission) VALUES
+    ('manager', 'task.view_all'),
+    ('manager', 'task.manage'),
+    ('manager', 'task.delete'),
+    ('manager', 'task.lock'),
+    ('manager', 'category.manage'),
+    ('manager', 'template.manage'),
+    ('manager', 'trash.manage'),
+    ('manager', 'job.manage'),
+    ('manager', 'report.view'),
+    ('manager', 'user.manage'),
+    ('manager', 'user.role.assign'),
+    ('manager', 'role.manage');
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_unverified_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
@@ -0,0 +1,7 @@
+-- Users who signed up are unverified until they open the link sent to their email, the existing users are
+-- taken as verified
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL;
+
+UPDATE users SET email_verified_at = NOW();
+
+CREATE INDEX users_unverified_idx ON users (id) WHERE email_verified_at IS NULL AND deleted_at IS NULL;
@@ -0,0 +1,6 @@
+-- The tasks created from the subtasks of a template point to the main task of the instantiation
+ALTER TABLE tasks
+    ADD COLUMN parent_task_id INTEGER NULL REFERENCES tasks(id) ON DELETE SET NULL,
+    ADD CONSTRAINT tasks_parent_task_id_check CHECK (parent_task_id <> id);
+
+CREATE INDEX tasks_parent_task_id_idx ON tasks (parent_task_id);
@@ -0,0 +1,8 @@
+ALTER TABLE caldav_resources DROP CONSTRAINT IF EXISTS caldav_resources_task_category_id_name_key;
+
+-- A name used in several calendars keeps the resource of its oldest task, the others are named after their task again
+DELETE FROM caldav_resources r USING caldav_resources o WHERE r.name = o.name AND r.task_id > o.task_id;
+
+ALTER TABLE caldav_resources
+    DROP COLUMN IF EXISTS task_category_id,
+    ADD CONSTRAINT caldav_resources_name_key UNIQUE (name);
@@ -0,0 +1,10 @@
+-- The names of calendar objects are unique within their calendar, a resource belongs to the calendar the
+-- client created it in
+ALTER TABLE caldav_resources ADD COLUMN task_category_id INTEGER NULL REFERENCES task_categories(id) ON DELETE CASCADE;
+
+UPDATE caldav_resources r SET task_category_id = t.task_category_id FROM tasks t WHERE t.id = r.task_id;
+
+ALTER TABLE caldav_resources
+    ALTER COLUMN task_category_id SET NOT NULL,
+    DROP CONSTRAINT caldav_resources_name_key,
+    ADD CONSTRAINT caldav_resources_task_category_id_name_key UNIQUE (task_category_id, name);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS jobs_rate_key_idx;
+
+ALTER TABLE jobs DROP COLUMN rate_key;
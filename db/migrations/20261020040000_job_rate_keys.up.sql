@@ -0,0 +1,4 @@
+-- Jobs queued on behalf of a key, such as the address of an email, are counted per key to rate limit them
+ALTER TABLE jobs ADD COLUMN rate_key VARCHAR(255) NULL;
+
+CREATE INDEX jobs_rate_key_idx ON jobs (type, rate_key, created_at) WHERE rate_key IS NOT NULL;
@@ -0,0 +1,3 @@
+-- A new email of a user is kept apart until the link sent to it is opened, the user keeps logging in and
+-- receiving mails with their verified email until then
+ALTER TABLE users ADD COLUMN pending_email VARCHAR(255) NULL;
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Header carrying an API key
+const APIKeyHeader = "X-API-Key"
+
+// Prefix of the API keys, so leaked keys are easy to recognise
+const apiKeyPrefix = "tm_"
+
+var (
+	ErrUnauthenticated = errors.New("you are not logged in")
+	ErrInvalidAPIKey   = errors.New("the API key is invalid or revoked")
+	ErrForbidden       = errors.New("you do not have the permission to do this")
+)
+
+type authenticationKey struct{}
+
+// The outcome of the authentication of a request
+type authentication struct {
+	principal internalModels.Principal
+	err       error
+}
+
+// Stores the principal of a request in its context, or the error that prevented the authentication
+func WithAuthentication(ctx context.Context, principal internalModels.Principal, err error) context.Context {
+	return context.WithValue(ctx, authenticationKey{}, authentication{principal: principal, err: err})
+}
+
+// Gets the principal the request of a context was made by
+func CurrentPrincipal(ctx context.Context) (internalModels.Principal, error) {
+	auth, ok := ctx.Value(authenticationKey{}).(authentication)
+	if !ok {
+		return internalModels.Principal{}, ErrUnauthenticated
+	}
+	if auth.err != nil {
+		return auth.principal, auth.err
+	}
+	return auth.principal, nil
+}
+
+// Gets the user the request of a context was made by
+func CurrentUser(ctx context.Context) (*models.User, error) {
+	principal, err := CurrentPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return principal.User, nil
+}
+
+// Checks whether the role of the user the request of a context was made by holds a permission
+func HasPermission(ctx context.Context, permission internalModels.Permission) bool {
+	principal, err := CurrentPrincipal(ctx)
+	if err != nil {
+		return false
+	}
+	return hasPermission(principal.Permissions, permission)
+}
+
+// Returns ErrForbidden unless the user the request of a context was made by holds a permission
+func RequirePermission(ctx context.Context, permission internalModels.Permission) error {
+	if _, err := CurrentPrincipal(ctx); err != nil {
+		return err
+	}
+	if !HasPermission(ctx, permission) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Resolves the user of a request from its access token or its API key
+type AuthController struct {
+	TokenIssuer           *TokenIssuer
+	UserSessionController *UserSessionController
+	APIKeyRepository      *repositories.APIKeyRepository
+	UserRepository        *repositories.UserRepository
+	RoleController        *RoleController
+}
+
+func NewAuthController(tokenIssuer *TokenIssuer, userSessionController *UserSessionController, apiKeyRepository *repositories.APIKeyRepository, userRepository *repositories.UserRepository, roleController *RoleController) *AuthController {
+	return &AuthController{TokenIssuer: tokenIssuer, UserSessionController: userSessionController, APIKeyRepository: apiKeyRepository, UserRepository: userRepository, RoleController: roleController}
+}
+
+// Authenticates a request with the API key of the X-API-Key header, or with the access token of the
+// Authorization header or of the jwt cookie. Requests without credentials return jwtauth.ErrNoTokenFound.
+func (c *AuthController) Authenticate(r *http.Request, now time.Time, ctx context.Context) (internalModels.Principal, error) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		apiKey, err := c.APIKeyRepository.UseAPIKey(hashAPIKey(key), ctx)
+		if err != nil {
+			if err == repositories.ErrNoMatch {
+				return internalModels.Principal{}, ErrInvalidAPIKey
+			}
+			return internalModels.Principal{}, err
+		}
+		user, err := c.UserRepository.GetUserByID(apiKey.UserID, ctx)
+		if err != nil {
+			return internalModels.Principal{}, err
+		}
+		principal, err := c.NewPrincipal(user, internalModels.AuthMethodAPIKey, now, ctx)
+		principal.APIKeyID = apiKey.ID
+		return principal, err
+	}
+
+	tokenString := TokenFromRequest(r)
+	if tokenString == "" {
+		return internalModels.Principal{}, jwtauth.ErrNoTokenFound
+	}
+	token, err := c.TokenIssuer.Verify(tokenString, now)
+	if err != nil {
+		return internalModels.Principal{}, jwtauth.ErrorReason(err)
+	}
+	userID, _ := TokenUserID(token)
+	sessionID, _ := TokenSessionID(token)
+	if err := c.UserSessionController.CheckSession(sessionID, userID, now, ctx); err != nil {
+		if err == ErrSessionRevoked {
+			return internalModels.Principal{}, jwtauth.ErrUnauthorized
+		}
+		return internalModels.Principal{}, err
+	}
+	user, err := c.UserRepository.GetUserByID(userID, ctx)
+	if err != nil {
+		return internalModels.Principal{}, jwtauth.ErrUnauthorized
+	}
+	principal, err := c.NewPrincipal(user, internalModels.AuthMethodToken, now, ctx)
+	principal.SessionID = sessionID
+	return principal, err
+}
+
+func hasPermission(permissions []internalModels.Permission, permission internalModels.Permission) bool {
+	for _, held := range permissions {
+		if held == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Makes the principal of a user authenticated by a method, with the permissions of their role
+func (c *AuthController) NewPrincipal(user *models.User, method string, now time.Time, ctx context.Context) (internalModels.Principal, error) {
+	permissions, err := c.RoleController.GetRolePermissions(user.Role, now, ctx)
+	if err != nil {
+		return internalModels.Principal{}, err
+	}
+	return internalModels.Principal{User: user, Method: method, Permissions: permissions}, nil
+}
+
+// Creates an API key of a user. The returned key holds the key, which cannot be retrieved again.
+func (c *AuthController) CreateAPIKey(userID int, name string, ctx context.Context) (internalModels.APIKey, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return internalModels.APIKey{}, errors.New("the name of the API key must contain between 1 and 100 characters")
+	}
+	keyBytes := make([]byte, 32)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return internalModels.APIKey{}, err
+	}
+	key := apiKeyPrefix + hex.EncodeToString(keyBytes)
+	apiKey, err := c.APIKeyRepository.AddAPIKey(internalModels.APIKey{UserID: userID, Name: name}, hashAPIKey(key), ctx)
+	if err != nil {
+		return apiKey, err
+	}
+	apiKey.Key = key
+	return apiKey, nil
+}
+
+func (c *AuthController) GetAPIKeys(userID int, ctx context.Context) ([]internalModels.APIKey, error) {
+	return c.APIKeyRepository.GetAPIKeysOfUser(userID, ctx)
+}
+
+func (c *AuthController) RevokeAPIKey(apiKeyID, userID int, ctx context.Context) error {
+	return c.APIKeyRepository.RevokeAPIKey(apiKeyID, userID, ctx)
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
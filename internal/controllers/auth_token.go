@@ -0,0 +1,233 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+)
+
+// The algorithms the tokens can be signed with
+const (
+	TokenAlgorithmHS256 = "HS256"
+	TokenAlgorithmRS256 = "RS256"
+	TokenAlgorithmEdDSA = "EdDSA"
+)
+
+// HS256 secrets shorter than the size of the hash are rejected
+const minTokenSecretLength = 32
+
+// Claim of the access tokens holding the ID of their session
+const SessionIDClaim = "sid"
+
+var (
+	ErrTokenSubject = errors.New("the token has no valid subject")
+	ErrTokenSession = errors.New("the token has no valid session")
+)
+
+type TokenConfig struct {
+	Algorithm string
+	Issuer    string
+	// Audience of the access tokens, the other tokens are signed for their own audience
+	Audience string
+	TTL      time.Duration
+}
+
+// Signs the tokens with the current key and verifies them with the current key or the previous keys,
+// the key used is found with the kid header of the token
+type TokenIssuer struct {
+	config     TokenConfig
+	signingKey jwk.Key
+	keys       jwk.Set
+}
+
+// Makes a key of an algorithm from a secret for HS256 or from a PEM encoded key for RS256 and EdDSA.
+// The key ID is the thumbprint of the key when it is not given.
+func NewTokenKey(algorithm, keyID string, material []byte) (jwk.Key, error) {
+	var key jwk.Key
+	var err error
+	switch algorithm {
+	case TokenAlgorithmHS256:
+		if len(material) < minTokenSecretLength {
+			return nil, fmt.Errorf("the HS256 secret must contain at least %d characters", minTokenSecretLength)
+		}
+		key, err = jwk.FromRaw(material)
+	case TokenAlgorithmRS256, TokenAlgorithmEdDSA:
+		key, err = jwk.ParseKey(material, jwk.WithPEM(true))
+	default:
+		return nil, fmt.Errorf("the token algorithm must be one of %s, %s or %s", TokenAlgorithmHS256, TokenAlgorithmRS256, TokenAlgorithmEdDSA)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s key: %w", algorithm, err)
+	}
+	if !keyMatchesAlgorithm(key, algorithm) {
+		return nil, fmt.Errorf("the key is not a %s key", algorithm)
+	}
+	if err := key.Set(jwk.AlgorithmKey, jwa.SignatureAlgorithm(algorithm)); err != nil {
+		return nil, err
+	}
+	if keyID == "" {
+		return key, jwk.AssignKeyID(key)
+	}
+	return key, key.Set(jwk.KeyIDKey, keyID)
+}
+
+func keyMatchesAlgorithm(key jwk.Key, algorithm string) bool {
+	switch key.(type) {
+	case jwk.SymmetricKey:
+		return algorithm == TokenAlgorithmHS256
+	case jwk.RSAPrivateKey, jwk.RSAPublicKey:
+		return algorithm == TokenAlgorithmRS256
+	case jwk.OKPPrivateKey, jwk.OKPPublicKey:
+		return algorithm == TokenAlgorithmEdDSA
+	}
+	return false
+}
+
+// Makes an issuer signing with a private or secret key. The previous keys only verify the tokens
+// signed before a rotation, until they expire.
+func NewTokenIssuer(config TokenConfig, signingKey jwk.Key, previousKeys ...jwk.Key) (*TokenIssuer, error) {
+	if config.Issuer == "" || config.Audience == "" {
+		return nil, errors.New("the token issuer and audience are required")
+	}
+	if config.TTL <= 0 {
+		return nil, errors.New("the token lifetime must be positive")
+	}
+	switch signingKey.(type) {
+	case jwk.SymmetricKey, jwk.RSAPrivateKey, jwk.OKPPrivateKey:
+	default:
+		return nil, errors.New("the signing key must be a secret or a private key")
+	}
+	keys := jwk.NewSet()
+	for _, key := range append([]jwk.Key{signingKey}, previousKeys...) {
+		if _, ok := keys.LookupKeyID(key.KeyID()); ok {
+			return nil, fmt.Errorf("the key ID %q is used by more than one key", key.KeyID())
+		}
+		publicKey, err := jwk.PublicKeyOf(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := keys.AddKey(publicKey); err != nil {
+			return nil, err
+		}
+	}
+	return &TokenIssuer{config: config, signingKey: signingKey, keys: keys}, nil
+}
+
+func (i *TokenIssuer) TTL() time.Duration {
+	return i.config.TTL
+}
+
+// Issues an access token of a session identifying a user by their ID, it never contains their credentials
+func (i *TokenIssuer) Issue(user *models.User, sessionID int, now time.Time) (string, error) {
+	return i.Sign(map[string]interface{}{
+		jwt.SubjectKey: strconv.Itoa(user.ID),
+		"role":         user.Role,
+		SessionIDClaim: sessionID,
+	}, i.config.Audience, i.config.TTL, now)
+}
+
+// Signs a token with the given claims for an audience, the registered claims are set by the issuer
+func (i *TokenIssuer) Sign(claims map[string]interface{}, audience string, ttl time.Duration, now time.Time) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+	token := jwt.New()
+	for name, value := range claims {
+		if err := token.Set(name, value); err != nil {
+			return "", err
+		}
+	}
+	registered := map[string]interface{}{
+		jwt.IssuerKey:     i.config.Issuer,
+		jwt.AudienceKey:   audience,
+		jwt.IssuedAtKey:   now,
+		jwt.ExpirationKey: now.Add(ttl),
+		jwt.JwtIDKey:      hex.EncodeToString(jti),
+	}
+	for name, value := range registered {
+		if err := token.Set(name, value); err != nil {
+			return "", err
+		}
+	}
+	signed, err := jwt.Sign(token, jwt.WithKey(i.signingKey.Algorithm(), i.signingKey))
+	if err != nil {
+		return "", err
+	}
+	return string(signed), nil
+}
+
+// Parses a token signed by one of the keys for an audience, expired tokens are rejected
+func (i *TokenIssuer) Parse(tokenString, audience string, now time.Time) (jwt.Token, error) {
+	return jwt.ParseString(tokenString,
+		jwt.WithKeySet(i.keys),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(i.config.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithClock(jwt.ClockFunc(func() time.Time { return now })),
+	)
+}
+
+// Verifies an access token
+func (i *TokenIssuer) Verify(tokenString string, now time.Time) (jwt.Token, error) {
+	token, err := i.Parse(tokenString, i.config.Audience, now)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := TokenUserID(token); err != nil {
+		return nil, err
+	}
+	if _, err := TokenSessionID(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+// Gets the public keys verifying the tokens, there are none when the tokens are signed with a secret
+func (i *TokenIssuer) PublicKeys() jwk.Set {
+	publicKeys := jwk.NewSet()
+	for index := 0; index < i.keys.Len(); index++ {
+		key, _ := i.keys.Key(index)
+		if _, ok := key.(jwk.SymmetricKey); !ok {
+			publicKeys.AddKey(key)
+		}
+	}
+	return publicKeys
+}
+
+// Gets the ID of the user identified by the subject of a token
+func TokenUserID(token jwt.Token) (int, error) {
+	userID, err := strconv.Atoi(token.Subject())
+	if err != nil || userID <= 0 {
+		return 0, ErrTokenSubject
+	}
+	return userID, nil
+}
+
+// Gets the ID of the session of an access token
+func TokenSessionID(token jwt.Token) (int, error) {
+	sessionID, ok := token.PrivateClaims()[SessionIDClaim].(float64)
+	if !ok || sessionID <= 0 || sessionID != float64(int(sessionID)) {
+		return 0, ErrTokenSession
+	}
+	return int(sessionID), nil
+}
+
+// Gets the token of the Authorization header, or of the jwt cookie
+func TokenFromRequest(r *http.Request) string {
+	if tokenString := jwtauth.TokenFromHeader(r); tokenString != "" {
+		return tokenString
+	}
+	return jwtauth.TokenFromCookie(r)
+}
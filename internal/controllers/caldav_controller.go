@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+// ErrCalDAVForbidden is returned when a CalDAV user may not create or delete tasks
+var ErrCalDAVForbidden = errors.New("you are not the manager, cannot create or delete tasks")
+
+type CalDAVController struct {
+	TaskController         *TaskController
+	MentionController      *MentionController
+	TaskCategoryRepository *repositories.TaskCategoryRepository
+	CalDAVRepository       *repositories.CalDAVRepository
+}
+
+func NewCalDAVController(taskController *TaskController, mentionController *MentionController, taskCategoryRepository *repositories.TaskCategoryRepository, calDAVRepository *repositories.CalDAVRepository) *CalDAVController {
+	return &CalDAVController{
+		TaskController:         taskController,
+		MentionController:      mentionController,
+		TaskCategoryRepository: taskCategoryRepository,
+		CalDAVRepository:       calDAVRepository,
+	}
+}
+
+func (c *CalDAVController) GetCalendar(taskCategoryID int, ctx context.Context) (*models.TaskCategory, error) {
+	taskCategory, err := c.TaskCategoryRepository.GetTaskCategoryByID(taskCategoryID, ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return taskCategory, repositories.ErrNoMatch
+		}
+		return taskCategory, err
+	}
+	return taskCategory, nil
+}
+
+// Gets the tasks of a category as calendar objects
+func (c *CalDAVController) GetCalendarObjects(taskCategoryID int, ctx context.Context) ([]internalModels.CalDAVObject, error) {
+	tasks, err := c.TaskCategoryRepository.GetTasksByCategory(taskCategoryID, ctx)
+	if err != nil && err != repositories.ErrNoMatch {
+		return nil, err
+	}
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	resources, err := c.CalDAVRepository.GetCalDAVResources(taskCategoryID, taskIDs, ctx)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]internalModels.CalDAVObject, 0, len(tasks))
+	for _, task := range tasks {
+		objects = append(objects, newCalDAVObject(task, resources[task.ID]))
+	}
+	return objects, nil
+}
+
+// Gets a calendar object by the name in its URL, without the .ics extension
+func (c *CalDAVController) GetCalendarObject(taskCategoryID int, name string, ctx context.Context) (internalModels.CalDAVObject, error) {
+	resource, err := c.CalDAVRepository.GetCalDAVResourceByName(taskCategoryID, name, ctx)
+	if err != nil && err != repositories.ErrNoMatch {
+		return internalModels.CalDAVObject{}, err
+	}
+	taskID := resource.TaskID
+	if err == repositories.ErrNoMatch {
+		// Tasks that were not created through CalDAV are named after their ID
+		taskID, err = ParseCalDAVObjectName(name)
+		if err != nil {
+			return internalModels.CalDAVObject{}, repositories.ErrNoMatch
+		}
+	}
+	task, err := c.TaskController.GetTaskByID(taskID, ctx)
+	if err != nil {
+		return internalModels.CalDAVObject{}, err
+	}
+	if task.TaskCategoryID != taskCategoryID {
+		return internalModels.CalDAVObject{}, repositories.ErrNoMatch
+	}
+	return newCalDAVObject(task, resource), nil
+}
+
+// Creates or updates the task of a calendar object from a VTODO. Only managers can create tasks, and an
+// update follows the task policy.
+func (c *CalDAVController) PutCalendarObject(taskCategoryID int, name, data string, actor internalModels.TaskActor, ctx context.Context) (internalModels.CalDAVObject, bool, error) {
+	userID := actor.UserID
+	todos, err := utils.ParseICalComponents(data, internalModels.CalendarComponentTodo)
+	if err != nil {
+		return internalModels.CalDAVObject{}, false, err
+	}
+	if len(todos) != 1 {
+		return internalModels.CalDAVObject{}, false, errors.New("the calendar object must contain exactly one VTODO")
+	}
+
+	object, err := c.GetCalendarObject(taskCategoryID, name, ctx)
+	if err != nil && err != repositories.ErrNoMatch {
+		return object, false, err
+	}
+	created := err == repositories.ErrNoMatch
+	var task *models.Task
+	if created {
+		if !actor.Manager {
+			return object, false, ErrCalDAVForbidden
+		}
+		task = &models.Task{
+			AuthorID:       userID,
+			TaskCategoryID: taskCategoryID,
+			Status:         null.StringFrom(string(repositories.NotStarted)),
+		}
+		uid, err := ApplyVTODO(todos[0], task)
+		if err != nil {
+			return object, false, err
+		}
+		// Same as the tasks added through the API, the author is assigned to the task
+		if err := c.CalDAVRepository.AddCalDAVTask(task, &internalModels.CalDAVResource{Name: name, UID: uid}, ctx); err != nil {
+			return object, false, err
+		}
+	} else {
+		taskData := *object.Task
+		if _, err := ApplyVTODO(todos[0], &taskData); err != nil {
+			return object, false, err
+		}
+		task, err = c.TaskController.UpdateTask(object.Task.ID, taskData, actor, ctx)
+		if err != nil {
+			return object, false, err
+		}
+	}
+	// The task is already saved, so mentions that cannot be recorded are only logged
+	if _, err := c.MentionController.ProcessTaskMentions(task, userID, ctx); err != nil {
+		log.Printf("Could not process the mentions of task %d: %v\n", task.ID, err)
+	}
+
+	// Read the task again so the ETag matches what a GET returns
+	object, err = c.GetCalendarObject(taskCategoryID, name, ctx)
+	return object, created, err
+}
+
+// Moves the task of a calendar object to the trash. Only managers can delete tasks.
+func (c *CalDAVController) DeleteCalendarObject(taskCategoryID int, name string, userID int, isManager bool, ctx context.Context) error {
+	if !isManager {
+		return ErrCalDAVForbidden
+	}
+	object, err := c.GetCalendarObject(taskCategoryID, name, ctx)
+	if err != nil {
+		return err
+	}
+	err = c.TaskController.DeleteTask(object.Task.ID, userID, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+var calDAVObjectNameRegex = regexp.MustCompile(`^task-([0-9]+)$`)
+
+// Gets the task ID from the name of a calendar object of a task that was not created through CalDAV
+func ParseCalDAVObjectName(name string) (int, error) {
+	match := calDAVObjectNameRegex.FindStringSubmatch(name)
+	if match == nil {
+		return 0, fmt.Errorf("invalid calendar object name %q", name)
+	}
+	return strconv.Atoi(match[1])
+}
+
+func newCalDAVObject(task *models.Task, resource internalModels.CalDAVResource) internalModels.CalDAVObject {
+	object := internalModels.CalDAVObject{Name: resource.Name, UID: resource.UID, Task: task}
+	if object.Name == "" {
+		object.Name = fmt.Sprintf("task-%d", task.ID)
+	}
+	if object.UID == "" {
+		object.UID = taskCalendarUID(task.ID)
+	}
+	object.Data = renderTaskCalendar(object.Name, []models.Task{*task}, internalModels.CalendarComponentTodo, map[int]string{task.ID: object.UID})
+	sum := sha256.Sum256([]byte(object.Data))
+	object.ETag = `"` + hex.EncodeToString(sum[:16]) + `"`
+	return object
+}
+
+// Maps the status of a VTODO to the status of a task
+var taskStatusesOfTodo = map[string]string{
+	"NEEDS-ACTION": string(repositories.NotStarted),
+	"IN-PROCESS":   string(repositories.InProgress),
+	"COMPLETED":    string(repositories.Complete),
+}
+
+// Copies the properties of a VTODO to a task and returns its UID. Properties that are missing keep
+// the value of the task, and a locked task stays locked since the lock is not part of iCalendar.
+func ApplyVTODO(props []utils.ICalProperty, task *models.Task) (string, error) {
+	var uid string
+	hasDue := false
+	for _, prop := range props {
+		switch prop.Name {
+		case "UID":
+			uid = utils.UnescapeICalText(prop.Value)
+		case "SUMMARY":
+			task.Name = strings.TrimSpace(utils.UnescapeICalText(prop.Value))
+		case "DESCRIPTION":
+			task.Description = utils.UnescapeICalText(prop.Value)
+		case "DTSTART", "DUE":
+			value, err := utils.ParseICalTime(prop)
+			if err != nil {
+				return uid, fmt.Errorf("invalid %s: %w", prop.Name, err)
+			}
+			if prop.Name == "DTSTART" {
+				task.StartDate = value
+			} else {
+				task.EndDate = value
+				hasDue = true
+			}
+		case "STATUS":
+			status, ok := taskStatusesOfTodo[strings.ToUpper(prop.Value)]
+			if ok && task.Status.String != string(repositories.Lock) {
+				task.Status = null.StringFrom(status)
+			}
+		}
+	}
+	if task.Name == "" {
+		return uid, errors.New("the VTODO must have a SUMMARY")
+	}
+	// Tasks always have dates, a to-do without them starts and is due now
+	if task.StartDate.IsZero() {
+		task.StartDate = time.Now()
+	}
+	if !hasDue && task.EndDate.IsZero() {
+		task.EndDate = task.StartDate
+	}
+	return uid, nil
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+type CalendarFeedController struct {
+	CalendarFeedRepository   *repositories.CalendarFeedRepository
+	UserTaskDetailRepository *repositories.UserTaskDetailRepository
+	TaskCategoryRepository   *repositories.TaskCategoryRepository
+	UserRepository           *repositories.UserRepository
+	RoleController           *RoleController
+}
+
+func NewCalendarFeedController(calendarFeedRepository *repositories.CalendarFeedRepository, userTaskDetailRepository *repositories.UserTaskDetailRepository, taskCategoryRepository *repositories.TaskCategoryRepository, userRepository *repositories.UserRepository, roleController *RoleController) *CalendarFeedController {
+	return &CalendarFeedController{
+		CalendarFeedRepository:   calendarFeedRepository,
+		UserTaskDetailRepository: userTaskDetailRepository,
+		TaskCategoryRepository:   taskCategoryRepository,
+		UserRepository:           userRepository,
+		RoleController:           roleController,
+	}
+}
+
+// Creates a feed of the tasks assigned to a user, or of the tasks of a category when a manager gives one.
+// The returned feed holds the token, which cannot be retrieved again.
+func (c *CalendarFeedController) CreateCalendarFeed(userID int, taskCategoryID null.Int, isManager bool, ctx context.Context) (internalModels.CalendarFeed, error) {
+	feed := internalModels.CalendarFeed{UserID: userID, TaskCategoryID: taskCategoryID}
+	if taskCategoryID.Valid {
+		if !isManager {
+			return feed, errors.New("you are not the manager, cannot create a feed of a task category")
+		}
+		if _, err := c.TaskCategoryRepository.GetTaskCategoryByID(taskCategoryID.Int, ctx); err != nil {
+			if err == sql.ErrNoRows {
+				return feed, repositories.ErrNoMatch
+			}
+			return feed, err
+		}
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return feed, err
+	}
+	token := hex.EncodeToString(tokenBytes)
+	if err := c.CalendarFeedRepository.AddCalendarFeed(&feed, hashCalendarToken(token), ctx); err != nil {
+		return feed, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+func (c *CalendarFeedController) GetCalendarFeeds(userID int, ctx context.Context) ([]internalModels.CalendarFeed, error) {
+	feeds, err := c.CalendarFeedRepository.GetCalendarFeedsOfUser(userID, ctx)
+	if err != nil {
+		return feeds, err
+	}
+	return feeds, nil
+}
+
+func (c *CalendarFeedController) RevokeCalendarFeed(feedID, userID int, ctx context.Context) error {
+	err := c.CalendarFeedRepository.RevokeCalendarFeed(feedID, userID, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Gets the feed of a token and the tasks it serves, unknown and revoked tokens return ErrNoMatch
+func (c *CalendarFeedController) GetCalendarFeedTasks(token string, ctx context.Context) (internalModels.CalendarFeed, []models.Task, error) {
+	feed, err := c.CalendarFeedRepository.GetActiveCalendarFeedByTokenHash(hashCalendarToken(token), ctx)
+	if err != nil {
+		return feed, nil, err
+	}
+	if !feed.TaskCategoryID.Valid {
+		tasks, err := c.UserTaskDetailRepository.GetAllTaskAssignedToUser(feed.UserID)
+		return feed, tasks, err
+	}
+
+	// A feed of a task category stops working when the role of its owner no longer sees every task
+	user, err := c.UserRepository.GetUserByID(feed.UserID, ctx)
+	if err != nil {
+		return feed, nil, err
+	}
+	permissions, err := c.RoleController.GetRolePermissions(user.Role, time.Now(), ctx)
+	if err != nil {
+		return feed, nil, err
+	}
+	if !hasPermission(permissions, internalModels.PermissionTaskViewAll) {
+		return feed, nil, repositories.ErrNoMatch
+	}
+	taskSlice, err := c.TaskCategoryRepository.GetTasksByCategory(feed.TaskCategoryID.Int, ctx)
+	if err != nil && err != repositories.ErrNoMatch {
+		return feed, nil, err
+	}
+	tasks := make([]models.Task, 0, len(taskSlice))
+	for _, task := range taskSlice {
+		tasks = append(tasks, *task)
+	}
+	return feed, tasks, nil
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Gets the last time the content of a feed changed
+func CalendarLastModified(feed internalModels.CalendarFeed, tasks []models.Task) time.Time {
+	lastModified := feed.CreatedAt
+	for _, task := range tasks {
+		if task.UpdatedAt.After(lastModified) {
+			lastModified = task.UpdatedAt
+		}
+	}
+	return lastModified
+}
+
+// Maps the status of a task to the status of a VTODO, locked tasks have no matching status
+var calendarTodoStatuses = map[string]string{
+	string(repositories.NotStarted): "NEEDS-ACTION",
+	string(repositories.InProgress): "IN-PROCESS",
+	string(repositories.Complete):   "COMPLETED",
+}
+
+// Renders tasks as an iCalendar document with one VTODO or VEVENT per task
+func RenderTaskCalendar(name string, tasks []models.Task, component string) string {
+	return renderTaskCalendar(name, tasks, component, nil)
+}
+
+// Gets the UID of a task that was not created by a CalDAV client
+func taskCalendarUID(taskID int) string {
+	return fmt.Sprintf("task-%d@task-management-app", taskID)
+}
+
+// Same as RenderTaskCalendar but uses the given UIDs for the tasks that have one
+func renderTaskCalendar(name string, tasks []models.Task, component string, uids map[int]string) string {
+	sorted := make([]models.Task, len(tasks))
+	copy(sorted, tasks)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	cal := &utils.ICalendar{}
+	cal.Begin("VCALENDAR")
+	cal.Property("VERSION", "2.0")
+	cal.Property("PRODID", "-//task-management-app//Tasks//EN")
+	cal.Property("CALSCALE", "GREGORIAN")
+	cal.Text("X-WR-CALNAME", name)
+	for _, task := range sorted {
+		cal.Begin(component)
+		// The UID only depends on the task, so clients update the same item when the task changes
+		uid, ok := uids[task.ID]
+		if !ok {
+			uid = taskCalendarUID(task.ID)
+		}
+		cal.Text("UID", uid)
+		cal.Time("DTSTAMP", task.UpdatedAt)
+		cal.Time("CREATED", task.CreatedAt)
+		cal.Time("LAST-MODIFIED", task.UpdatedAt)
+		cal.Text("SUMMARY", task.Name)
+		if task.Description != "" {
+			cal.Text("DESCRIPTION", task.Description)
+		}
+		if !task.EndDate.Before(task.StartDate) {
+			cal.Time("DTSTART", task.StartDate)
+		}
+		if component == internalModels.CalendarComponentEvent {
+			cal.Time("DTEND", task.EndDate)
+		} else {
+			cal.Time("DUE", task.EndDate)
+			if status, ok := calendarTodoStatuses[task.Status.String]; ok {
+				cal.Property("STATUS", status)
+			}
+			if task.Status.String == string(repositories.Complete) {
+				cal.Time("COMPLETED", task.UpdatedAt)
+				cal.Property("PERCENT-COMPLETE", "100")
+			}
+		}
+		cal.End(component)
+	}
+	cal.End("VCALENDAR")
+	return cal.String()
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+type ChecklistController struct {
+	ChecklistRepository *repositories.ChecklistRepository
+	TaskRepository      *repositories.TaskRepository
+}
+
+func NewChecklistController(checklistRepository *repositories.ChecklistRepository, taskRepository *repositories.TaskRepository) *ChecklistController {
+	return &ChecklistController{ChecklistRepository: checklistRepository, TaskRepository: taskRepository}
+}
+
+func (c *ChecklistController) GetChecklistItems(taskID int, ctx context.Context) ([]models.ChecklistItem, error) {
+	// Make sure the task exists and is not in the trash
+	if _, err := c.TaskRepository.GetTaskByID(taskID, ctx); err != nil {
+		return nil, err
+	}
+	items, err := c.ChecklistRepository.GetChecklistItems(taskID, ctx)
+	if err != nil {
+		return items, err
+	}
+	return items, nil
+}
+
+func (c *ChecklistController) AddChecklistItem(item *models.ChecklistItem, ctx context.Context) error {
+	item.Text = strings.TrimSpace(item.Text)
+	if item.Text == "" {
+		return errors.New("the text of the checklist item is required")
+	}
+	if _, err := c.TaskRepository.GetTaskByID(item.TaskID, ctx); err != nil {
+		return err
+	}
+	err := c.ChecklistRepository.AddChecklistItem(item, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *ChecklistController) ToggleChecklistItem(taskID, itemID int, ctx context.Context) (models.ChecklistItem, error) {
+	item, err := c.ChecklistRepository.ToggleChecklistItem(taskID, itemID, ctx)
+	if err != nil {
+		return item, err
+	}
+	return item, nil
+}
+
+func (c *ChecklistController) ReorderChecklistItems(taskID int, itemIDs []int, ctx context.Context) ([]models.ChecklistItem, error) {
+	if _, err := c.TaskRepository.GetTaskByID(taskID, ctx); err != nil {
+		return nil, err
+	}
+	if err := c.ChecklistRepository.ReorderChecklistItems(taskID, itemIDs, ctx); err != nil {
+		return nil, err
+	}
+	return c.ChecklistRepository.GetChecklistItems(taskID, ctx)
+}
+
+func (c *ChecklistController) DeleteChecklistItem(taskID, itemID int, ctx context.Context) error {
+	err := c.ChecklistRepository.DeleteChecklistItem(taskID, itemID, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,517 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+// Fields read from an imported tasks file, the default headers are the field names
+var TaskImportFields = []string{"name", "description", "start_date", "end_date", "status", "author_id", "task_category_id"}
+
+// Fields read from a tasks file imported as an upsert, the task category can be given by its external ID
+var TaskUpsertFields = []string{"external_id", "name", "description", "start_date", "end_date", "status", "author_id", "task_category_id", "task_category_external_id"}
+
+// Fields read from an imported task categories file
+var TaskCategoryImportFields = []string{"name"}
+
+// Fields read from a task categories file imported as an upsert
+var TaskCategoryUpsertFields = []string{"external_id", "name"}
+
+// Job types of the imports that run in the background
+const (
+	JobTypeImportTasks          = "import_tasks"
+	JobTypeImportTaskCategories = "import_task_categories"
+)
+
+// Limits of the import jobs, an import that cannot be written usually fails again
+const (
+	importJobMaxAttempts = 3
+	importJobTimeout     = 10 * time.Minute
+)
+
+// Layouts accepted for the dates of imported tasks
+var importDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+type CSVImportController struct {
+	TaskRepository         *repositories.TaskRepository
+	TaskCategoryRepository *repositories.TaskCategoryRepository
+	UserRepository         *repositories.UserRepository
+}
+
+func NewCSVImportController(taskRepository *repositories.TaskRepository, taskCategoryRepository *repositories.TaskCategoryRepository, userRepository *repositories.UserRepository) *CSVImportController {
+	return &CSVImportController{TaskRepository: taskRepository, TaskCategoryRepository: taskCategoryRepository, UserRepository: userRepository}
+}
+
+// Registers the job types running imports in the background, their result is the import report
+func (c *CSVImportController) RegisterJobs(registry *JobRegistry) {
+	registry.Register(JobTypeImportTasks, func(ctx context.Context, job internalModels.Job, progress func(int)) (interface{}, error) {
+		payload := internalModels.ImportJob{}
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return c.ImportTasks(strings.NewReader(payload.Data), payload.Options, payload.ImporterID, ctx)
+	}, importJobMaxAttempts, importJobTimeout)
+	registry.Register(JobTypeImportTaskCategories, func(ctx context.Context, job internalModels.Job, progress func(int)) (interface{}, error) {
+		payload := internalModels.ImportJob{}
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return c.ImportTaskCategories(strings.NewReader(payload.Data), payload.Options, ctx)
+	}, importJobMaxAttempts, importJobTimeout)
+}
+
+// Validates every row of a tasks file and writes the tasks in a single transaction when all rows are valid.
+// Rows without an author are written by the importing user. The returned error is only set when the
+// options are invalid, the file cannot be read or the tasks cannot be written, problems in rows are listed
+// in the report.
+func (c *CSVImportController) ImportTasks(reader io.Reader, options internalModels.ImportOptions, importerID int, ctx context.Context) (internalModels.ImportReport, error) {
+	report := internalModels.ImportReport{DryRun: options.DryRun, Errors: []internalModels.ImportRowError{}}
+	upsert, err := validateImportOptions(options)
+	if err != nil {
+		return report, err
+	}
+	fields := TaskImportFields
+	if upsert {
+		fields = TaskUpsertFields
+	}
+	rows, err := utils.ReadCSVRows(reader, fields, options.Mapping)
+	if err != nil {
+		return report, err
+	}
+	report.TotalRows = len(rows)
+
+	tasks := make([]*models.Task, 0, len(rows))
+	for _, row := range rows {
+		task, rowErrors := ParseTaskImportRow(row, importerID)
+		report.Errors = append(report.Errors, rowErrors...)
+		tasks = append(tasks, task)
+	}
+	if upsert {
+		if err := c.resolveTaskCategoryExternalIDs(rows, tasks, options.ExternalSource, &report, ctx); err != nil {
+			return report, err
+		}
+	}
+	if err := c.checkTaskReferences(rows, tasks, &report, ctx); err != nil {
+		return report, err
+	}
+	if upsert {
+		return c.upsertTasks(rows, tasks, options, report, ctx)
+	}
+
+	report.ValidRows = report.TotalRows - countErrorRows(report.Errors)
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+	report.Created = len(tasks)
+	if options.DryRun {
+		return report, nil
+	}
+	if err := c.TaskRepository.AddTasks(tasks, ctx); err != nil {
+		return report, err
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// Checks that the authors and task categories of the tasks exist, with one query each
+func (c *CSVImportController) checkTaskReferences(rows []utils.CSVRow, tasks []*models.Task, report *internalModels.ImportReport, ctx context.Context) error {
+	authorIDs := make([]int, 0, len(tasks))
+	taskCategoryIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		authorIDs = append(authorIDs, task.AuthorID)
+		taskCategoryIDs = append(taskCategoryIDs, task.TaskCategoryID)
+	}
+	existingAuthors, err := c.UserRepository.GetExistingUserIDs(uniqueIDs(authorIDs), ctx)
+	if err != nil {
+		return err
+	}
+	existingTaskCategories, err := c.TaskCategoryRepository.GetExistingTaskCategoryIDs(uniqueIDs(taskCategoryIDs), ctx)
+	if err != nil {
+		return err
+	}
+	for i, task := range tasks {
+		if task.AuthorID != 0 && !existingAuthors[task.AuthorID] {
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: rows[i].Number, Column: "author_id", Message: fmt.Sprintf("user %d does not exist", task.AuthorID)})
+		}
+		if task.TaskCategoryID != 0 && !existingTaskCategories[task.TaskCategoryID] {
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: rows[i].Number, Column: "task_category_id", Message: fmt.Sprintf("task category %d does not exist", task.TaskCategoryID)})
+		}
+	}
+	return nil
+}
+
+// Sets the task category of the rows that give it by the external ID of a task category of the same source
+func (c *CSVImportController) resolveTaskCategoryExternalIDs(rows []utils.CSVRow, tasks []*models.Task, source string, report *internalModels.ImportReport, ctx context.Context) error {
+	taskCategories, err := c.TaskCategoryRepository.GetTaskCategoriesByExternalSource(source, ctx)
+	if err != nil {
+		return err
+	}
+	taskCategoryIDs := make(map[string]int, len(taskCategories))
+	for _, taskCategory := range taskCategories {
+		taskCategoryIDs[taskCategory.ExternalID.String] = taskCategory.ID
+	}
+	for i, row := range rows {
+		externalID := row.Values["task_category_external_id"]
+		if externalID == "" {
+			continue
+		}
+		if row.Values["task_category_id"] != "" {
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "task_category_external_id", Message: "give either the task category ID or its external ID"})
+			continue
+		}
+		taskCategoryID, ok := taskCategoryIDs[externalID]
+		if !ok {
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "task_category_external_id", Message: fmt.Sprintf("task category with external ID %q does not exist", externalID)})
+			continue
+		}
+		tasks[i].TaskCategoryID = taskCategoryID
+	}
+	return nil
+}
+
+// Matches the tasks to the tasks of the same external source, then inserts the new ones, updates the
+// changed ones and archives the ones missing from the file when asked. Tasks in the trash are left
+// untouched and counted as unchanged.
+func (c *CSVImportController) upsertTasks(rows []utils.CSVRow, tasks []*models.Task, options internalModels.ImportOptions, report internalModels.ImportReport, ctx context.Context) (internalModels.ImportReport, error) {
+	existing, err := c.TaskRepository.GetTasksByExternalSource(options.ExternalSource, ctx)
+	if err != nil {
+		return report, err
+	}
+	trashed, err := c.TaskRepository.GetTrashedTaskExternalIDs(options.ExternalSource, ctx)
+	if err != nil {
+		return report, err
+	}
+	existingByExternalID := make(map[string]*models.Task, len(existing))
+	for _, task := range existing {
+		existingByExternalID[task.ExternalID.String] = task
+	}
+
+	seen := make(map[string]int, len(rows))
+	changed := []*models.Task{}
+	for i, row := range rows {
+		externalID, ok := checkImportExternalID(row, seen, &report)
+		if !ok {
+			continue
+		}
+		task := tasks[i]
+		task.ExternalSource = null.StringFrom(options.ExternalSource)
+		task.ExternalID = null.StringFrom(externalID)
+		current, ok := existingByExternalID[externalID]
+		switch {
+		case trashed[externalID]:
+			report.Unchanged++
+		case !ok:
+			report.Created++
+			changed = append(changed, task)
+		default:
+			merged := MergeImportedTask(current, task, row, options.ArchiveMissing)
+			if ImportedTaskChanged(current, merged) {
+				report.Updated++
+				changed = append(changed, merged)
+			} else {
+				report.Unchanged++
+			}
+		}
+	}
+	archiveTaskIDs := []int{}
+	if options.ArchiveMissing {
+		for _, task := range existing {
+			if _, ok := seen[task.ExternalID.String]; !ok && !task.ArchivedAt.Valid {
+				archiveTaskIDs = append(archiveTaskIDs, task.ID)
+			}
+		}
+		report.Archived = len(archiveTaskIDs)
+	}
+
+	report.ValidRows = report.TotalRows - countErrorRows(report.Errors)
+	if len(report.Errors) > 0 {
+		report.Created, report.Updated, report.Unchanged, report.Archived = 0, 0, 0, 0
+		return report, nil
+	}
+	if options.DryRun {
+		return report, nil
+	}
+	if err := c.TaskRepository.UpsertTasks(changed, archiveTaskIDs, ctx); err != nil {
+		return report, err
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// Validates every row of a task categories file and writes the task categories in a single transaction
+// when all rows are valid. A name cannot be used twice in the file or by another task category.
+func (c *CSVImportController) ImportTaskCategories(reader io.Reader, options internalModels.ImportOptions, ctx context.Context) (internalModels.ImportReport, error) {
+	report := internalModels.ImportReport{DryRun: options.DryRun, Errors: []internalModels.ImportRowError{}}
+	upsert, err := validateImportOptions(options)
+	if err != nil {
+		return report, err
+	}
+	if options.ArchiveMissing {
+		return report, errors.New("only tasks can be archived")
+	}
+	fields := TaskCategoryImportFields
+	if upsert {
+		fields = TaskCategoryUpsertFields
+	}
+	rows, err := utils.ReadCSVRows(reader, fields, options.Mapping)
+	if err != nil {
+		return report, err
+	}
+	report.TotalRows = len(rows)
+
+	existing, err := c.TaskCategoryRepository.GetAllTaskCategories(ctx)
+	if err != nil {
+		return report, err
+	}
+	existingNames := make(map[string]int, len(existing))
+	for _, taskCategory := range existing {
+		existingNames[strings.ToLower(taskCategory.Name)] = taskCategory.ID
+	}
+	existingByExternalID := make(map[string]*models.TaskCategory)
+	trashed := make(map[string]bool)
+	if upsert {
+		for _, taskCategory := range existing {
+			if taskCategory.ExternalSource.String == options.ExternalSource {
+				existingByExternalID[taskCategory.ExternalID.String] = taskCategory
+			}
+		}
+		trashed, err = c.TaskCategoryRepository.GetTrashedTaskCategoryExternalIDs(options.ExternalSource, ctx)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	fileNames := make(map[string]int, len(rows))
+	seen := make(map[string]int, len(rows))
+	changed := []*models.TaskCategory{}
+	for _, row := range rows {
+		taskCategory := &models.TaskCategory{Name: row.Values["name"]}
+		var current *models.TaskCategory
+		if upsert {
+			externalID, ok := checkImportExternalID(row, seen, &report)
+			if !ok {
+				continue
+			}
+			taskCategory.ExternalSource = null.StringFrom(options.ExternalSource)
+			taskCategory.ExternalID = null.StringFrom(externalID)
+			if trashed[externalID] {
+				report.Unchanged++
+				continue
+			}
+			current = existingByExternalID[externalID]
+		}
+
+		name := taskCategory.Name
+		key := strings.ToLower(name)
+		ownerID, usedByExisting := existingNames[key]
+		previousRow, usedInFile := fileNames[key]
+		switch {
+		case name == "":
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "name", Message: "the name is required"})
+			continue
+		case usedInFile:
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "name", Message: fmt.Sprintf("task category %q is already in row %d", name, previousRow)})
+			continue
+		case usedByExisting && (current == nil || current.ID != ownerID):
+			report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "name", Message: fmt.Sprintf("task category %q already exists", name)})
+			continue
+		}
+		fileNames[key] = row.Number
+
+		switch {
+		case current == nil:
+			report.Created++
+			changed = append(changed, taskCategory)
+		case current.Name != name:
+			report.Updated++
+			merged := *current
+			merged.Name = name
+			changed = append(changed, &merged)
+		default:
+			report.Unchanged++
+		}
+	}
+
+	report.ValidRows = report.TotalRows - countErrorRows(report.Errors)
+	if len(report.Errors) > 0 {
+		report.Created, report.Updated, report.Unchanged = 0, 0, 0
+		return report, nil
+	}
+	if options.DryRun {
+		return report, nil
+	}
+	if upsert {
+		err = c.TaskCategoryRepository.UpsertTaskCategories(changed, ctx)
+	} else {
+		err = c.TaskCategoryRepository.AddTaskCategories(changed, ctx)
+	}
+	if err != nil {
+		return report, err
+	}
+	report.Committed = true
+	return report, nil
+}
+
+// Checks the mode of an import and tells if it is an upsert
+func validateImportOptions(options internalModels.ImportOptions) (bool, error) {
+	switch options.Mode {
+	case "", internalModels.ImportModeCreate:
+		if options.ArchiveMissing {
+			return false, errors.New("tasks can only be archived by an upsert import")
+		}
+		return false, nil
+	case internalModels.ImportModeUpsert:
+		if strings.TrimSpace(options.ExternalSource) == "" {
+			return true, errors.New("the external source is required by an upsert import")
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid import mode %q, must be create or upsert", options.Mode)
+}
+
+// Gets the external ID of a row of an upsert, which must be given and cannot be used twice in the file
+func checkImportExternalID(row utils.CSVRow, seen map[string]int, report *internalModels.ImportReport) (string, bool) {
+	externalID := row.Values["external_id"]
+	if externalID == "" {
+		report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "external_id", Message: "the external ID is required"})
+		return externalID, false
+	}
+	if previousRow, ok := seen[externalID]; ok {
+		report.Errors = append(report.Errors, internalModels.ImportRowError{Row: row.Number, Column: "external_id", Message: fmt.Sprintf("external ID %q is already in row %d", externalID, previousRow)})
+		return externalID, false
+	}
+	seen[externalID] = row.Number
+	return externalID, true
+}
+
+// Builds a task from a row of a tasks file and checks the values that do not need the database
+func ParseTaskImportRow(row utils.CSVRow, importerID int) (*models.Task, []internalModels.ImportRowError) {
+	rowErrors := []internalModels.ImportRowError{}
+	addError := func(column, message string) {
+		rowErrors = append(rowErrors, internalModels.ImportRowError{Row: row.Number, Column: column, Message: message})
+	}
+
+	task := &models.Task{
+		Name:        row.Values["name"],
+		Description: row.Values["description"],
+		AuthorID:    importerID,
+		Status:      null.StringFrom(string(repositories.NotStarted)),
+	}
+	if task.Name == "" {
+		addError("name", "the name is required")
+	}
+	for _, column := range []string{"start_date", "end_date"} {
+		value := row.Values[column]
+		if value == "" {
+			addError(column, "the date is required")
+			continue
+		}
+		date, err := parseImportDate(value)
+		if err != nil {
+			addError(column, fmt.Sprintf("invalid date %q", value))
+			continue
+		}
+		if column == "start_date" {
+			task.StartDate = date
+		} else {
+			task.EndDate = date
+		}
+	}
+	if !task.StartDate.IsZero() && !task.EndDate.IsZero() && task.EndDate.Before(task.StartDate) {
+		addError("end_date", "the end date is before the start date")
+	}
+	if status := row.Values["status"]; status != "" {
+		if !repositories.IsValidTaskStatus(status) {
+			addError("status", fmt.Sprintf("invalid status %q", status))
+		}
+		task.Status = null.StringFrom(status)
+	}
+	if value := row.Values["author_id"]; value != "" {
+		authorID, err := strconv.Atoi(value)
+		if err != nil || authorID <= 0 {
+			addError("author_id", fmt.Sprintf("invalid user ID %q", value))
+		} else {
+			task.AuthorID = authorID
+		}
+	}
+	value := row.Values["task_category_id"]
+	taskCategoryID, err := strconv.Atoi(value)
+	switch {
+	case value == "" && row.Values["task_category_external_id"] != "":
+		// Set from the external ID of the task category by the upsert
+	case value == "":
+		addError("task_category_id", "the task category is required")
+	case err != nil || taskCategoryID <= 0:
+		addError("task_category_id", fmt.Sprintf("invalid task category ID %q", value))
+	default:
+		task.TaskCategoryID = taskCategoryID
+	}
+	return task, rowErrors
+}
+
+// Copies the values of an imported row to an existing task. The author and the status are only
+// replaced when the row gives them, and the task is unarchived when the import archives missing tasks.
+func MergeImportedTask(current, imported *models.Task, row utils.CSVRow, unarchive bool) *models.Task {
+	merged := *current
+	merged.R = nil
+	merged.Name = imported.Name
+	merged.Description = imported.Description
+	merged.StartDate = imported.StartDate
+	merged.EndDate = imported.EndDate
+	merged.TaskCategoryID = imported.TaskCategoryID
+	if row.Values["author_id"] != "" {
+		merged.AuthorID = imported.AuthorID
+	}
+	if row.Values["status"] != "" {
+		merged.Status = imported.Status
+	}
+	if unarchive {
+		merged.ArchivedAt = null.Time{}
+	}
+	return &merged
+}
+
+// Tells if an import changes the values of a task
+func ImportedTaskChanged(current, merged *models.Task) bool {
+	return current.Name != merged.Name ||
+		current.Description != merged.Description ||
+		!current.StartDate.Equal(merged.StartDate) ||
+		!current.EndDate.Equal(merged.EndDate) ||
+		current.Status != merged.Status ||
+		current.AuthorID != merged.AuthorID ||
+		current.TaskCategoryID != merged.TaskCategoryID ||
+		current.ArchivedAt.Valid != merged.ArchivedAt.Valid
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	var err error
+	for _, layout := range importDateLayouts {
+		var date time.Time
+		date, err = time.Parse(layout, value)
+		if err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// Counts the rows that have at least one error
+func countErrorRows(rowErrors []internalModels.ImportRowError) int {
+	rows := make(map[int]bool, len(rowErrors))
+	for _, rowError := range rowErrors {
+		rows[rowError.Row] = true
+	}
+	return len(rows)
+}
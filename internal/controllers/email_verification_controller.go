@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+var ErrInvalidVerificationToken = errors.New("the email verification link is invalid or has expired")
+
+// Audience of the verification tokens, so that no other token signed by the server is accepted
+const emailVerificationAudience = "verify_email"
+
+// Claim of the verification tokens holding the verified address, a link stops working when the email changes
+const emailVerificationClaim = "email"
+
+// Verifies the email of the users who sign up through a signed, expiring link sent to it, and applies the
+// policy for the users who have not verified it yet
+type EmailVerificationController struct {
+	UserRepository *repositories.UserRepository
+	TokenIssuer    *TokenIssuer
+	Mailer         utils.Mailer
+	Policy         internalModels.EmailVerificationPolicy
+	// How long a verification link can be used
+	TTL time.Duration
+	// The page the emailed link opens, the token is added to its query
+	VerifyURL string
+}
+
+func NewEmailVerificationController(userRepository *repositories.UserRepository, tokenIssuer *TokenIssuer, mailer utils.Mailer, policy internalModels.EmailVerificationPolicy, ttl time.Duration, verifyURL string) *EmailVerificationController {
+	return &EmailVerificationController{
+		UserRepository: userRepository,
+		TokenIssuer:    tokenIssuer,
+		Mailer:         mailer,
+		Policy:         policy,
+		TTL:            ttl,
+		VerifyURL:      verifyURL,
+	}
+}
+
+// Emails a verification link to a user, to their pending email when they asked for a new one
+func (c *EmailVerificationController) SendVerification(user *models.User, now time.Time, ctx context.Context) error {
+	email := user.Email
+	if user.PendingEmail.Valid {
+		email = user.PendingEmail.String
+	}
+	token, err := c.TokenIssuer.Sign(map[string]interface{}{
+		jwt.SubjectKey:         strconv.Itoa(user.ID),
+		emailVerificationClaim: email,
+	}, emailVerificationAudience, c.TTL, now)
+	if err != nil {
+		return err
+	}
+	link, err := url.Parse(c.VerifyURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return c.Mailer.Send(utils.Mail{
+		To:      email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hello %s,\n\nOpen this link within %d hours to verify the email of your account:\n\n%s\n\nIf you did not sign up, ignore this email.\n",
+			user.Name, int(c.TTL.Hours()), link.String()),
+	}, ctx)
+}
+
+// Emails a new verification link to the user of an email address, if they have not verified it yet or are waiting
+// to change their email to it. Nothing is sent for an unknown or verified address, and no error tells it apart.
+func (c *EmailVerificationController) ResendVerification(email string, now time.Time, ctx context.Context) error {
+	user, err := c.UserRepository.GetUserByEmail(email, ctx)
+	if err == sql.ErrNoRows {
+		user, err = c.UserRepository.GetUserByPendingEmail(email, ctx)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return c.SendVerification(user, now, ctx)
+	}
+	if err != nil {
+		return err
+	}
+	// The link would go to the pending email rather than the given one
+	if user.PendingEmail.Valid {
+		return nil
+	}
+	verified, err := c.UserRepository.IsEmailVerified(user.ID, ctx)
+	if err != nil || verified {
+		return err
+	}
+	return c.SendVerification(user, now, ctx)
+}
+
+// Verifies the email of the user of a verification token, and returns the user
+func (c *EmailVerificationController) VerifyEmail(tokenString string, now time.Time, ctx context.Context) (int, error) {
+	token, err := c.TokenIssuer.Parse(tokenString, emailVerificationAudience, now)
+	if err != nil {
+		return 0, ErrInvalidVerificationToken
+	}
+	userID, err := TokenUserID(token)
+	if err != nil {
+		return 0, ErrInvalidVerificationToken
+	}
+	email, ok := token.PrivateClaims()[emailVerificationClaim].(string)
+	if !ok || email == "" {
+		return 0, ErrInvalidVerificationToken
+	}
+	if err := c.UserRepository.VerifyEmail(userID, email, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			return 0, ErrInvalidVerificationToken
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// Verifies the email of a user without a link, for managers
+func (c *EmailVerificationController) VerifyUser(userID int, ctx context.Context) error {
+	user, err := c.UserRepository.GetUserByID(userID, ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repositories.ErrNoMatch
+		}
+		return err
+	}
+	return c.UserRepository.VerifyEmail(user.ID, user.Email, ctx)
+}
+
+// Gets the users who have not verified their email
+func (c *EmailVerificationController) GetPendingUsers(ctx context.Context) ([]internalModels.PendingUser, error) {
+	pendingUsers := []internalModels.PendingUser{}
+	users, err := c.UserRepository.GetUnverifiedUsers(ctx)
+	if err != nil {
+		return pendingUsers, err
+	}
+	for _, user := range users {
+		pendingUsers = append(pendingUsers, internalModels.PendingUser{ID: user.ID, Name: user.Name, Email: user.Email, Role: user.Role})
+	}
+	return pendingUsers, nil
+}
+
+// Returns an error wrapping repositories.ErrEmailNotVerified when the policy keeps a user from logging in
+func (c *EmailVerificationController) CheckLogin(userID int, ctx context.Context) error {
+	if c.Policy != internalModels.EmailVerificationBlockLogin {
+		return nil
+	}
+	verified, err := c.UserRepository.IsEmailVerified(userID, ctx)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return fmt.Errorf("please verify your email before logging in: %w", repositories.ErrEmailNotVerified)
+	}
+	return nil
+}
+
+// Returns an error wrapping repositories.ErrEmailNotVerified when a user cannot be assigned tasks
+func (c *EmailVerificationController) CheckAssignable(userID int, ctx context.Context) error {
+	return c.UserRepository.CheckAssignable([]int{userID}, ctx)
+}
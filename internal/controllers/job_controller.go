@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Maximum number of jobs listed at once
+const jobListLimit = 100
+
+var ErrJobRateLimited = errors.New("too many jobs were queued for this key, try again later")
+
+type JobController struct {
+	JobRepository *repositories.JobRepository
+	Registry      *JobRegistry
+}
+
+func NewJobController(jobRepository *repositories.JobRepository, registry *JobRegistry) *JobController {
+	return &JobController{JobRepository: jobRepository, Registry: registry}
+}
+
+// Adds a job to the queue, it runs as soon as a worker is free unless it has a later run time
+func (c *JobController) EnqueueJob(newJob internalModels.NewJob, ctx context.Context) (internalModels.Job, error) {
+	job, err := c.newQueuedJob(newJob)
+	if err != nil {
+		return job, err
+	}
+	if err := c.JobRepository.AddJob(&job, ctx); err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+// Adds a job to the queue on behalf of a rate key, such as the address of an email. At most limit jobs of
+// the type are queued for the key within the window, ErrJobRateLimited is returned past it.
+func (c *JobController) EnqueueRateLimitedJob(newJob internalModels.NewJob, rateKey string, limit int, window time.Duration, ctx context.Context) (internalModels.Job, error) {
+	job, err := c.newQueuedJob(newJob)
+	if err != nil {
+		return job, err
+	}
+	added, err := c.JobRepository.AddRateLimitedJob(&job, rateKey, limit, time.Now().Add(-window), ctx)
+	if err != nil {
+		return job, err
+	}
+	if !added {
+		return job, ErrJobRateLimited
+	}
+	return job, nil
+}
+
+// Adds a job of a recurring type to the queue, when a job of the type is already queued its payload is
+// replaced and it keeps its run time
+func (c *JobController) ScheduleJob(newJob internalModels.NewJob, ctx context.Context) (internalModels.Job, error) {
+	job, err := c.newQueuedJob(newJob)
+	if err != nil {
+		return job, err
+	}
+	if err := c.JobRepository.ScheduleJob(&job, ctx); err != nil {
+		return job, err
+	}
+	return job, nil
+}
+
+// Removes the queued jobs of a recurring type, so it is no longer run
+func (c *JobController) UnscheduleJobs(jobType string, ctx context.Context) (int64, error) {
+	count, err := c.JobRepository.DeleteQueuedJobs(jobType, ctx)
+	if err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// Makes the job to queue, with the limits of its type when it does not set its own
+func (c *JobController) newQueuedJob(newJob internalModels.NewJob) (internalModels.Job, error) {
+	jobType, ok := c.Registry.types[newJob.Type]
+	if !ok {
+		return internalModels.Job{}, fmt.Errorf("unknown job type %q", newJob.Type)
+	}
+	payload, err := json.Marshal(newJob.Payload)
+	if err != nil {
+		return internalModels.Job{}, err
+	}
+	job := internalModels.Job{
+		Type:           newJob.Type,
+		Payload:        payload,
+		MaxAttempts:    newJob.MaxAttempts,
+		TimeoutSeconds: int(newJob.Timeout / time.Second),
+		RunAt:          newJob.RunAt,
+		CreatedBy:      newJob.CreatedBy,
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = jobType.maxAttempts
+	}
+	if job.TimeoutSeconds <= 0 {
+		job.TimeoutSeconds = int(jobType.timeout / time.Second)
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now()
+	}
+	return job, nil
+}
+
+// Gets a job, users can only see the jobs they created and managers can see all jobs
+func (c *JobController) GetJob(jobID, userID int, isManager bool, ctx context.Context) (internalModels.Job, error) {
+	job, err := c.JobRepository.GetJobByID(jobID, ctx)
+	if err != nil {
+		return job, err
+	}
+	if !isManager && job.CreatedBy.Int != userID {
+		return internalModels.Job{}, repositories.ErrNoMatch
+	}
+	return job, nil
+}
+
+// Gets the latest jobs, of every status when status is empty
+func (c *JobController) GetJobs(status string, ctx context.Context) ([]internalModels.Job, error) {
+	switch status {
+	case "", internalModels.JobQueued, internalModels.JobRunning, internalModels.JobSucceeded, internalModels.JobDead:
+	default:
+		return nil, fmt.Errorf("invalid job status %q", status)
+	}
+	jobs, err := c.JobRepository.GetJobs(status, jobListLimit, ctx)
+	if err != nil {
+		return jobs, err
+	}
+	return jobs, nil
+}
+
+// Queues a dead job again with all its attempts
+func (c *JobController) RetryDeadJob(jobID int, ctx context.Context) (internalModels.Job, error) {
+	job, err := c.JobRepository.RequeueDeadJob(jobID, ctx)
+	if err != nil {
+		return job, err
+	}
+	return job, nil
+}
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Limits of a job type when a job does not set its own
+const (
+	defaultJobMaxAttempts = 5
+	defaultJobTimeout     = 5 * time.Minute
+)
+
+// Delays before retrying a failed job, doubled after each attempt
+const (
+	jobBackoffBase = 10 * time.Second
+	jobBackoffMax  = time.Hour
+)
+
+// Runs a job of a type and returns its result, which is stored as JSON. The context is cancelled
+// when the job times out or the worker stops, and progress reports the progress in percent.
+type JobFunc func(ctx context.Context, job internalModels.Job, progress func(percent int)) (interface{}, error)
+
+type jobType struct {
+	run         JobFunc
+	maxAttempts int
+	timeout     time.Duration
+}
+
+// The job types a process knows how to run
+type JobRegistry struct {
+	types map[string]jobType
+}
+
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{types: make(map[string]jobType)}
+}
+
+// Registers the function running the jobs of a type, zero limits use the defaults
+func (r *JobRegistry) Register(name string, run JobFunc, maxAttempts int, timeout time.Duration) {
+	if maxAttempts <= 0 {
+		maxAttempts = defaultJobMaxAttempts
+	}
+	if timeout <= 0 {
+		timeout = defaultJobTimeout
+	}
+	r.types[name] = jobType{run: run, maxAttempts: maxAttempts, timeout: timeout}
+}
+
+// Gets the names of the registered job types
+func (r *JobRegistry) Types() []string {
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Gets the delay before the next attempt of a job that failed the given number of attempts
+func JobBackoff(attempts int) time.Duration {
+	delay := jobBackoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= jobBackoffMax {
+			return jobBackoffMax
+		}
+	}
+	return delay
+}
+
+// Workers claiming and running the jobs of the registered types from the jobs table
+type JobWorkerPool struct {
+	JobRepository *repositories.JobRepository
+	Registry      *JobRegistry
+	Workers       int
+	PollInterval  time.Duration
+	// Prefix of the IDs the workers lock jobs with
+	WorkerID string
+
+	stopClaiming context.CancelFunc
+	cancelJobs   context.CancelFunc
+	jobsCtx      context.Context
+	wg           sync.WaitGroup
+}
+
+func NewJobWorkerPool(jobRepository *repositories.JobRepository, registry *JobRegistry, workers int) *JobWorkerPool {
+	hostname, _ := os.Hostname()
+	return &JobWorkerPool{
+		JobRepository: jobRepository,
+		Registry:      registry,
+		Workers:       workers,
+		PollInterval:  time.Second,
+		WorkerID:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Starts the workers, they run until Stop is called
+func (p *JobWorkerPool) Start() {
+	claimCtx, stopClaiming := context.WithCancel(context.Background())
+	p.jobsCtx, p.cancelJobs = context.WithCancel(context.Background())
+	p.stopClaiming = stopClaiming
+	for i := 0; i < p.Workers; i++ {
+		p.wg.Add(1)
+		// Every worker locks jobs with its own ID, so it can tell when another worker took its job
+		workerID := fmt.Sprintf("%s-%d", p.WorkerID, i+1)
+		go func() {
+			defer p.wg.Done()
+			p.work(claimCtx, workerID)
+		}()
+	}
+	log.Printf("Started %d job workers for %v", p.Workers, p.Registry.Types())
+}
+
+// Stops claiming jobs and waits for the running jobs to finish. Jobs still running after the timeout
+// are cancelled and queued again.
+func (p *JobWorkerPool) Stop(timeout time.Duration) {
+	if p.stopClaiming == nil {
+		return
+	}
+	p.stopClaiming()
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.Println("Cancelling the running jobs")
+		p.cancelJobs()
+		<-done
+	}
+	p.cancelJobs()
+	log.Println("Stopped job workers")
+}
+
+func (p *JobWorkerPool) work(claimCtx context.Context, workerID string) {
+	types := p.Registry.Types()
+	for {
+		if claimCtx.Err() != nil {
+			return
+		}
+		job, err := p.JobRepository.ClaimJob(types, workerID, claimCtx)
+		if err == nil {
+			p.run(job, workerID)
+			continue
+		}
+		if err != repositories.ErrNoMatch && claimCtx.Err() == nil {
+			log.Printf("Could not claim a job: %v\n", err)
+		}
+		select {
+		case <-claimCtx.Done():
+			return
+		case <-time.After(p.PollInterval):
+		}
+	}
+}
+
+// Runs a claimed job and records its outcome
+func (p *JobWorkerPool) run(job internalModels.Job, workerID string) {
+	// The outcome is recorded even when the worker is stopping
+	ctx := context.Background()
+	jobType := p.Registry.types[job.Type]
+	if job.Attempts > job.MaxAttempts {
+		p.record(job, p.JobRepository.KillJob(job.ID, workerID, "the worker running the last attempt stopped", ctx))
+		return
+	}
+
+	jobCtx, cancel := context.WithTimeout(p.jobsCtx, time.Duration(job.TimeoutSeconds)*time.Second)
+	defer cancel()
+	progress := func(percent int) {
+		if err := p.JobRepository.UpdateJobProgress(job.ID, workerID, percent, ctx); err != nil {
+			log.Printf("Could not update the progress of job %d: %v\n", job.ID, err)
+		}
+	}
+	result, err := runJobFunc(jobType.run, jobCtx, job, progress)
+
+	switch {
+	case err == nil:
+		data, err := json.Marshal(result)
+		if err != nil {
+			p.record(job, p.JobRepository.KillJob(job.ID, workerID, fmt.Sprintf("invalid result: %v", err), ctx))
+			return
+		}
+		p.record(job, p.JobRepository.CompleteJob(job.ID, workerID, data, ctx))
+	case p.jobsCtx.Err() != nil:
+		p.record(job, p.JobRepository.ReleaseJob(job.ID, workerID, ctx))
+	case job.Attempts >= job.MaxAttempts:
+		log.Printf("Job %d (%s) failed its last attempt: %v\n", job.ID, job.Type, err)
+		p.record(job, p.JobRepository.KillJob(job.ID, workerID, err.Error(), ctx))
+	default:
+		runAt := time.Now().Add(JobBackoff(job.Attempts))
+		p.record(job, p.JobRepository.RetryJobLater(job.ID, workerID, err.Error(), runAt, ctx))
+	}
+}
+
+func (p *JobWorkerPool) record(job internalModels.Job, err error) {
+	if err == repositories.ErrNoMatch {
+		log.Printf("Job %d was claimed by another worker before its outcome was recorded\n", job.ID)
+	} else if err != nil {
+		log.Printf("Could not record the outcome of job %d: %v\n", job.ID, err)
+	}
+}
+
+// Runs a job function, a panic fails the attempt instead of stopping the worker
+func runJobFunc(run JobFunc, ctx context.Context, job internalModels.Job, progress func(int)) (result interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	result, err = run(ctx, job, progress)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	return result, err
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+// Job type sending an email
+const JobTypeSendMail = "send_mail"
+
+// At most mailRateLimit emails are queued for an address within mailRateWindow, so the endpoints sending
+// links cannot be used to flood a mailbox
+const (
+	mailRateLimit  = 5
+	mailRateWindow = time.Hour
+)
+
+// Sends the emails of the app through send_mail jobs, so they are retried when the mail server fails and
+// are not lost when the server stops. The jobs send them with the mailer.
+type MailQueue struct {
+	JobController *JobController
+	Mailer        utils.Mailer
+}
+
+func NewMailQueue(jobController *JobController, mailer utils.Mailer) *MailQueue {
+	return &MailQueue{JobController: jobController, Mailer: mailer}
+}
+
+// Queues an email, ErrJobRateLimited is returned when too many emails were queued for its address
+func (q *MailQueue) Send(mail utils.Mail, ctx context.Context) error {
+	job := internalModels.NewJob{
+		Type:    JobTypeSendMail,
+		Payload: internalModels.SendMailJob{To: mail.To, Subject: mail.Subject, Body: mail.Body},
+	}
+	_, err := q.JobController.EnqueueRateLimitedJob(job, strings.ToLower(mail.To), mailRateLimit, mailRateWindow, ctx)
+	return err
+}
+
+// Registers the job sending the queued emails
+func (q *MailQueue) RegisterJobs(registry *JobRegistry) {
+	registry.Register(JobTypeSendMail, func(ctx context.Context, job internalModels.Job, progress func(int)) (interface{}, error) {
+		payload := internalModels.SendMailJob{}
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, err
+		}
+		return nil, q.Mailer.Send(utils.Mail{To: payload.To, Subject: payload.Subject, Body: payload.Body}, ctx)
+	}, 0, time.Minute)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+type MentionController struct {
+	MentionRepository      *repositories.MentionRepository
+	NotificationRepository *repositories.NotificationRepository
+	UserRepository         *repositories.UserRepository
+}
+
+func NewMentionController(mentionRepository *repositories.MentionRepository, notificationRepository *repositories.NotificationRepository, userRepository *repositories.UserRepository) *MentionController {
+	return &MentionController{MentionRepository: mentionRepository, NotificationRepository: notificationRepository, UserRepository: userRepository}
+}
+
+// Matches @name or @name@example.com when the @ does not follow a character of a word or an email
+var mentionRegex = regexp.MustCompile(`(?:^|[^A-Za-z0-9._%+\-@])@([A-Za-z0-9._%+\-]+(?:@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})?)`)
+
+// Gets the handles mentioned in a text without the leading @, in order of appearance and without duplicates
+func ParseMentions(text string) []string {
+	handles := []string{}
+	seen := make(map[string]bool)
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		// A dot right after a mention ends the sentence
+		handle := strings.TrimRight(match[1], ".")
+		if handle == "" || seen[strings.ToLower(handle)] {
+			continue
+		}
+		seen[strings.ToLower(handle)] = true
+		handles = append(handles, handle)
+	}
+	return handles
+}
+
+// Finds the user of a handle. A handle without a domain is matched against the part of the email addresses
+// before the @, not the display names, and must match exactly one user.
+func (c *MentionController) resolveMention(handle string, ctx context.Context) (*models.User, error) {
+	if strings.Contains(handle, "@") {
+		user, err := c.UserRepository.GetUserByEmail(handle, ctx)
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return user, err
+	}
+	users, err := c.UserRepository.GetUsersByEmailName(handle, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) != 1 {
+		return nil, nil
+	}
+	return users[0], nil
+}
+
+// Records the users mentioned in the description of a task and notifies the users that were not mentioned there before
+func (c *MentionController) ProcessTaskMentions(task *models.Task, mentionedBy int, ctx context.Context) (internalModels.MentionReport, error) {
+	report := internalModels.MentionReport{Mentioned: []string{}, Unknown: []string{}}
+	userIDs := []int{}
+	for _, handle := range ParseMentions(task.Description) {
+		user, err := c.resolveMention(handle, ctx)
+		if err != nil {
+			return report, err
+		}
+		if user == nil {
+			report.Unknown = append(report.Unknown, handle)
+			continue
+		}
+		report.Mentioned = append(report.Mentioned, handle)
+		userIDs = append(userIDs, user.ID)
+	}
+
+	added, err := c.MentionRepository.AddMentions(task.ID, uniqueIDs(userIDs), mentionedBy, internalModels.MentionSourceDescription, ctx)
+	if err != nil {
+		return report, err
+	}
+	notifications := []internalModels.Notification{}
+	for _, userID := range added {
+		// Users do not need to be told about their own mentions
+		if userID == mentionedBy {
+			continue
+		}
+		notifications = append(notifications, internalModels.Notification{
+			UserID:  userID,
+			Type:    internalModels.NotificationMention,
+			TaskID:  null.IntFrom(task.ID),
+			Message: fmt.Sprintf("You were mentioned in the task %q", task.Name),
+		})
+	}
+	if err := c.NotificationRepository.AddNotifications(notifications, ctx); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+func (c *MentionController) GetMentionsOfUser(userID int, ctx context.Context) ([]internalModels.Mention, error) {
+	mentions, err := c.MentionRepository.GetMentionsOfUser(userID, ctx)
+	if err != nil {
+		return mentions, err
+	}
+	return mentions, nil
+}
@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+type NotificationController struct {
+	NotificationRepository *repositories.NotificationRepository
+}
+
+func NewNotificationController(notificationRepository *repositories.NotificationRepository) *NotificationController {
+	return &NotificationController{NotificationRepository: notificationRepository}
+}
+
+func (c *NotificationController) GetNotificationsOfUser(userID int, unreadOnly bool, ctx context.Context) ([]models.Notification, error) {
+	notifications, err := c.NotificationRepository.GetNotificationsOfUser(userID, unreadOnly, ctx)
+	if err != nil {
+		return notifications, err
+	}
+	return notifications, nil
+}
+
+func (c *NotificationController) MarkNotificationRead(userID, notificationID int, ctx context.Context) (models.Notification, error) {
+	notification, err := c.NotificationRepository.MarkNotificationRead(userID, notificationID, ctx)
+	if err != nil {
+		return notification, err
+	}
+	return notification, nil
+}
+
+func (c *NotificationController) MarkAllNotificationsRead(userID int, ctx context.Context) (int64, error) {
+	marked, err := c.NotificationRepository.MarkAllNotificationsRead(userID, ctx)
+	if err != nil {
+		return marked, err
+	}
+	return marked, nil
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrInvalidPasswordResetToken = errors.New("the password reset link is invalid or has expired")
+
+// Lets the users who forgot their password choose a new one through a single-use link sent to their email.
+// The tokens are generated and hashed like the refresh tokens, only their hashes are stored.
+type PasswordResetController struct {
+	UserRepository          *repositories.UserRepository
+	PasswordResetRepository *repositories.PasswordResetRepository
+	// The sessions revoked by a reset are removed from its cache
+	UserSessionController *UserSessionController
+	Mailer                utils.Mailer
+	// How long a reset link can be used
+	TTL time.Duration
+	// The page the emailed link opens, the token is added to its query
+	ResetURL string
+}
+
+func NewPasswordResetController(userRepository *repositories.UserRepository, passwordResetRepository *repositories.PasswordResetRepository, userSessionController *UserSessionController, mailer utils.Mailer, ttl time.Duration, resetURL string) *PasswordResetController {
+	return &PasswordResetController{
+		UserRepository:          userRepository,
+		PasswordResetRepository: passwordResetRepository,
+		UserSessionController:   userSessionController,
+		Mailer:                  mailer,
+		TTL:                     ttl,
+		ResetURL:                resetURL,
+	}
+}
+
+// Emails a reset link to the user of an email address. Nothing is sent for an unknown address, and no
+// error tells it apart, so the response cannot be used to find out which addresses have an account.
+func (c *PasswordResetController) RequestPasswordReset(email string, now time.Time, ctx context.Context) error {
+	user, err := c.UserRepository.GetUserByEmail(email, ctx)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	token, err := newRefreshToken()
+	if err != nil {
+		return err
+	}
+	if err := c.PasswordResetRepository.AddPasswordResetToken(user.ID, hashRefreshToken(token), now.Add(c.TTL), ctx); err != nil {
+		return err
+	}
+	link, err := url.Parse(c.ResetURL)
+	if err != nil {
+		return err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return c.Mailer.Send(utils.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hello %s,\n\nSomeone asked to reset the password of your account. Open this link within %d minutes to choose a new password:\n\n%s\n\nIf it was not you, ignore this email and your password stays the same.\n",
+			user.Name, int(c.TTL.Minutes()), link.String()),
+	}, ctx)
+}
+
+// Changes the password of the user of a reset token, and returns the user. The token and every other token
+// of the user cannot be used again, and every session of the user has to log in again.
+func (c *PasswordResetController) ResetPassword(token, newPassword string, ctx context.Context) (int, error) {
+	// Hashed the same way as a password given at signup or changed by the user
+	newPassword = html.EscapeString(strings.TrimSpace(newPassword))
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), 14)
+	if err != nil {
+		return 0, err
+	}
+	userID, err := c.PasswordResetRepository.ResetPassword(hashRefreshToken(token), string(hashedPassword), ctx)
+	if err == repositories.ErrNoMatch {
+		return 0, ErrInvalidPasswordResetToken
+	}
+	if err != nil {
+		return 0, err
+	}
+	c.UserSessionController.ForgetUserSessions(userID)
+	return userID, nil
+}
@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Layout of the days of a report
+const reportDateLayout = "2006-01-02"
+
+// Longest range of a report, in days
+const maxReportDays = 366
+
+// Statuses listed in a cumulative flow, from the first to the last stage
+var reportStatuses = []string{
+	string(repositories.NotStarted),
+	string(repositories.InProgress),
+	string(repositories.Lock),
+	string(repositories.Complete),
+}
+
+type ReportController struct {
+	ReportRepository       *repositories.ReportRepository
+	TaskCategoryRepository *repositories.TaskCategoryRepository
+}
+
+func NewReportController(reportRepository *repositories.ReportRepository, taskCategoryRepository *repositories.TaskCategoryRepository) *ReportController {
+	return &ReportController{ReportRepository: reportRepository, TaskCategoryRepository: taskCategoryRepository}
+}
+
+// Reads the days of a report from YYYY-MM-DD values. The range ends today when to is empty and covers
+// the given number of days when from is empty.
+func ParseReportRange(from, to string, taskCategoryID, defaultDays int, now time.Time) (internalModels.ReportRange, error) {
+	reportRange := internalModels.ReportRange{TaskCategoryID: taskCategoryID}
+	reportRange.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		date, err := time.Parse(reportDateLayout, to)
+		if err != nil {
+			return reportRange, errors.New("to must be a date formatted as YYYY-MM-DD")
+		}
+		reportRange.To = date
+	}
+	reportRange.From = reportRange.To.AddDate(0, 0, 1-defaultDays)
+	if from != "" {
+		date, err := time.Parse(reportDateLayout, from)
+		if err != nil {
+			return reportRange, errors.New("from must be a date formatted as YYYY-MM-DD")
+		}
+		reportRange.From = date
+	}
+	if reportRange.From.After(reportRange.To) {
+		return reportRange, errors.New("from must not be after to")
+	}
+	if days := reportDays(reportRange); days > maxReportDays {
+		return reportRange, fmt.Errorf("a report cannot cover more than %d days", maxReportDays)
+	}
+	return reportRange, nil
+}
+
+func reportDays(reportRange internalModels.ReportRange) int {
+	return int(reportRange.To.Sub(reportRange.From).Hours()/24) + 1
+}
+
+// Gets the open tasks at the end of each day of the range
+func (c *ReportController) GetBurndown(reportRange internalModels.ReportRange, ctx context.Context) ([]internalModels.BurndownPoint, error) {
+	counts, err := c.getDailyStatusCounts(reportRange, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BurndownFromCounts(reportRange, counts), nil
+}
+
+// Gets the tasks completed in each week of the range
+func (c *ReportController) GetThroughput(reportRange internalModels.ReportRange, ctx context.Context) ([]internalModels.ThroughputPoint, error) {
+	if err := c.checkTaskCategory(reportRange, ctx); err != nil {
+		return nil, err
+	}
+	completions, err := c.ReportRepository.GetWeeklyCompletions(reportRange, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ThroughputFromCompletions(reportRange, completions), nil
+}
+
+// Gets the tasks in every status at the end of each day of the range
+func (c *ReportController) GetCumulativeFlow(reportRange internalModels.ReportRange, ctx context.Context) ([]internalModels.CumulativeFlowPoint, error) {
+	counts, err := c.getDailyStatusCounts(reportRange, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return CumulativeFlowFromCounts(reportRange, counts), nil
+}
+
+func (c *ReportController) getDailyStatusCounts(reportRange internalModels.ReportRange, ctx context.Context) ([]internalModels.DailyStatusCount, error) {
+	if err := c.checkTaskCategory(reportRange, ctx); err != nil {
+		return nil, err
+	}
+	return c.ReportRepository.GetDailyStatusCounts(reportRange, ctx)
+}
+
+func (c *ReportController) checkTaskCategory(reportRange internalModels.ReportRange, ctx context.Context) error {
+	if reportRange.TaskCategoryID == 0 {
+		return nil
+	}
+	if _, err := c.TaskCategoryRepository.GetTaskCategoryByID(reportRange.TaskCategoryID, ctx); err != nil {
+		if err == sql.ErrNoRows {
+			return repositories.ErrNoMatch
+		}
+		return err
+	}
+	return nil
+}
+
+// Builds the cumulative flow of every day of the range, statuses without tasks are counted as zero
+func CumulativeFlowFromCounts(reportRange internalModels.ReportRange, counts []internalModels.DailyStatusCount) []internalModels.CumulativeFlowPoint {
+	byDay := make(map[string]map[string]int)
+	for _, count := range counts {
+		day := count.Date.Format(reportDateLayout)
+		if byDay[day] == nil {
+			byDay[day] = make(map[string]int)
+		}
+		byDay[day][count.Status] += count.Count
+	}
+	points := make([]internalModels.CumulativeFlowPoint, 0, reportDays(reportRange))
+	for day := reportRange.From; !day.After(reportRange.To); day = day.AddDate(0, 0, 1) {
+		date := day.Format(reportDateLayout)
+		statuses := make(map[string]int, len(reportStatuses))
+		for _, status := range reportStatuses {
+			statuses[status] = 0
+		}
+		for status, count := range byDay[date] {
+			statuses[status] = count
+		}
+		points = append(points, internalModels.CumulativeFlowPoint{Date: date, Statuses: statuses})
+	}
+	return points
+}
+
+// Builds the burndown of every day of the range, open tasks are the tasks that are not complete
+func BurndownFromCounts(reportRange internalModels.ReportRange, counts []internalModels.DailyStatusCount) []internalModels.BurndownPoint {
+	flow := CumulativeFlowFromCounts(reportRange, counts)
+	points := make([]internalModels.BurndownPoint, 0, len(flow))
+	for _, point := range flow {
+		total := 0
+		for _, count := range point.Statuses {
+			total += count
+		}
+		open := total - point.Statuses[string(repositories.Complete)]
+		points = append(points, internalModels.BurndownPoint{Date: point.Date, Total: total, Open: open})
+	}
+	return points
+}
+
+// Builds the throughput of every week overlapping the range, weeks start on Monday
+func ThroughputFromCompletions(reportRange internalModels.ReportRange, completions map[time.Time]int) []internalModels.ThroughputPoint {
+	week := reportRange.From.AddDate(0, 0, -(int(reportRange.From.Weekday())+6)%7)
+	points := []internalModels.ThroughputPoint{}
+	for ; !week.After(reportRange.To); week = week.AddDate(0, 0, 7) {
+		points = append(points, internalModels.ThroughputPoint{WeekStart: week.Format(reportDateLayout), Completed: completions[week]})
+	}
+	return points
+}
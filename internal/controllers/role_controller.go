@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+var ErrBuiltInRole = errors.New("the built-in roles cannot be changed or deleted")
+
+// ErrRoleAssignmentForbidden is returned when a user gives a role that allows more than their own, or
+// changes their own role
+var ErrRoleAssignmentForbidden = errors.New("you cannot give a role with permissions you do not hold, or change your own role")
+
+// How long the permissions of the roles are trusted before they are read again, a role changed by
+// another server keeps its old permissions for at most this long
+const roleCacheInterval = 30 * time.Second
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// Keeps the roles and their permissions. The permissions are cached, since every request needs the ones
+// of the role of its user.
+type RoleController struct {
+	RoleRepository *repositories.RoleRepository
+
+	mu          sync.Mutex
+	permissions map[string][]internalModels.Permission
+	loadedAt    time.Time
+}
+
+func NewRoleController(roleRepository *repositories.RoleRepository) *RoleController {
+	return &RoleController{RoleRepository: roleRepository}
+}
+
+// Gets the permissions of a role, unknown roles have none
+func (c *RoleController) GetRolePermissions(role string, now time.Time, ctx context.Context) ([]internalModels.Permission, error) {
+	c.mu.Lock()
+	permissions, loadedAt := c.permissions, c.loadedAt
+	c.mu.Unlock()
+
+	if permissions == nil || now.Sub(loadedAt) >= roleCacheInterval {
+		roles, err := c.RoleRepository.GetRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		permissions = make(map[string][]internalModels.Permission, len(roles))
+		for _, role := range roles {
+			permissions[role.Name] = role.Permissions
+		}
+		c.mu.Lock()
+		c.permissions, c.loadedAt = permissions, now
+		c.mu.Unlock()
+	}
+	return permissions[role], nil
+}
+
+// Checks that a principal can give a role to a user. The role must exist, and the principal can neither
+// change their own role nor give or take away a role with permissions they do not hold.
+func (c *RoleController) CheckRoleAssignment(name string, user *models.User, assigner internalModels.Principal, ctx context.Context) error {
+	role, err := c.RoleRepository.GetRole(name, ctx)
+	if err == repositories.ErrNoMatch {
+		return fmt.Errorf("the role %q does not exist", name)
+	}
+	if err != nil {
+		return err
+	}
+	if user.ID == assigner.User.ID {
+		return ErrRoleAssignmentForbidden
+	}
+	current, err := c.GetRolePermissions(user.Role, time.Now(), ctx)
+	if err != nil {
+		return err
+	}
+	for _, permission := range append(role.Permissions, current...) {
+		if !hasPermission(assigner.Permissions, permission) {
+			return ErrRoleAssignmentForbidden
+		}
+	}
+	return nil
+}
+
+func (c *RoleController) GetRoles(ctx context.Context) ([]internalModels.Role, error) {
+	return c.RoleRepository.GetRoles(ctx)
+}
+
+func (c *RoleController) GetRole(name string, ctx context.Context) (internalModels.Role, error) {
+	return c.RoleRepository.GetRole(name, ctx)
+}
+
+// Adds a custom role
+func (c *RoleController) AddRole(role internalModels.Role, ctx context.Context) (internalModels.Role, error) {
+	role, err := validateRole(role)
+	if err != nil {
+		return role, err
+	}
+	if _, err := c.RoleRepository.GetRole(role.Name, ctx); err != repositories.ErrNoMatch {
+		if err == nil {
+			return role, fmt.Errorf("the role %q already exists", role.Name)
+		}
+		return role, err
+	}
+	if err := c.RoleRepository.AddRole(role, ctx); err != nil {
+		return role, err
+	}
+	c.forgetPermissions()
+	return c.RoleRepository.GetRole(role.Name, ctx)
+}
+
+// Changes the description and the permissions of a custom role
+func (c *RoleController) UpdateRole(name string, role internalModels.Role, ctx context.Context) (internalModels.Role, error) {
+	existing, err := c.RoleRepository.GetRole(name, ctx)
+	if err != nil {
+		return existing, err
+	}
+	if existing.BuiltIn {
+		return existing, ErrBuiltInRole
+	}
+	role.Name = name
+	role, err = validateRole(role)
+	if err != nil {
+		return role, err
+	}
+	if err := c.RoleRepository.UpdateRole(role, ctx); err != nil {
+		return role, err
+	}
+	c.forgetPermissions()
+	return c.RoleRepository.GetRole(name, ctx)
+}
+
+// Deletes a custom role that no user holds
+func (c *RoleController) DeleteRole(name string, ctx context.Context) error {
+	existing, err := c.RoleRepository.GetRole(name, ctx)
+	if err != nil {
+		return err
+	}
+	if existing.BuiltIn {
+		return ErrBuiltInRole
+	}
+	if err := c.RoleRepository.DeleteRole(name, ctx); err != nil {
+		return err
+	}
+	c.forgetPermissions()
+	return nil
+}
+
+// Empties the cache, so the permissions are read again
+func (c *RoleController) forgetPermissions() {
+	c.mu.Lock()
+	c.permissions = nil
+	c.mu.Unlock()
+}
+
+// Checks the name and the permissions of a role, and sorts its permissions without duplicates
+func validateRole(role internalModels.Role) (internalModels.Role, error) {
+	role.Name = strings.TrimSpace(role.Name)
+	if !roleNamePattern.MatchString(role.Name) {
+		return role, errors.New("the name of a role must contain between 2 and 50 lowercase letters, digits, '_' or '-', starting with a letter")
+	}
+	role.Description = strings.TrimSpace(role.Description)
+	known := make(map[internalModels.Permission]bool, len(internalModels.Permissions))
+	for _, permission := range internalModels.Permissions {
+		known[permission] = true
+	}
+	seen := make(map[internalModels.Permission]bool, len(role.Permissions))
+	permissions := make([]internalModels.Permission, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		if !known[permission] {
+			return role, fmt.Errorf("unknown permission %q", permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			permissions = append(permissions, permission)
+		}
+	}
+	sort.Slice(permissions, func(i, j int) bool { return permissions[i] < permissions[j] })
+	role.Permissions = permissions
+	return role, nil
+}
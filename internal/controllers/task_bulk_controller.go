@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Maximum number of tasks that can be selected by a single bulk request
+const MaxBulkTasks = 1000
+
+// Runs an operation on many tasks at once and reports the result of every task.
+// Each task is authorized with the same rules as the single task endpoints before anything is written,
+// using the access of the user to the task categories by task category ID.
+func (c *TaskController) BulkUpdateTasks(request internalModels.BulkTaskRequest, userID int, isManager bool, accesses map[int]internalModels.TaskCategoryAccess, ctx context.Context) (internalModels.BulkTaskReport, error) {
+	report := internalModels.BulkTaskReport{Operation: request.Operation.Type, Mode: request.Mode, Results: []internalModels.BulkTaskResult{}}
+	if report.Mode == "" {
+		report.Mode = internalModels.BulkModeAllOrNothing
+	}
+	if report.Mode != internalModels.BulkModeAllOrNothing && report.Mode != internalModels.BulkModeBestEffort {
+		return report, fmt.Errorf("the mode must be either '%s' or '%s'", internalModels.BulkModeAllOrNothing, internalModels.BulkModeBestEffort)
+	}
+	if err := validateBulkOperation(request.Operation); err != nil {
+		return report, err
+	}
+	// Tasks can only be moved to a category the user can see
+	if request.Operation.Type == internalModels.BulkSetCategory &&
+		!HasTaskCategoryPermission(accesses[request.Operation.TaskCategoryID], isManager, internalModels.TaskCategoryView) {
+		return report, fmt.Errorf("task category %d does not exist", request.Operation.TaskCategoryID)
+	}
+	taskIDs, err := c.selectBulkTaskIDs(request, userID, isManager, ctx)
+	if err != nil {
+		return report, err
+	}
+
+	tasks, err := c.TaskRepository.GetTasksByIDs(taskIDs, ctx)
+	if err != nil {
+		return report, err
+	}
+	taskByID := make(map[int]*models.Task, len(tasks))
+	for _, task := range tasks {
+		taskByID[task.ID] = task
+	}
+	assigned, err := c.TaskRepository.GetAssignedTaskIDs(userID, taskIDs, ctx)
+	if err != nil {
+		return report, err
+	}
+
+	taskErrors := make(map[int]error)
+	authorizedIDs := make([]int, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		task, ok := taskByID[taskID]
+		if !ok {
+			taskErrors[taskID] = repositories.ErrNoMatch
+			continue
+		}
+		access := accesses[task.TaskCategoryID]
+		if !HasTaskCategoryPermission(access, isManager, internalModels.TaskCategoryView) {
+			taskErrors[taskID] = repositories.ErrNoMatch
+			continue
+		}
+		// The owners of the category of a task act as managers
+		managesTask := HasTaskCategoryPermission(access, isManager, internalModels.TaskCategoryManage)
+		actor := internalModels.TaskActor{
+			UserID:   userID,
+			Manager:  managesTask,
+			Locker:   managesTask,
+			Author:   task.AuthorID == userID,
+			Assignee: assigned[taskID],
+		}
+		if err := AuthorizeBulkOperation(actor, request.Operation, task); err != nil {
+			taskErrors[taskID] = err
+			continue
+		}
+		authorizedIDs = append(authorizedIDs, taskID)
+	}
+
+	bestEffort := report.Mode == internalModels.BulkModeBestEffort
+	if bestEffort || len(taskErrors) == 0 {
+		var applyErrors map[int]error
+		applyErrors, err = c.TaskRepository.ApplyBulkOperation(request.Operation, authorizedIDs, userID, bestEffort, ctx)
+		for taskID, applyErr := range applyErrors {
+			taskErrors[taskID] = applyErr
+		}
+		// A failure that is not tied to a task, such as a failed commit, is returned as is
+		if err != nil && len(applyErrors) == 0 {
+			return report, err
+		}
+		report.Committed = err == nil
+	}
+
+	for _, taskID := range taskIDs {
+		result := internalModels.BulkTaskResult{TaskID: taskID, Status: internalModels.BulkResultSuccess}
+		if taskErr, ok := taskErrors[taskID]; ok {
+			result.Status = internalModels.BulkResultFailed
+			result.Error = taskErr.Error()
+			report.Failed++
+		} else if !report.Committed {
+			result.Status = internalModels.BulkResultRolledBack
+		} else {
+			report.Succeeded++
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+// Gets the IDs of the tasks selected by a bulk request, either listed explicitly or matching a filter.
+// A filter only selects the tasks the user can see.
+func (c *TaskController) selectBulkTaskIDs(request internalModels.BulkTaskRequest, userID int, isManager bool, ctx context.Context) ([]int, error) {
+	var taskIDs []int
+	switch {
+	case len(request.TaskIDs) > 0:
+		taskIDs = uniqueIDs(request.TaskIDs)
+	case len(request.Filter) > 0:
+		filterValues := make(map[string]interface{}, len(request.Filter))
+		for key, value := range request.Filter {
+			switch key {
+			case "id", "author_id", "task_category_id":
+				valueConv, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s in filter", key)
+				}
+				filterValues[key] = valueConv
+			case "name", "description", "status":
+				filterValues[key] = value
+			default:
+				return nil, fmt.Errorf("cannot filter tasks by %s", key)
+			}
+		}
+		if !isManager {
+			filterValues["visible_to_user_id"] = userID
+		}
+		var err error
+		taskIDs, err = c.TaskRepository.GetTaskIDsByFilter(filterValues, ctx)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("either task_ids or filter is required")
+	}
+
+	if len(taskIDs) == 0 {
+		return nil, errors.New("no tasks match the selector")
+	}
+	if len(taskIDs) > MaxBulkTasks {
+		return nil, fmt.Errorf("the selector matches %d tasks, a bulk request can update at most %d tasks", len(taskIDs), MaxBulkTasks)
+	}
+	return taskIDs, nil
+}
+
+// Checks that an operation has the parameters it needs
+func validateBulkOperation(operation internalModels.BulkTaskOperation) error {
+	switch operation.Type {
+	case internalModels.BulkSetStatus:
+		if !repositories.IsValidTaskStatus(operation.Status) {
+			return fmt.Errorf("invalid status %q", operation.Status)
+		}
+	case internalModels.BulkSetCategory:
+		if operation.TaskCategoryID <= 0 {
+			return errors.New("task_category_id is required")
+		}
+	case internalModels.BulkAddAssignee, internalModels.BulkRemoveAssignee:
+		if operation.UserID <= 0 {
+			return errors.New("user_id is required")
+		}
+	case internalModels.BulkLock, internalModels.BulkUnlock, internalModels.BulkDelete:
+	default:
+		return fmt.Errorf("unknown operation %q", operation.Type)
+	}
+	return nil
+}
+
+// Gets the permission of a role letting it run an operation on the tasks of every category, following the
+// permissions of the single task endpoints
+func BulkOperationPermission(operation internalModels.BulkTaskOperation) internalModels.Permission {
+	switch {
+	case operation.Type == internalModels.BulkDelete:
+		return internalModels.PermissionTaskDelete
+	case operation.Type == internalModels.BulkLock, operation.Type == internalModels.BulkUnlock,
+		operation.Type == internalModels.BulkSetStatus && operation.Status == string(repositories.Lock):
+		return internalModels.PermissionTaskLock
+	default:
+		return internalModels.PermissionTaskManage
+	}
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+var ErrTaskCategoryForbidden = errors.New("you are not a manager or an owner of this task category")
+
+// Checks if a user has a permission on a task category. Managers have every permission, owners manage
+// their categories, and the public categories are seen by every user while the private ones are only
+// seen by their members.
+func HasTaskCategoryPermission(access internalModels.TaskCategoryAccess, isManager bool, permission internalModels.TaskCategoryPermission) bool {
+	if isManager {
+		return true
+	}
+	switch permission {
+	case internalModels.TaskCategoryView:
+		return access.Visibility == internalModels.TaskCategoryVisibilityPublic || access.Role != ""
+	case internalModels.TaskCategoryManage:
+		return access.Role == internalModels.TaskCategoryRoleOwner
+	}
+	return false
+}
+
+func ValidateTaskCategoryVisibility(visibility string) error {
+	if visibility != internalModels.TaskCategoryVisibilityPublic && visibility != internalModels.TaskCategoryVisibilityPrivate {
+		return fmt.Errorf("the visibility must be either '%s' or '%s'", internalModels.TaskCategoryVisibilityPublic, internalModels.TaskCategoryVisibilityPrivate)
+	}
+	return nil
+}
+
+func ValidateTaskCategoryRole(role string) error {
+	if role != internalModels.TaskCategoryRoleOwner && role != internalModels.TaskCategoryRoleMember {
+		return fmt.Errorf("the role must be either '%s' or '%s'", internalModels.TaskCategoryRoleOwner, internalModels.TaskCategoryRoleMember)
+	}
+	return nil
+}
+
+// Checks if a user has a permission on a task category. A category the user cannot see is not found,
+// so its existence is not revealed.
+func (c *TaskCategoryController) CheckTaskCategoryPermission(taskCategoryID, userID int, isManager bool, permission internalModels.TaskCategoryPermission, ctx context.Context) error {
+	access, err := c.TaskCategoryRepository.GetTaskCategoryAccess(taskCategoryID, userID, ctx)
+	if err != nil {
+		return err
+	}
+	if !HasTaskCategoryPermission(access, isManager, internalModels.TaskCategoryView) {
+		return repositories.ErrNoMatch
+	}
+	if !HasTaskCategoryPermission(access, isManager, permission) {
+		return ErrTaskCategoryForbidden
+	}
+	return nil
+}
+
+// Gets the visibility of a task category and the role of a user in it
+func (c *TaskCategoryController) GetTaskCategoryAccess(taskCategoryID, userID int, ctx context.Context) (internalModels.TaskCategoryAccess, error) {
+	return c.TaskCategoryRepository.GetTaskCategoryAccess(taskCategoryID, userID, ctx)
+}
+
+// Gets the visibility of every task category and the role of a user in them, by task category ID
+func (c *TaskCategoryController) GetTaskCategoryAccesses(userID int, ctx context.Context) (map[int]internalModels.TaskCategoryAccess, error) {
+	return c.TaskCategoryRepository.GetTaskCategoryAccesses(userID, ctx)
+}
+
+// Gets the task categories a user can see
+func (c *TaskCategoryController) GetVisibleTaskCategories(userID int, isManager bool, ctx context.Context) (models.TaskCategorySlice, error) {
+	taskCategories, err := c.TaskCategoryRepository.GetAllTaskCategories(ctx)
+	if err != nil || isManager {
+		return taskCategories, err
+	}
+	accesses, err := c.TaskCategoryRepository.GetTaskCategoryAccesses(userID, ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := models.TaskCategorySlice{}
+	for _, taskCategory := range taskCategories {
+		if HasTaskCategoryPermission(accesses[taskCategory.ID], false, internalModels.TaskCategoryView) {
+			visible = append(visible, taskCategory)
+		}
+	}
+	return visible, nil
+}
+
+func (c *TaskCategoryController) GetTaskCategoryMembers(taskCategoryID int, ctx context.Context) ([]internalModels.TaskCategoryMember, error) {
+	return c.TaskCategoryRepository.GetTaskCategoryMembers(taskCategoryID, ctx)
+}
+
+// Adds a user to a task category with a role, or changes the role of a member
+func (c *TaskCategoryController) SetTaskCategoryMember(member internalModels.TaskCategoryMember, ctx context.Context) (internalModels.TaskCategoryMember, error) {
+	if err := ValidateTaskCategoryRole(member.Role); err != nil {
+		return member, err
+	}
+	return c.TaskCategoryRepository.SetTaskCategoryMember(member, ctx)
+}
+
+func (c *TaskCategoryController) RemoveTaskCategoryMember(taskCategoryID, userID int, ctx context.Context) error {
+	return c.TaskCategoryRepository.RemoveTaskCategoryMember(taskCategoryID, userID, ctx)
+}
+
+// Keeps the tasks of the task categories a user can see
+func (c *TaskCategoryController) FilterVisibleTasks(tasks models.TaskSlice, userID int, isManager bool, ctx context.Context) (models.TaskSlice, error) {
+	if isManager {
+		return tasks, nil
+	}
+	accesses, err := c.TaskCategoryRepository.GetTaskCategoryAccesses(userID, ctx)
+	if err != nil {
+		return nil, err
+	}
+	visible := models.TaskSlice{}
+	for _, task := range tasks {
+		if HasTaskCategoryPermission(accesses[task.TaskCategoryID], false, internalModels.TaskCategoryView) {
+			visible = append(visible, task)
+		}
+	}
+	return visible, nil
+}
@@ -2,12 +2,12 @@ package controllers
 
 import (
 	"context"
-	"encoding/csv"
-	"os"
-	"regexp"
+	"errors"
 
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
 )
 
 type TaskCategoryController struct {
@@ -26,7 +26,25 @@ func (c *TaskCategoryController) GetAllTaskCategories(ctx context.Context) (mode
 	return taskCategories, nil
 }
 
+// Gets the tree of the task categories a user can see, a category under a hidden parent is a root
+func (c *TaskCategoryController) GetTaskCategoryTree(userID int, isManager bool, ctx context.Context) ([]*internalModels.TaskCategoryNode, error) {
+	taskCategories, err := c.GetVisibleTaskCategories(userID, isManager, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTaskCategoryTree(taskCategories), nil
+}
+
 func (c *TaskCategoryController) AddTaskCategory(taskCategory *models.TaskCategory, ctx context.Context) error {
+	if taskCategory.ParentID.Valid {
+		taskCategories, err := c.TaskCategoryRepository.GetAllTaskCategories(ctx)
+		if err != nil {
+			return err
+		}
+		if err := ValidateTaskCategoryParent(taskCategories, 0, taskCategory.ParentID); err != nil {
+			return err
+		}
+	}
 	err := c.TaskCategoryRepository.AddTaskCategory(taskCategory, ctx)
 	if err != nil {
 		return err
@@ -42,10 +60,24 @@ func (c *TaskCategoryController) GetTaskCategoryByID(taskCategoryID int, ctx con
 	return taskCategory, nil
 }
 
-func (c *TaskCategoryController) DeleteTaskCategory(taskCategoryID int, ctx context.Context) error {
-	err := c.TaskCategoryRepository.DeleteTaskCategory(taskCategoryID, ctx)
-	if err != nil {
-		return err
+// Moves a task category to the trash, its tasks are reassigned or deleted by the policy
+func (c *TaskCategoryController) DeleteTaskCategory(taskCategoryID, deletedBy int, policy internalModels.TaskCategoryDeletePolicy, ctx context.Context) (internalModels.TaskCategoryDeleteResult, error) {
+	if err := ValidateTaskCategoryDeletePolicy(taskCategoryID, policy); err != nil {
+		return internalModels.TaskCategoryDeleteResult{TaskCategoryID: taskCategoryID}, err
+	}
+	return c.TaskCategoryRepository.DeleteTaskCategory(taskCategoryID, deletedBy, policy, ctx)
+}
+
+// Checks that the tasks of a deleted task category are either reassigned to another category or deleted
+func ValidateTaskCategoryDeletePolicy(taskCategoryID int, policy internalModels.TaskCategoryDeletePolicy) error {
+	if policy.ReassignTo.Valid && policy.Cascade {
+		return errors.New("the tasks cannot be both reassigned and deleted")
+	}
+	if policy.ReassignTo.Valid && policy.ReassignTo.Int == taskCategoryID {
+		return errors.New("the tasks cannot be reassigned to the deleted task category")
+	}
+	if policy.ReassignTo.Valid && policy.ReassignTo.Int <= 0 {
+		return errors.New("invalid task category to reassign the tasks to")
 	}
 	return nil
 }
@@ -64,52 +96,70 @@ func (c *TaskCategoryController) UpdateTaskCategory(taskCategoryID int, taskCate
 	return taskCategoryUpdated, nil
 }
 
-// Import task categories data from a CSV file
-func (re *TaskCategoryController) ImportTaskCategoryDataFromCSV(path string) ([]models.TaskCategory, error) {
-	// Create a slice to store the taskCategory data
-	taskCategoryList := []models.TaskCategory{}
-
-	// Open the CSV file
-	file, err := os.Open("./data/task-category.csv")
+func (c *TaskCategoryController) GetTasksByCategory(taskCategoryID int, ctx context.Context) (models.TaskSlice, error) {
+	tasks, err := c.TaskCategoryRepository.GetTasksByCategory(taskCategoryID, ctx)
 	if err != nil {
-		return taskCategoryList, err
+		return tasks, err
 	}
-	defer file.Close()
+	return tasks, nil
+}
 
-	// Create a new CSV reader
-	reader := csv.NewReader(file)
-	reader.TrimLeadingSpace = true // Trim leading spaces around fields
+func (c *TaskCategoryController) GetTaskCategorySettings(taskCategoryID int, ctx context.Context) (internalModels.TaskCategorySettings, error) {
+	settings, err := c.TaskCategoryRepository.GetTaskCategorySettings(taskCategoryID, ctx)
+	if err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
 
-	// Read all the records from the CSV file
-	records, err := reader.ReadAll()
+func (c *TaskCategoryController) UpdateTaskCategorySettings(taskCategoryID int, settings internalModels.TaskCategorySettings, ctx context.Context) (internalModels.TaskCategorySettings, error) {
+	settings.TaskCategoryID = taskCategoryID
+	if settings.Visibility == "" {
+		settings.Visibility = internalModels.TaskCategoryVisibilityPublic
+	}
+	err := c.TaskCategoryRepository.UpdateTaskCategorySettings(settings, ctx)
 	if err != nil {
-		return taskCategoryList, err
+		return settings, err
 	}
+	return settings, nil
+}
 
-	// Loop through the records and create a new TaskCategory struct for each record
-	for i, record := range records {
-		// Skip the first index, which is the header name
-		if i == 0 {
-			continue
+// Moves a task category under another one, or to the root when the parent is null
+func (c *TaskCategoryController) MoveTaskCategory(taskCategoryID int, parentID null.Int, ctx context.Context) (*models.TaskCategory, error) {
+	err := c.TaskCategoryRepository.MoveTaskCategory(taskCategoryID, parentID, func(taskCategories models.TaskCategorySlice) error {
+		if !taskCategoryExists(taskCategories, taskCategoryID) {
+			return repositories.ErrNoMatch
 		}
+		return ValidateTaskCategoryParent(taskCategories, taskCategoryID, parentID)
+	}, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTaskCategoryByID(taskCategoryID, ctx)
+}
 
-		// remove unexpected characters
-		re := regexp.MustCompile("[^a-zA-Z0-9]+")
-		name := re.ReplaceAllString(record[0], "")
-
-		taskCategory := models.TaskCategory{
-			Name: name,
+// Moves the tasks and the children of a task category to a target category and moves the source
+// category to the trash
+func (c *TaskCategoryController) MergeTaskCategory(sourceID, targetID, deletedBy int, ctx context.Context) (internalModels.TaskCategoryMergeResult, error) {
+	result := internalModels.TaskCategoryMergeResult{SourceID: sourceID, TargetID: targetID}
+	movedTasks, err := c.TaskCategoryRepository.MergeTaskCategory(sourceID, targetID, deletedBy, func(taskCategories models.TaskCategorySlice) error {
+		if !taskCategoryExists(taskCategories, sourceID) {
+			return repositories.ErrNoMatch
 		}
-		taskCategoryList = append(taskCategoryList, taskCategory)
+		return ValidateTaskCategoryMerge(taskCategories, sourceID, targetID)
+	}, ctx)
+	if err != nil {
+		return result, err
 	}
-
-	return taskCategoryList, nil
+	result.MovedTasks = movedTasks
+	return result, nil
 }
 
-func (c *TaskCategoryController) GetTasksByCategory(taskCategoryID int, ctx context.Context) (models.TaskSlice, error) {
-	tasks, err := c.TaskCategoryRepository.GetTasksByCategory(taskCategoryID, ctx)
-	if err != nil {
-		return tasks, err
+func taskCategoryExists(taskCategories models.TaskCategorySlice, taskCategoryID int) bool {
+	for _, taskCategory := range taskCategories {
+		if taskCategory.ID == taskCategoryID {
+			return true
+		}
 	}
-	return tasks, nil
+	return false
 }
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+// Deepest level of the category tree, root categories are at level 1
+const MaxTaskCategoryDepth = 5
+
+// Separator of the names in the path of a category
+const taskCategoryPathSeparator = " / "
+
+// Builds the tree of the given categories. Categories whose parent is not in the list are roots,
+// and the categories of a level are sorted by name.
+func BuildTaskCategoryTree(categories models.TaskCategorySlice) []*internalModels.TaskCategoryNode {
+	nodes := make(map[int]*internalModels.TaskCategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &internalModels.TaskCategoryNode{
+			ID:       category.ID,
+			Name:     category.Name,
+			ParentID: category.ParentID,
+			Children: []*internalModels.TaskCategoryNode{},
+		}
+	}
+	roots := []*internalModels.TaskCategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if parent, ok := nodes[category.ParentID.Int]; category.ParentID.Valid && ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	sortTaskCategoryNodes(roots, "", 1)
+	return roots
+}
+
+func sortTaskCategoryNodes(nodes []*internalModels.TaskCategoryNode, parentPath string, depth int) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Name != nodes[j].Name {
+			return nodes[i].Name < nodes[j].Name
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	for _, node := range nodes {
+		node.Depth = depth
+		node.Path = node.Name
+		if parentPath != "" {
+			node.Path = parentPath + taskCategoryPathSeparator + node.Name
+		}
+		sortTaskCategoryNodes(node.Children, node.Path, depth+1)
+	}
+}
+
+// Checks that a category can be placed under a parent: the parent must exist, must not be the category
+// or one of its descendants, and the subtree of the category must fit under the depth limit.
+// The category ID is 0 for a new category.
+func ValidateTaskCategoryParent(categories models.TaskCategorySlice, categoryID int, parentID null.Int) error {
+	if !parentID.Valid {
+		return nil
+	}
+	parents := taskCategoryParents(categories)
+	if _, ok := parents[parentID.Int]; !ok {
+		return fmt.Errorf("parent task category %d does not exist", parentID.Int)
+	}
+	if parentID.Int == categoryID {
+		return errors.New("a task category cannot be its own parent")
+	}
+	parentDepth, underCategory := taskCategoryDepth(parents, parentID.Int, categoryID)
+	if underCategory {
+		return errors.New("a task category cannot be moved under one of its descendants")
+	}
+	height := 1
+	if categoryID != 0 {
+		height = taskCategorySubtreeHeight(categories, categoryID)
+	}
+	if parentDepth+height > MaxTaskCategoryDepth {
+		return fmt.Errorf("task categories cannot be nested more than %d levels deep", MaxTaskCategoryDepth)
+	}
+	return nil
+}
+
+// Checks that a category can be merged into a target category, which takes its tasks and its children.
+// The target must exist and must not be the category or one of its descendants, and the children must
+// fit under the depth limit once they are moved under the target.
+func ValidateTaskCategoryMerge(categories models.TaskCategorySlice, sourceID, targetID int) error {
+	parents := taskCategoryParents(categories)
+	if _, ok := parents[targetID]; !ok {
+		return fmt.Errorf("target task category %d does not exist", targetID)
+	}
+	if sourceID == targetID {
+		return errors.New("a task category cannot be merged into itself")
+	}
+	targetDepth, underSource := taskCategoryDepth(parents, targetID, sourceID)
+	if underSource {
+		return errors.New("a task category cannot be merged into one of its descendants")
+	}
+	if targetDepth+taskCategorySubtreeHeight(categories, sourceID)-1 > MaxTaskCategoryDepth {
+		return fmt.Errorf("task categories cannot be nested more than %d levels deep", MaxTaskCategoryDepth)
+	}
+	return nil
+}
+
+func taskCategoryParents(categories models.TaskCategorySlice) map[int]null.Int {
+	parents := make(map[int]null.Int, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	return parents
+}
+
+// Gets the depth of a category by going up to its root, and whether the given ancestor was met on the way
+func taskCategoryDepth(parents map[int]null.Int, categoryID, ancestorID int) (int, bool) {
+	depth := 0
+	for id := null.IntFrom(categoryID); id.Valid; {
+		if ancestorID != 0 && id.Int == ancestorID {
+			return depth, true
+		}
+		depth++
+		next, ok := parents[id.Int]
+		// The depth guards against a cycle in the stored parents
+		if !ok || depth > len(parents) {
+			break
+		}
+		id = next
+	}
+	return depth, false
+}
+
+// Gets the number of levels of the subtree of a category, 1 for a category without children
+func taskCategorySubtreeHeight(categories models.TaskCategorySlice, categoryID int) int {
+	children := make(map[int][]int)
+	for _, category := range categories {
+		if category.ParentID.Valid {
+			children[category.ParentID.Int] = append(children[category.ParentID.Int], category.ID)
+		}
+	}
+	var height func(id, level int) int
+	height = func(id, level int) int {
+		max := 1
+		// The level guards against a cycle in the stored parents
+		if level > len(categories) {
+			return max
+		}
+		for _, child := range children[id] {
+			if h := height(child, level+1) + 1; h > max {
+				max = h
+			}
+		}
+		return max
+	}
+	return height(categoryID, 1)
+}
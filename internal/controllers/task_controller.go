@@ -2,25 +2,60 @@ package controllers
 
 import (
 	"context"
-	"encoding/csv"
-	"errors"
-	"os"
-	"regexp"
-	"strconv"
-	"strings"
+	"encoding/json"
+	"log"
 	"time"
 
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
-	"github.com/volatiletech/null/v8"
 )
 
+// Job type archiving the completed tasks, each run queues the next one
+const JobTypeArchiveCompletedTasks = "archive_completed_tasks"
+
+// Delay between the runs of the archiving job
+const archiveJobInterval = time.Hour
+
 type TaskController struct {
-	TaskRepository *repositories.TaskRepository
+	TaskRepository      *repositories.TaskRepository
+	ChecklistRepository *repositories.ChecklistRepository
+}
+
+func NewTaskController(taskRepository *repositories.TaskRepository, checklistRepository *repositories.ChecklistRepository) *TaskController {
+	return &TaskController{TaskRepository: taskRepository, ChecklistRepository: checklistRepository}
 }
 
-func NewTaskController(taskRepository *repositories.TaskRepository) *TaskController {
-	return &TaskController{TaskRepository: taskRepository}
+// Adds the values computed from related records, such as the checklist completion and the SLA state, to the tasks
+func (c *TaskController) GetTaskDetails(tasks models.TaskSlice, ctx context.Context) ([]internalModels.TaskDetail, error) {
+	details := make([]internalModels.TaskDetail, 0, len(tasks))
+	taskIDs := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+	}
+	progress, err := c.ChecklistRepository.GetChecklistProgress(taskIDs, ctx)
+	if err != nil {
+		return details, err
+	}
+	slaStates, err := c.TaskRepository.GetTaskSLAStates(taskIDs, ctx)
+	if err != nil {
+		return details, err
+	}
+	now := time.Now()
+	for _, task := range tasks {
+		p := progress[task.ID]
+		sla := ComputeTaskSLA(slaStates[task.ID], now)
+		details = append(details, internalModels.TaskDetail{
+			Task:                task,
+			ChecklistTotal:      p.Total,
+			ChecklistDone:       p.Done,
+			ChecklistCompletion: p.Percentage(),
+			SLAStatus:           sla.Status,
+			SLADueAt:            sla.DueAt,
+			SLARemainingSeconds: sla.RemainingSeconds,
+		})
+	}
+	return details, nil
 }
 
 func (c *TaskController) GetAllTasks(ctx context.Context, filterValues map[string]interface{}) (models.TaskSlice, error) {
@@ -47,31 +82,50 @@ func (c *TaskController) GetTaskByID(taskID int, ctx context.Context) (*models.T
 	return task, nil
 }
 
-func (c *TaskController) DeleteTask(taskID int, ctx context.Context) error {
-	err := c.TaskRepository.DeleteTask(taskID, ctx)
+func (c *TaskController) DeleteTask(taskID, deletedBy int, ctx context.Context) error {
+	err := c.TaskRepository.DeleteTask(taskID, deletedBy, ctx)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *TaskController) UpdateTask(taskID int, taskData models.Task, ctx context.Context, isManager bool) (*models.Task, error) {
+// Updates a task on behalf of an actor, who may only change the fields the task policy allows them to.
+// The author and assignee flags of the actor are filled from the task.
+func (c *TaskController) UpdateTask(taskID int, taskData models.Task, actor internalModels.TaskActor, ctx context.Context) (*models.Task, error) {
 	task, err := c.TaskRepository.GetTaskByID(taskID, ctx)
 	if err != nil {
 		return task, err
 	}
+	assigned, err := c.TaskRepository.GetAssignedTaskIDs(actor.UserID, []int{task.ID}, ctx)
+	if err != nil {
+		return task, err
+	}
+	actor.Author = task.AuthorID == actor.UserID
+	actor.Assignee = assigned[task.ID]
+	if err := AuthorizeTaskUpdate(actor, task, taskData); err != nil {
+		return task, err
+	}
+
+	wasComplete := task.Status.String == string(repositories.Complete)
 
 	task.Name = taskData.Name
 	task.Description = taskData.Description
 	task.StartDate = taskData.StartDate
 	task.EndDate = taskData.EndDate
 	task.Status = taskData.Status
-	if task.Status.String == "Lock" && !isManager {
-		return task, errors.New("you are not the manager, cannot lock this task")
-	}
 	task.AuthorID = taskData.AuthorID
 	task.UpdatedAt = time.Now()
 	task.TaskCategoryID = taskData.TaskCategoryID
+	if task.Status.String == string(repositories.Complete) && !wasComplete {
+		blocked, err := c.ChecklistRepository.BlocksCompletion(task.ID, task.TaskCategoryID, ctx)
+		if err != nil {
+			return task, err
+		}
+		if blocked {
+			return task, repositories.ErrChecklistIncomplete
+		}
+	}
 
 	taskUpdated, err := c.TaskRepository.UpdateTask(task, ctx)
 	if err != nil {
@@ -96,102 +150,50 @@ func (c *TaskController) UnLockTask(taskID int, ctx context.Context) error {
 	return nil
 }
 
-// Import tasks data from a CSV file
-func (c *TaskController) ImportTaskDataFromCSV(path string) ([]models.Task, error) {
-	// Create a slice to store the task data
-	taskList := []models.Task{}
-
-	// Open the CSV file
-	file, err := os.Open(path)
+func (c *TaskController) ArchiveTask(taskID int, ctx context.Context) error {
+	err := c.TaskRepository.ArchiveTask(taskID, ctx)
 	if err != nil {
-		return taskList, err
+		return err
 	}
-	defer file.Close()
-
-	// Create a new CSV reader
-	reader := csv.NewReader(file)
-	// Trim leading spaces around fields
-	reader.TrimLeadingSpace = true
+	return nil
+}
 
-	// Read all the records from the CSV file
-	records, err := reader.ReadAll()
+func (c *TaskController) UnarchiveTask(taskID int, ctx context.Context) error {
+	err := c.TaskRepository.UnarchiveTask(taskID, ctx)
 	if err != nil {
-		return taskList, err
+		return err
 	}
+	return nil
+}
 
-	// Layout for time.Time field in struct
-	const layoutDate = "2006-01-02T15:04:05Z"
-
-	// Loop through the records and create a new Task struct for each record
-	for i, record := range records {
-		// Skip the first index, which is the header name
-		if i == 0 {
-			continue
-		}
-		data := strings.Split(record[0], ",")
-		// remove unexpected characters
-		re := regexp.MustCompile("[^a-zA-Z0-9 ]+")
-
-		// regex for Name
-		name := re.ReplaceAllString(data[0], "")
-
-		// regex for description
-		description := re.ReplaceAllString(data[1], "")
-
-		// convert start date from string to time.Time
-		startDate, err := time.Parse(layoutDate, data[2])
-		if err != nil {
-			return taskList, err
-		}
-
-		// convert end date from string to time.Time
-		endDate, err := time.Parse(layoutDate, data[3])
-		if err != nil {
-			return taskList, err
-		}
-
-		// regex for task status
-		status := re.ReplaceAllString(data[4], "")
+// Archives the complete tasks that were completed more than the given duration ago
+func (c *TaskController) ArchiveCompletedTasks(after time.Duration, ctx context.Context) (int64, error) {
+	return c.TaskRepository.ArchiveTasksCompletedBefore(time.Now().Add(-after), ctx)
+}
 
-		// convert authorID from string to int
-		authorID, err := strconv.Atoi(data[5])
-		if err != nil {
-			return taskList, err
+// Registers the job type archiving the completed tasks. A run queues the next one an hour later with the same
+// payload before archiving, so a failed attempt does not stop the schedule.
+func (c *TaskController) RegisterJobs(registry *JobRegistry, jobController *JobController) {
+	registry.Register(JobTypeArchiveCompletedTasks, func(ctx context.Context, job internalModels.Job, progress func(int)) (interface{}, error) {
+		payload := internalModels.ArchiveCompletedTasksJob{}
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, err
 		}
-
-		// convert createdAt from string to time.Time
-		createdAt, err := time.Parse(layoutDate, data[6])
-		if err != nil {
-			return taskList, err
+		if payload.AfterDays <= 0 {
+			return nil, nil
 		}
-
-		// convert updatedAt from string to time.Time
-		updatedAt, err := time.Parse(layoutDate, data[7])
-		if err != nil {
-			return taskList, err
+		if _, err := jobController.ScheduleJob(internalModels.NewJob{Type: JobTypeArchiveCompletedTasks, Payload: payload, RunAt: time.Now().Add(archiveJobInterval)}, ctx); err != nil {
+			return nil, err
 		}
-
-		// convert taskCategoryID from string to int
-		taskCategoryID, err := strconv.Atoi(data[8])
+		count, err := c.ArchiveCompletedTasks(time.Duration(payload.AfterDays)*24*time.Hour, ctx)
 		if err != nil {
-			return taskList, err
+			return nil, err
 		}
-
-		task := models.Task{
-			Name:           name,
-			Description:    description,
-			StartDate:      startDate,
-			EndDate:        endDate,
-			Status:         null.StringFrom(status),
-			AuthorID:       authorID,
-			CreatedAt:      createdAt,
-			UpdatedAt:      updatedAt,
-			TaskCategoryID: taskCategoryID,
+		if count > 0 {
+			log.Printf("Archived %d completed tasks", count)
 		}
-		taskList = append(taskList, task)
-	}
-
-	return taskList, nil
+		return map[string]int64{"archived": count}, nil
+	}, 0, 0)
 }
 
 func (c *TaskController) GetTaskCategoryOfTask(taskID int, ctx context.Context) (*models.TaskCategory, error) {
@@ -210,22 +212,6 @@ func (c *TaskController) GetTasksByName(name string, ctx context.Context) (model
 	return tasks, nil
 }
 
-func (c *TaskController) GetTaskCount(ctx context.Context) (int64, error) {
-	count, err := c.TaskRepository.GetTaskCount(ctx)
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
-}
-
-func (c *TaskController) CountFilteredStatusTask(status string, ctx context.Context) (int64, error) {
-	count, err := c.TaskRepository.CountFilteredStatusTask(status, ctx)
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
-}
-
 // func (c *TaskController) FilterTasks(filterValues map[string]string, ctx context.Context) (models.TaskSlice, error) {
 // 	tasks, err := c.TaskRepository.FilterTasks(filterValues, ctx)
 // 	if err != nil {
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+// Number of assignees listed by open workload in a dashboard
+const dashboardTopAssignees = 5
+
+// Gets the end of the week of a time, which is the start of the next Monday
+func DashboardWeekEnd(now time.Time) time.Time {
+	daysToMonday := 7 - (int(now.Weekday())+6)%7
+	return time.Date(now.Year(), now.Month(), now.Day()+daysToMonday, 0, 0, 0, 0, now.Location())
+}
+
+// Gets the summary of the tasks a user authored or is assigned to, and of all tasks for managers
+func (c *TaskController) GetDashboard(userID int, isManager bool, now time.Time, ctx context.Context) (internalModels.Dashboard, error) {
+	dashboard := internalModels.Dashboard{}
+	weekEnd := DashboardWeekEnd(now)
+	me, err := c.TaskRepository.GetTaskSummary(userID, now, weekEnd, dashboardTopAssignees, ctx)
+	if err != nil {
+		return dashboard, err
+	}
+	dashboard.Me = me
+	if isManager {
+		global, err := c.TaskRepository.GetTaskSummary(0, now, weekEnd, dashboardTopAssignees, ctx)
+		if err != nil {
+			return dashboard, err
+		}
+		dashboard.Global = &global
+	}
+	return dashboard, nil
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+// Headers of a CSV export. The task fields use the headers read by the CSV import, so an export can be
+// imported again, and mapping external_id to id upserts the exported tasks.
+var TaskExportColumns = []string{
+	"id", "name", "description", "start_date", "end_date", "status", "author_id", "task_category_id",
+	"task_category_name", "assignee_ids", "assignee_emails", "external_source", "external_id", "archived_at",
+	"created_at", "updated_at",
+}
+
+// Separates the assignees in a cell of a CSV export
+const exportListSeparator = ";"
+
+// Writes exported tasks one at a time in one of the export formats
+type TaskExportWriter struct {
+	format string
+	writer io.Writer
+	csv    *csv.Writer
+	count  int
+}
+
+func NewTaskExportWriter(format string, writer io.Writer) (*TaskExportWriter, error) {
+	exportWriter := &TaskExportWriter{format: format, writer: writer}
+	switch format {
+	case internalModels.ExportFormatCSV:
+		exportWriter.csv = csv.NewWriter(writer)
+	case internalModels.ExportFormatNDJSON, internalModels.ExportFormatJSON:
+	default:
+		return nil, fmt.Errorf("invalid export format %q, must be csv, ndjson or json", format)
+	}
+	return exportWriter, nil
+}
+
+func (w *TaskExportWriter) ContentType() string {
+	switch w.format {
+	case internalModels.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case internalModels.ExportFormatNDJSON:
+		return "application/x-ndjson"
+	}
+	return "application/json"
+}
+
+// Writes what comes before the first task: the header of a CSV file or the start of a JSON array
+func (w *TaskExportWriter) Begin() error {
+	switch w.format {
+	case internalModels.ExportFormatCSV:
+		return w.csv.Write(TaskExportColumns)
+	case internalModels.ExportFormatJSON:
+		_, err := io.WriteString(w.writer, "[")
+		return err
+	}
+	return nil
+}
+
+func (w *TaskExportWriter) Write(task internalModels.TaskExport) error {
+	defer func() { w.count++ }()
+	if w.format == internalModels.ExportFormatCSV {
+		return w.csv.Write(TaskExportRecord(task))
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	if w.format == internalModels.ExportFormatJSON {
+		if w.count > 0 {
+			data = append([]byte(","), data...)
+		}
+	} else {
+		data = append(data, '\n')
+	}
+	_, err = w.writer.Write(data)
+	return err
+}
+
+// Writes what comes after the last task and flushes the buffered rows
+func (w *TaskExportWriter) End() error {
+	if w.format == internalModels.ExportFormatJSON {
+		if _, err := io.WriteString(w.writer, "]\n"); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+// Writes the buffered rows to the underlying writer
+func (w *TaskExportWriter) Flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// Gets the cells of an exported task in the order of TaskExportColumns
+func TaskExportRecord(task internalModels.TaskExport) []string {
+	assigneeIDs := make([]string, 0, len(task.AssigneeIDs))
+	for _, id := range task.AssigneeIDs {
+		assigneeIDs = append(assigneeIDs, strconv.Itoa(id))
+	}
+	archivedAt := ""
+	if task.ArchivedAt.Valid {
+		archivedAt = formatExportTime(task.ArchivedAt.Time)
+	}
+	return []string{
+		strconv.Itoa(task.ID),
+		task.Name,
+		task.Description,
+		formatExportTime(task.StartDate),
+		formatExportTime(task.EndDate),
+		task.Status.String,
+		strconv.Itoa(task.AuthorID),
+		strconv.Itoa(task.TaskCategoryID),
+		task.TaskCategoryName,
+		strings.Join(assigneeIDs, exportListSeparator),
+		strings.Join(task.AssigneeEmails, exportListSeparator),
+		task.ExternalSource.String,
+		task.ExternalID.String,
+		archivedAt,
+		formatExportTime(task.CreatedAt),
+		formatExportTime(task.UpdatedAt),
+	}
+}
+
+func formatExportTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
+
+// Calls fn with each task matching the filter values, the tasks are read from the database one at a time
+func (c *TaskController) ExportTasks(filterValues map[string]interface{}, fn func(internalModels.TaskExport) error, ctx context.Context) error {
+	err := c.TaskRepository.ForEachTaskExport(filterValues, fn, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
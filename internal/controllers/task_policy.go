@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+var ErrTaskForbidden = errors.New("you are not allowed to change this task")
+
+// The fields of a task its authors and assignees may change to report their progress. The other fields
+// can only be changed by the managers of the task.
+var taskProgressFields = map[string]bool{"description": true, "status": true}
+
+// Gets the fields of a task an update changes, by their JSON name. Dates are compared to the second,
+// the precision of the calendar clients.
+func changedTaskFields(task *models.Task, taskData models.Task) []string {
+	fields := []string{}
+	if task.Name != taskData.Name {
+		fields = append(fields, "name")
+	}
+	if task.Description != taskData.Description {
+		fields = append(fields, "description")
+	}
+	if !task.StartDate.Truncate(time.Second).Equal(taskData.StartDate.Truncate(time.Second)) {
+		fields = append(fields, "start_date")
+	}
+	if !task.EndDate.Truncate(time.Second).Equal(taskData.EndDate.Truncate(time.Second)) {
+		fields = append(fields, "end_date")
+	}
+	if task.Status != taskData.Status {
+		fields = append(fields, "status")
+	}
+	if task.AuthorID != taskData.AuthorID {
+		fields = append(fields, "author_id")
+	}
+	if task.TaskCategoryID != taskData.TaskCategoryID {
+		fields = append(fields, "task_category_id")
+	}
+	return fields
+}
+
+func isLocked(status null.String) bool {
+	return status.String == string(repositories.Lock)
+}
+
+// Decides whether an actor may update a task with the values of taskData. Authors and assignees may change
+// the progress fields, the managers of the task may change every field, and a locked task can only be
+// changed by the actors who may unlock it. Returns an error wrapping ErrTaskForbidden otherwise.
+func AuthorizeTaskUpdate(actor internalModels.TaskActor, task *models.Task, taskData models.Task) error {
+	if !actor.Manager && !actor.Author && !actor.Assignee {
+		return fmt.Errorf("%w, only its managers, author and assignees can change it", ErrTaskForbidden)
+	}
+	if (isLocked(task.Status) || isLocked(taskData.Status)) && !actor.Locker {
+		if isLocked(task.Status) {
+			return fmt.Errorf("%w, the task is locked", ErrTaskForbidden)
+		}
+		return fmt.Errorf("%w, you are not the manager, cannot lock this task", ErrTaskForbidden)
+	}
+	if actor.Manager {
+		return nil
+	}
+	managed := []string{}
+	for _, field := range changedTaskFields(task, taskData) {
+		if !taskProgressFields[field] {
+			managed = append(managed, field)
+		}
+	}
+	if len(managed) > 0 {
+		return fmt.Errorf("%w, only its managers can change %s", ErrTaskForbidden, strings.Join(managed, ", "))
+	}
+	return nil
+}
+
+// Decides whether an actor may assign users to a task or remove its assignees
+func AuthorizeTaskAssignment(actor internalModels.TaskActor) error {
+	if !actor.Manager {
+		return fmt.Errorf("%w, only its managers can change its assignees", ErrTaskForbidden)
+	}
+	return nil
+}
+
+// Decides whether an actor may run a bulk operation on a task, following the policy of the single task
+// endpoints
+func AuthorizeBulkOperation(actor internalModels.TaskActor, operation internalModels.BulkTaskOperation, task *models.Task) error {
+	update := *task
+	switch operation.Type {
+	case internalModels.BulkSetStatus:
+		update.Status = null.StringFrom(operation.Status)
+	case internalModels.BulkLock, internalModels.BulkUnlock:
+		if !actor.Locker {
+			return fmt.Errorf("%w, you are not the manager, cannot lock or unlock this task", ErrTaskForbidden)
+		}
+		return nil
+	case internalModels.BulkSetCategory:
+		update.TaskCategoryID = operation.TaskCategoryID
+	case internalModels.BulkAddAssignee, internalModels.BulkRemoveAssignee:
+		return AuthorizeTaskAssignment(actor)
+	case internalModels.BulkDelete:
+		if !actor.Manager {
+			return fmt.Errorf("%w, only its managers can delete it", ErrTaskForbidden)
+		}
+		return nil
+	}
+	return AuthorizeTaskUpdate(actor, task, update)
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+// Business hours of the SLA policies, from Monday to Friday in UTC
+const (
+	businessDayStartHour = 9
+	businessDayEndHour   = 17
+)
+
+// Share of the time to a deadline under which a task is at risk
+const slaAtRiskShare = 0.25
+
+// Gets the time the given number of business hours after a start time, business hours run
+// from 9:00 to 17:00 UTC on weekdays
+func AddBusinessHours(start time.Time, hours int) time.Time {
+	current := start.UTC()
+	remaining := time.Duration(hours) * time.Hour
+	for {
+		current = nextBusinessTime(current)
+		dayEnd := time.Date(current.Year(), current.Month(), current.Day(), businessDayEndHour, 0, 0, 0, time.UTC)
+		available := dayEnd.Sub(current)
+		if remaining <= available {
+			return current.Add(remaining)
+		}
+		remaining -= available
+		current = dayEnd
+	}
+}
+
+// Gets the first business time at or after a time
+func nextBusinessTime(t time.Time) time.Time {
+	for {
+		dayStart := time.Date(t.Year(), t.Month(), t.Day(), businessDayStartHour, 0, 0, 0, time.UTC)
+		dayEnd := time.Date(t.Year(), t.Month(), t.Day(), businessDayEndHour, 0, 0, 0, time.UTC)
+		weekend := t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+		switch {
+		case weekend || !t.Before(dayEnd):
+			t = dayStart.AddDate(0, 0, 1)
+		case t.Before(dayStart):
+			return dayStart
+		default:
+			return t
+		}
+	}
+}
+
+// Checks that the response times of an SLA policy are positive
+func ValidateSLAPolicy(policy internalModels.SLAPolicy) error {
+	if policy.StartWithinHours.Valid && policy.StartWithinHours.Int <= 0 {
+		return errors.New("start_within_hours must be positive")
+	}
+	if policy.CompleteWithinBusinessHours.Valid && policy.CompleteWithinBusinessHours.Int <= 0 {
+		return errors.New("complete_within_business_hours must be positive")
+	}
+	if policy.EscalateToUserID.Valid && !policy.IsSet() {
+		return errors.New("an escalation user needs a response time")
+	}
+	return nil
+}
+
+// Computes the state of a task against the SLA policy of its category. A missed deadline keeps the
+// task breached, and the due time is the next deadline the task has not reached yet.
+func ComputeTaskSLA(state internalModels.TaskSLAState, now time.Time) internalModels.TaskSLA {
+	sla := internalModels.TaskSLA{Status: internalModels.SLAStatusNone, Breaches: []string{}}
+	if !state.Policy.IsSet() {
+		return sla
+	}
+	type deadline struct {
+		breach  string
+		dueAt   time.Time
+		reached null.Time
+	}
+	deadlines := []deadline{}
+	if state.Policy.StartWithinHours.Valid {
+		dueAt := state.CreatedAt.Add(time.Duration(state.Policy.StartWithinHours.Int) * time.Hour)
+		deadlines = append(deadlines, deadline{breach: internalModels.SLABreachStart, dueAt: dueAt, reached: state.StartedAt})
+	}
+	if state.Policy.CompleteWithinBusinessHours.Valid {
+		dueAt := AddBusinessHours(state.CreatedAt, state.Policy.CompleteWithinBusinessHours.Int)
+		deadlines = append(deadlines, deadline{breach: internalModels.SLABreachComplete, dueAt: dueAt, reached: state.CompletedAt})
+	}
+
+	var next *deadline
+	for i := range deadlines {
+		d := &deadlines[i]
+		if d.reached.Valid {
+			if d.reached.Time.After(d.dueAt) {
+				sla.Breaches = append(sla.Breaches, d.breach)
+			}
+			continue
+		}
+		if now.After(d.dueAt) {
+			sla.Breaches = append(sla.Breaches, d.breach)
+		}
+		if next == nil {
+			next = d
+		}
+	}
+
+	if next != nil {
+		sla.DueAt = null.TimeFrom(next.dueAt)
+		sla.RemainingSeconds = null.Int64From(int64(next.dueAt.Sub(now) / time.Second))
+	}
+	switch {
+	case len(sla.Breaches) > 0:
+		sla.Status = internalModels.SLAStatusBreached
+	case next == nil:
+		sla.Status = internalModels.SLAStatusMet
+	case next.dueAt.Sub(now) < time.Duration(float64(next.dueAt.Sub(state.CreatedAt))*slaAtRiskShare):
+		sla.Status = internalModels.SLAStatusAtRisk
+	default:
+		sla.Status = internalModels.SLAStatusOnTrack
+	}
+	return sla
+}
+
+// Job type escalating the SLA breaches, each run queues the next one
+const JobTypeEscalateSLABreaches = "escalate_sla_breaches"
+
+// Delay between the runs of the escalation job
+const slaEscalationJobInterval = 5 * time.Minute
+
+type SLAController struct {
+	TaskRepository *repositories.TaskRepository
+}
+
+func NewSLAController(taskRepository *repositories.TaskRepository) *SLAController {
+	return &SLAController{TaskRepository: taskRepository}
+}
+
+// Escalates the breaches of the open tasks that were not escalated yet. Every breach notifies the owners
+// of the task category and the users allowed to manage every task, and the escalation user of the policy is notified and assigned to the task. Returns the
+// number of escalated breaches.
+func (c *SLAController) EscalateBreaches(now time.Time, ctx context.Context) (int, error) {
+	states, err := c.TaskRepository.GetOpenTaskSLAStates(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, state := range states {
+		sla := ComputeTaskSLA(state, now)
+		for _, breach := range sla.Breaches {
+			// A late start of a task that was started since cannot be acted on anymore
+			if breach == internalModels.SLABreachStart && state.StartedAt.Valid {
+				continue
+			}
+			message := fmt.Sprintf("Task %d breached its SLA: it was not started within %d hours", state.TaskID, state.Policy.StartWithinHours.Int)
+			if breach == internalModels.SLABreachComplete {
+				message = fmt.Sprintf("Task %d breached its SLA: it was not completed within %d business hours", state.TaskID, state.Policy.CompleteWithinBusinessHours.Int)
+			}
+			escalated, err := c.TaskRepository.EscalateSLABreach(state.TaskID, breach, message, internalModels.PermissionTaskManage, state.Policy.EscalateToUserID, ctx)
+			if err != nil {
+				log.Printf("Could not escalate the SLA breach of task %d: %v\n", state.TaskID, err)
+				continue
+			}
+			if escalated {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// Registers the recurring escalation job, which queues its next run before escalating so a failed
+// attempt does not stop the schedule.
+func (c *SLAController) RegisterJobs(registry *JobRegistry, jobController *JobController) {
+	registry.Register(JobTypeEscalateSLABreaches, func(ctx context.Context, job internalModels.Job, progress func(int)) (interface{}, error) {
+		if _, err := jobController.ScheduleJob(internalModels.NewJob{Type: JobTypeEscalateSLABreaches, Payload: struct{}{}, RunAt: time.Now().Add(slaEscalationJobInterval)}, ctx); err != nil {
+			return nil, err
+		}
+		count, err := c.EscalateBreaches(time.Now(), ctx)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			log.Printf("Escalated %d SLA breaches", count)
+		}
+		return count, nil
+	}, 0, 0)
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+// Placeholders look like {{name}}, spaces around the name are allowed
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+type TaskTemplateController struct {
+	TaskTemplateRepository *repositories.TaskTemplateRepository
+	TaskRepository         *repositories.TaskRepository
+	UserRepository         *repositories.UserRepository
+}
+
+func NewTaskTemplateController(taskTemplateRepository *repositories.TaskTemplateRepository, taskRepository *repositories.TaskRepository, userRepository *repositories.UserRepository) *TaskTemplateController {
+	return &TaskTemplateController{TaskTemplateRepository: taskTemplateRepository, TaskRepository: taskRepository, UserRepository: userRepository}
+}
+
+func (c *TaskTemplateController) GetAllTaskTemplates(ctx context.Context) ([]internalModels.TaskTemplate, error) {
+	templates, err := c.TaskTemplateRepository.GetAllTaskTemplates(ctx)
+	if err != nil {
+		return templates, err
+	}
+	return templates, nil
+}
+
+func (c *TaskTemplateController) GetTaskTemplateByID(templateID int, ctx context.Context) (internalModels.TaskTemplate, error) {
+	template, err := c.TaskTemplateRepository.GetTaskTemplateByID(templateID, ctx)
+	if err != nil {
+		return template, err
+	}
+	return template, nil
+}
+
+func (c *TaskTemplateController) AddTaskTemplate(template *internalModels.TaskTemplate, createdBy int, ctx context.Context) error {
+	if err := validateTaskTemplate(*template); err != nil {
+		return err
+	}
+	template.CreatedBy = null.IntFrom(createdBy)
+	err := c.TaskTemplateRepository.AddTaskTemplate(template, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *TaskTemplateController) UpdateTaskTemplate(templateID int, templateData internalModels.TaskTemplate, ctx context.Context) (internalModels.TaskTemplate, error) {
+	template, err := c.TaskTemplateRepository.GetTaskTemplateByID(templateID, ctx)
+	if err != nil {
+		return template, err
+	}
+	if err := validateTaskTemplate(templateData); err != nil {
+		return template, err
+	}
+
+	template.Name = templateData.Name
+	template.TaskName = templateData.TaskName
+	template.TaskDescription = templateData.TaskDescription
+	template.TaskCategoryID = templateData.TaskCategoryID
+	template.StartOffsetHours = templateData.StartOffsetHours
+	template.EndOffsetHours = templateData.EndOffsetHours
+	template.AssigneeIDs = templateData.AssigneeIDs
+	template.Subtasks = templateData.Subtasks
+
+	err = c.TaskTemplateRepository.UpdateTaskTemplate(&template, ctx)
+	if err != nil {
+		return template, err
+	}
+	return template, nil
+}
+
+func (c *TaskTemplateController) DeleteTaskTemplate(templateID int, ctx context.Context) error {
+	err := c.TaskTemplateRepository.DeleteTaskTemplate(templateID, ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Creates the tasks described by a template. Placeholders are replaced with the given variables and
+// {{anchor_date}}, dates are computed from the anchor date, and the subtasks are linked to the main task.
+// Like a task added through the API, every task is also assigned to its author, besides the assignees.
+// The tasks are created in a single transaction, so a failure leaves none of them behind.
+func (c *TaskTemplateController) InstantiateTaskTemplate(templateID int, instantiation internalModels.TaskTemplateInstantiation, authorID int, ctx context.Context) (internalModels.TaskTemplateInstance, error) {
+	instance := internalModels.TaskTemplateInstance{Subtasks: models.TaskSlice{}}
+	template, err := c.TaskTemplateRepository.GetTaskTemplateByID(templateID, ctx)
+	if err != nil {
+		return instance, err
+	}
+	if instantiation.AnchorDate.IsZero() {
+		return instance, errors.New("anchor_date is required")
+	}
+	taskCategoryID := instantiation.TaskCategoryID
+	if taskCategoryID == 0 {
+		if !template.TaskCategoryID.Valid {
+			return instance, errors.New("the template has no default category, task_category_id is required")
+		}
+		taskCategoryID = template.TaskCategoryID.Int
+	}
+
+	variables := map[string]string{"anchor_date": instantiation.AnchorDate.Format("2006-01-02")}
+	for key, value := range instantiation.Variables {
+		variables[key] = value
+	}
+
+	// Build every task before inserting anything, so that a missing variable does not leave half of the tasks behind
+	task, err := newTaskFromBlueprint(template.TaskName, template.TaskDescription, template.StartOffsetHours, template.EndOffsetHours, instantiation.AnchorDate, variables)
+	if err != nil {
+		return instance, err
+	}
+	task.AuthorID = authorID
+	task.TaskCategoryID = taskCategoryID
+	assigneeIDs := uniqueIDs(append([]int{authorID}, append(template.AssigneeIDs, instantiation.AssigneeIDs...)...))
+
+	subtasks := models.TaskSlice{}
+	subtaskAssigneeIDs := [][]int{}
+	for _, blueprint := range template.Subtasks {
+		subtask, err := newTaskFromBlueprint(blueprint.Name, blueprint.Description, blueprint.StartOffsetHours, blueprint.EndOffsetHours, instantiation.AnchorDate, variables)
+		if err != nil {
+			return instance, err
+		}
+		subtask.AuthorID = authorID
+		subtask.TaskCategoryID = taskCategoryID
+		subtasks = append(subtasks, subtask)
+
+		// Subtasks without their own assignees are given to the assignees of the main task
+		if len(blueprint.AssigneeIDs) == 0 {
+			subtaskAssigneeIDs = append(subtaskAssigneeIDs, assigneeIDs)
+		} else {
+			subtaskAssigneeIDs = append(subtaskAssigneeIDs, uniqueIDs(append([]int{authorID}, blueprint.AssigneeIDs...)))
+		}
+	}
+
+	// The author is added to the tasks as their creator, the other assignees must exist and have verified their email
+	explicitAssigneeIDs := []int{}
+	for _, userIDs := range append([][]int{assigneeIDs}, subtaskAssigneeIDs...) {
+		for _, userID := range userIDs {
+			if userID != authorID {
+				explicitAssigneeIDs = append(explicitAssigneeIDs, userID)
+			}
+		}
+	}
+	if err := c.UserRepository.CheckAssignable(uniqueIDs(explicitAssigneeIDs), ctx); err != nil {
+		return instance, err
+	}
+
+	if err := c.TaskRepository.AddTaskWithSubtasks(task, assigneeIDs, subtasks, subtaskAssigneeIDs, ctx); err != nil {
+		return instance, err
+	}
+	instance.Task = task
+	instance.Subtasks = subtasks
+	return instance, nil
+}
+
+// Builds a task from the name, description and date offsets of a template
+func newTaskFromBlueprint(name, description string, startOffsetHours, endOffsetHours int, anchorDate time.Time, variables map[string]string) (*models.Task, error) {
+	renderedName, err := RenderTemplateText(name, variables)
+	if err != nil {
+		return nil, err
+	}
+	renderedDescription, err := RenderTemplateText(description, variables)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Task{
+		Name:        renderedName,
+		Description: renderedDescription,
+		StartDate:   anchorDate.Add(time.Duration(startOffsetHours) * time.Hour),
+		EndDate:     anchorDate.Add(time.Duration(endOffsetHours) * time.Hour),
+		Status:      null.StringFrom(string(repositories.NotStarted)),
+	}, nil
+}
+
+// Replaces every {{placeholder}} in the text with its value, an error lists the placeholders without a value
+func RenderTemplateText(text string, variables map[string]string) (string, error) {
+	missing := []string{}
+	rendered := placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderRegex.FindStringSubmatch(placeholder)[1]
+		value, ok := variables[name]
+		if !ok {
+			missing = append(missing, name)
+			return placeholder
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		missing = uniqueStrings(missing)
+		return rendered, fmt.Errorf("missing values for placeholders: %s", strings.Join(missing, ", "))
+	}
+	return rendered, nil
+}
+
+func validateTaskTemplate(template internalModels.TaskTemplate) error {
+	if strings.TrimSpace(template.Name) == "" {
+		return errors.New("the template name is required")
+	}
+	if strings.TrimSpace(template.TaskName) == "" {
+		return errors.New("the task name is required")
+	}
+	if template.EndOffsetHours < template.StartOffsetHours {
+		return errors.New("the end offset must not be before the start offset")
+	}
+	for i, subtask := range template.Subtasks {
+		if strings.TrimSpace(subtask.Name) == "" {
+			return fmt.Errorf("the name of subtask %d is required", i+1)
+		}
+		if subtask.EndOffsetHours < subtask.StartOffsetHours {
+			return fmt.Errorf("the end offset of subtask %d must not be before its start offset", i+1)
+		}
+	}
+	return nil
+}
+
+// Removes duplicated IDs while keeping the original order
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// Removes duplicated strings and sorts them
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
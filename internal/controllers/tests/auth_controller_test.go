@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+)
+
+func TestCurrentPrincipal(t *testing.T) {
+	user := &models.User{ID: 1, Role: "user"}
+	principal := internalModels.Principal{User: user, Method: internalModels.AuthMethodAPIKey, APIKeyID: 3, Permissions: []internalModels.Permission{}}
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		expectedErr error
+	}{
+		{name: "without authentication", ctx: context.Background(), expectedErr: controllers.ErrUnauthenticated},
+		{name: "invalid credentials", ctx: controllers.WithAuthentication(context.Background(), internalModels.Principal{}, controllers.ErrInvalidAPIKey), expectedErr: controllers.ErrInvalidAPIKey},
+		{name: "authenticated", ctx: controllers.WithAuthentication(context.Background(), principal, nil)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := controllers.CurrentPrincipal(tc.ctx)
+			if err != tc.expectedErr {
+				t.Fatalf("expected error %v, got %v", tc.expectedErr, err)
+			}
+			if tc.expectedErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, principal) {
+				t.Errorf("expected principal %+v, got %+v", principal, got)
+			}
+			currentUser, err := controllers.CurrentUser(tc.ctx)
+			if err != nil || currentUser != user {
+				t.Errorf("expected user %v, got %v, %v", user, currentUser, err)
+			}
+		})
+	}
+}
+
+func TestHasPermission(t *testing.T) {
+	manager := controllers.WithAuthentication(context.Background(), internalModels.Principal{
+		User:        &models.User{ID: 1, Role: internalModels.RoleManager},
+		Permissions: []internalModels.Permission{internalModels.PermissionTaskDelete, internalModels.PermissionTaskLock},
+	}, nil)
+	user := controllers.WithAuthentication(context.Background(), internalModels.Principal{User: &models.User{ID: 2, Role: internalModels.RoleUser}}, nil)
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		permission  internalModels.Permission
+		expectedErr error
+	}{
+		{name: "held permission", ctx: manager, permission: internalModels.PermissionTaskLock},
+		{name: "missing permission", ctx: manager, permission: internalModels.PermissionRoleManage, expectedErr: controllers.ErrForbidden},
+		{name: "role without permissions", ctx: user, permission: internalModels.PermissionTaskDelete, expectedErr: controllers.ErrForbidden},
+		{name: "without authentication", ctx: context.Background(), permission: internalModels.PermissionTaskDelete, expectedErr: controllers.ErrUnauthenticated},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := controllers.HasPermission(tc.ctx, tc.permission); got != (tc.expectedErr == nil) {
+				t.Errorf("expected HasPermission to be %v", tc.expectedErr == nil)
+			}
+			if err := controllers.RequirePermission(tc.ctx, tc.permission); err != tc.expectedErr {
+				t.Errorf("expected error %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestBulkOperationPermission(t *testing.T) {
+	testCases := []struct {
+		operation internalModels.BulkTaskOperation
+		expected  internalModels.Permission
+	}{
+		{operation: internalModels.BulkTaskOperation{Type: internalModels.BulkDelete}, expected: internalModels.PermissionTaskDelete},
+		{operation: internalModels.BulkTaskOperation{Type: internalModels.BulkLock}, expected: internalModels.PermissionTaskLock},
+		{operation: internalModels.BulkTaskOperation{Type: internalModels.BulkUnlock}, expected: internalModels.PermissionTaskLock},
+		{operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: "Lock"}, expected: internalModels.PermissionTaskLock},
+		{operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: "Complete"}, expected: internalModels.PermissionTaskManage},
+		{operation: internalModels.BulkTaskOperation{Type: internalModels.BulkAddAssignee}, expected: internalModels.PermissionTaskManage},
+	}
+	for _, tc := range testCases {
+		if got := controllers.BulkOperationPermission(tc.operation); got != tc.expected {
+			t.Errorf("%s %s: expected %s, got %s", tc.operation.Type, tc.operation.Status, tc.expected, got)
+		}
+	}
+}
+
+func TestAuthenticateWithoutValidCredentials(t *testing.T) {
+	issuer := tokenIssuer(t, tokenConfig, tokenKey(t, controllers.TokenAlgorithmHS256, "k1", []byte("test-secret-of-at-least-32-characters")))
+	authController := controllers.NewAuthController(issuer, nil, nil, nil, nil)
+
+	r := httptest.NewRequest("GET", "/tasks", nil)
+	if _, err := authController.Authenticate(r, time.Now(), r.Context()); err != jwtauth.ErrNoTokenFound {
+		t.Errorf("expected %v, got %v", jwtauth.ErrNoTokenFound, err)
+	}
+
+	r = httptest.NewRequest("GET", "/tasks", nil)
+	r.Header.Set("Authorization", "Bearer not-a-token")
+	if _, err := authController.Authenticate(r, time.Now(), r.Context()); err == nil {
+		t.Error("expected an error for an invalid token")
+	}
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+)
+
+var tokenConfig = controllers.TokenConfig{
+	Algorithm: controllers.TokenAlgorithmHS256,
+	Issuer:    "task-management-app",
+	Audience:  "task-management-app",
+	TTL:       time.Hour,
+}
+
+func privateKeyPEM(t *testing.T, key interface{}) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func tokenKey(t *testing.T, algorithm, keyID string, material []byte) jwk.Key {
+	key, err := controllers.NewTokenKey(algorithm, keyID, material)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key
+}
+
+func tokenIssuer(t *testing.T, config controllers.TokenConfig, signingKey jwk.Key, previousKeys ...jwk.Key) *controllers.TokenIssuer {
+	issuer, err := controllers.NewTokenIssuer(config, signingKey, previousKeys...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return issuer
+}
+
+func TestNewTokenKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name          string
+		algorithm     string
+		material      []byte
+		expectedError string
+	}{
+		{
+			name:      "HS256 secret",
+			algorithm: controllers.TokenAlgorithmHS256,
+			material:  []byte(strings.Repeat("s", 32)),
+		},
+		{
+			name:          "Short HS256 secret",
+			algorithm:     controllers.TokenAlgorithmHS256,
+			material:      []byte("secret"),
+			expectedError: "the HS256 secret must contain at least 32 characters",
+		},
+		{
+			name:      "RS256 key",
+			algorithm: controllers.TokenAlgorithmRS256,
+			material:  privateKeyPEM(t, rsaKey),
+		},
+		{
+			name:      "EdDSA key",
+			algorithm: controllers.TokenAlgorithmEdDSA,
+			material:  privateKeyPEM(t, edKey),
+		},
+		{
+			name:          "Key of another algorithm",
+			algorithm:     controllers.TokenAlgorithmRS256,
+			material:      privateKeyPEM(t, edKey),
+			expectedError: "the key is not a RS256 key",
+		},
+		{
+			name:          "Unknown algorithm",
+			algorithm:     "none",
+			material:      []byte(strings.Repeat("s", 32)),
+			expectedError: "the token algorithm must be one of HS256, RS256 or EdDSA",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := controllers.NewTokenKey(tc.algorithm, "", tc.material)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Errorf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if key.KeyID() == "" || key.Algorithm().String() != tc.algorithm {
+				t.Errorf("expected a %s key with a key ID, got %q with %q", tc.algorithm, key.Algorithm(), key.KeyID())
+			}
+		})
+	}
+}
+
+func TestTokenIssuerVerify(t *testing.T) {
+	issuer := tokenIssuer(t, tokenConfig, tokenKey(t, controllers.TokenAlgorithmHS256, "current", []byte(strings.Repeat("a", 32))))
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	tokenString, err := issuer.Issue(&models.User{ID: 7, Email: "user@example.com", Password: "hash", Role: "manager"}, 12, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(tokenString, "user@example.com") {
+		t.Errorf("the token must not contain credentials")
+	}
+
+	token, err := issuer.Verify(tokenString, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	userID, err := controllers.TokenUserID(token)
+	if err != nil || userID != 7 {
+		t.Errorf("expected user 7, got %d (%v)", userID, err)
+	}
+	sessionID, err := controllers.TokenSessionID(token)
+	if err != nil || sessionID != 12 {
+		t.Errorf("expected session 12, got %d (%v)", sessionID, err)
+	}
+	if role, _ := token.Get("role"); role != "manager" {
+		t.Errorf("expected the manager role, got %v", role)
+	}
+	if token.JwtID() == "" || !token.IssuedAt().Equal(now) || !token.Expiration().Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected registered claims %+v", token)
+	}
+	if _, ok := token.Get("email"); ok {
+		t.Errorf("the token must not contain the email")
+	}
+
+	if _, err := issuer.Verify(tokenString, now.Add(2*time.Hour)); err == nil {
+		t.Errorf("expected an expired token to be rejected")
+	}
+
+	otherAudience := tokenConfig
+	otherAudience.Audience = "other-service"
+	other := tokenIssuer(t, otherAudience, tokenKey(t, controllers.TokenAlgorithmHS256, "current", []byte(strings.Repeat("a", 32))))
+	if _, err := other.Verify(tokenString, now); err == nil {
+		t.Errorf("expected a token of another audience to be rejected")
+	}
+
+	withoutSession, err := issuer.Sign(map[string]interface{}{jwt.SubjectKey: "7"}, tokenConfig.Audience, time.Minute, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := issuer.Verify(withoutSession, now); err != controllers.ErrTokenSession {
+		t.Errorf("expected a token without a session to be rejected, got %v", err)
+	}
+
+	confirmation, err := issuer.Sign(map[string]interface{}{jwt.SubjectKey: "7"}, "delete_task_category", time.Minute, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := issuer.Verify(confirmation, now); err == nil {
+		t.Errorf("expected a token signed for another purpose to be rejected as an access token")
+	}
+}
+
+func TestTokenIssuerKeyRotation(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	user := &models.User{ID: 3, Role: "user"}
+
+	rsaConfig := tokenConfig
+	rsaConfig.Algorithm = controllers.TokenAlgorithmRS256
+	oldKey := tokenKey(t, controllers.TokenAlgorithmRS256, "2026-01", privateKeyPEM(t, rsaKey))
+	oldIssuer := tokenIssuer(t, rsaConfig, oldKey)
+	oldToken, err := oldIssuer.Issue(user, 1, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	edConfig := tokenConfig
+	edConfig.Algorithm = controllers.TokenAlgorithmEdDSA
+	newIssuer := tokenIssuer(t, edConfig, tokenKey(t, controllers.TokenAlgorithmEdDSA, "2026-10", privateKeyPEM(t, edKey)), oldKey)
+	newToken, err := newIssuer.Issue(user, 2, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, tokenString := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := newIssuer.Verify(tokenString, now); err != nil {
+			t.Errorf("expected the %s token to be verified, got %v", name, err)
+		}
+	}
+	if _, err := oldIssuer.Verify(newToken, now); err == nil {
+		t.Errorf("expected a token of an unknown key to be rejected")
+	}
+
+	publicKeys := newIssuer.PublicKeys()
+	if publicKeys.Len() != 2 {
+		t.Fatalf("expected 2 public keys, got %d", publicKeys.Len())
+	}
+	for _, keyID := range []string{"2026-10", "2026-01"} {
+		key, ok := publicKeys.LookupKeyID(keyID)
+		if !ok {
+			t.Fatalf("expected the key %q to be published", keyID)
+		}
+		if _, ok := key.(jwk.RSAPrivateKey); ok {
+			t.Errorf("expected only public keys to be published")
+		}
+		if _, ok := key.(jwk.OKPPrivateKey); ok {
+			t.Errorf("expected only public keys to be published")
+		}
+	}
+}
+
+func TestTokenIssuerPublishesNoSecret(t *testing.T) {
+	issuer := tokenIssuer(t, tokenConfig, tokenKey(t, controllers.TokenAlgorithmHS256, "", []byte(strings.Repeat("a", 32))))
+	if issuer.PublicKeys().Len() != 0 {
+		t.Errorf("expected no public key for HS256")
+	}
+}
+
+func TestNewTokenIssuerDuplicateKeyID(t *testing.T) {
+	current := tokenKey(t, controllers.TokenAlgorithmHS256, "same", []byte(strings.Repeat("a", 32)))
+	previous := tokenKey(t, controllers.TokenAlgorithmHS256, "same", []byte(strings.Repeat("b", 32)))
+	if _, err := controllers.NewTokenIssuer(tokenConfig, current, previous); err == nil {
+		t.Errorf("expected an error for a key ID used twice")
+	}
+}
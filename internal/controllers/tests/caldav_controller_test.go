@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestApplyVTODO(t *testing.T) {
+	testCases := []struct {
+		name           string
+		data           string
+		task           models.Task
+		expectedUID    string
+		expectedTask   models.Task
+		expectedErrors bool
+	}{
+		{
+			name: "Creates a task from a VTODO",
+			data: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nUID:abc-123\r\nSUMMARY:Write the\r\n  release notes\r\nDESCRIPTION:First line\\nsecond\\, line\r\n" +
+				"DTSTART;TZID=Europe/Paris:20230420T150000\r\nDUE;VALUE=DATE:20230421\r\nSTATUS:IN-PROCESS\r\n" +
+				"BEGIN:VALARM\r\nDESCRIPTION:Reminder\r\nEND:VALARM\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			task:        models.Task{Status: null.StringFrom("Not Started")},
+			expectedUID: "abc-123",
+			expectedTask: models.Task{
+				Name:        "Write the release notes",
+				Description: "First line\nsecond, line",
+				StartDate:   time.Date(2023, 4, 20, 13, 0, 0, 0, time.UTC),
+				EndDate:     time.Date(2023, 4, 21, 0, 0, 0, 0, time.UTC),
+				Status:      null.StringFrom("In Progress"),
+			},
+		},
+		{
+			name: "Keeps the lock of a task",
+			data: "BEGIN:VTODO\nSUMMARY:Locked\nSTATUS:COMPLETED\nEND:VTODO\n",
+			task: models.Task{
+				Status:    null.StringFrom("Lock"),
+				StartDate: time.Date(2023, 4, 20, 13, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 4, 22, 13, 0, 0, 0, time.UTC),
+			},
+			expectedTask: models.Task{
+				Name:      "Locked",
+				Status:    null.StringFrom("Lock"),
+				StartDate: time.Date(2023, 4, 20, 13, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2023, 4, 22, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:           "Requires a summary",
+			data:           "BEGIN:VTODO\nDUE:20230421T130000Z\nEND:VTODO\n",
+			expectedErrors: true,
+		},
+		{
+			name:           "Rejects invalid dates",
+			data:           "BEGIN:VTODO\nSUMMARY:Bad date\nDUE:tomorrow\nEND:VTODO\n",
+			expectedErrors: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			todos, err := utils.ParseICalComponents(tc.data, "VTODO")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(todos) != 1 {
+				t.Fatalf("Unexpected number of VTODO: %d", len(todos))
+			}
+			task := tc.task
+			uid, err := controllers.ApplyVTODO(todos[0], &task)
+			if tc.expectedErrors {
+				if err == nil {
+					t.Errorf("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if uid != tc.expectedUID {
+				t.Errorf("Unexpected UID: got %q want %q", uid, tc.expectedUID)
+			}
+			if task.Name != tc.expectedTask.Name || task.Description != tc.expectedTask.Description || task.Status != tc.expectedTask.Status {
+				t.Errorf("Unexpected task: got %+v want %+v", task, tc.expectedTask)
+			}
+			if !task.StartDate.Equal(tc.expectedTask.StartDate) || !task.EndDate.Equal(tc.expectedTask.EndDate) {
+				t.Errorf("Unexpected dates: got %v - %v want %v - %v", task.StartDate, task.EndDate, tc.expectedTask.StartDate, tc.expectedTask.EndDate)
+			}
+		})
+	}
+}
+
+func TestParseCalDAVObjectName(t *testing.T) {
+	if id, err := controllers.ParseCalDAVObjectName("task-42"); err != nil || id != 42 {
+		t.Errorf("Unexpected result: %d, %v", id, err)
+	}
+	if _, err := controllers.ParseCalDAVObjectName("3F2504E0-4F89"); err == nil {
+		t.Errorf("Expected an error for a name chosen by a client")
+	}
+}
+
+func TestPutCalendarObjectCreates(t *testing.T) {
+	database := testDatabase(t)
+	userRepository := repositories.NewUserRepository(database)
+	mentionController := controllers.NewMentionController(repositories.NewMentionRepository(database), repositories.NewNotificationRepository(database), userRepository)
+	controller := controllers.NewCalDAVController(newTestTaskController(database), mentionController, repositories.NewTaskCategoryRepository(database), repositories.NewCalDAVRepository(database))
+	ctx := context.Background()
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	firstCategoryID := insertTestTaskCategory(t, database, "Home")
+	secondCategoryID := insertTestTaskCategory(t, database, "Work")
+	todo := func(summary string) string {
+		return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nBEGIN:VTODO\r\nSUMMARY:" + summary + "\r\nDTSTART:20230420T150000Z\r\nDUE:20230421T150000Z\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"
+	}
+	actor := internalModels.TaskActor{UserID: managerID, Manager: true}
+
+	// The same name can be used in two calendars, and a task without a UID gets the default one
+	first, created, err := controller.PutCalendarObject(firstCategoryID, "groceries", todo("Buy milk"), actor, ctx)
+	if err != nil || !created {
+		t.Fatalf("expected the object to be created, got %v and %v", created, err)
+	}
+	second, created, err := controller.PutCalendarObject(secondCategoryID, "groceries", todo("Order lunch"), actor, ctx)
+	if err != nil || !created {
+		t.Fatalf("expected the object to be created, got %v and %v", created, err)
+	}
+	if first.Task.ID == second.Task.ID || first.UID != fmt.Sprintf("task-%d@task-management-app", first.Task.ID) {
+		t.Errorf("expected two tasks with their default UIDs, got %+v and %+v", first, second)
+	}
+	for categoryID, expected := range map[int]string{firstCategoryID: "Buy milk", secondCategoryID: "Order lunch"} {
+		object, err := controller.GetCalendarObject(categoryID, "groceries", ctx)
+		if err != nil || object.Task.Name != expected {
+			t.Errorf("expected %q in calendar %d, got %+v and %v", expected, categoryID, object.Task, err)
+		}
+	}
+	if assignees := testAssigneeIDs(t, database, first.Task.ID); len(assignees) != 1 || assignees[0] != managerID {
+		t.Errorf("expected the author to be assigned, got %v", assignees)
+	}
+
+	// The author of this task does not exist, so the assignment fails and the task is rolled back with it
+	missing := internalModels.TaskActor{UserID: managerID + 1000, Manager: true}
+	if _, _, err := controller.PutCalendarObject(firstCategoryID, "orphan", todo("Orphan"), missing, ctx); err == nil {
+		t.Fatal("expected the assignment of a missing author to fail")
+	}
+	var count int
+	if err := database.Conn.QueryRow(`SELECT COUNT(*) FROM tasks WHERE name='Orphan';`).Scan(&count); err != nil || count != 0 {
+		t.Errorf("expected the task to be rolled back, got %d tasks and %v", count, err)
+	}
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestRenderTaskCalendar(t *testing.T) {
+	tasks := []models.Task{
+		{
+			ID:          2,
+			Name:        "Release, v2; final",
+			Description: "Line one\nline two",
+			StartDate:   time.Date(2023, 4, 20, 13, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2023, 4, 21, 13, 0, 0, 0, time.UTC),
+			Status:      null.StringFrom("Complete"),
+			CreatedAt:   time.Date(2023, 4, 19, 8, 0, 0, 0, time.UTC),
+			UpdatedAt:   time.Date(2023, 4, 21, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			ID:        1,
+			Name:      strings.Repeat("long ", 20),
+			StartDate: time.Date(2023, 4, 20, 13, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2023, 4, 22, 13, 0, 0, 0, time.UTC),
+			Status:    null.StringFrom("Lock"),
+			CreatedAt: time.Date(2023, 4, 19, 8, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2023, 4, 19, 8, 0, 0, 0, time.UTC),
+		},
+	}
+
+	t.Run("Renders tasks as VTODO", func(t *testing.T) {
+		body := controllers.RenderTaskCalendar("Assigned tasks", tasks, internalModels.CalendarComponentTodo)
+		expected := []string{
+			"BEGIN:VCALENDAR\r\n",
+			"UID:task-2@task-management-app\r\n",
+			"SUMMARY:Release\\, v2\\; final\r\n",
+			"DESCRIPTION:Line one\\nline two\r\n",
+			"DUE:20230421T130000Z\r\n",
+			"STATUS:COMPLETED\r\n",
+			"COMPLETED:20230421T093000Z\r\n",
+			"END:VCALENDAR\r\n",
+		}
+		for _, line := range expected {
+			if !strings.Contains(body, line) {
+				t.Errorf("Expected %q in calendar:\n%s", line, body)
+			}
+		}
+		// Tasks are ordered by ID so the feed is stable
+		if strings.Index(body, "UID:task-1@") > strings.Index(body, "UID:task-2@") {
+			t.Errorf("Expected tasks ordered by ID")
+		}
+		for _, line := range strings.Split(body, "\r\n") {
+			if len(line) > 75 {
+				t.Errorf("Line is longer than 75 octets: %q", line)
+			}
+		}
+		if strings.Count(body, "STATUS:") != 1 {
+			t.Errorf("Expected no status for a locked task")
+		}
+	})
+
+	t.Run("Renders tasks as VEVENT", func(t *testing.T) {
+		body := controllers.RenderTaskCalendar("Assigned tasks", tasks, internalModels.CalendarComponentEvent)
+		if strings.Count(body, "BEGIN:VEVENT\r\n") != 2 || !strings.Contains(body, "DTEND:20230422T130000Z\r\n") {
+			t.Errorf("Unexpected calendar:\n%s", body)
+		}
+		if strings.Contains(body, "STATUS:") || strings.Contains(body, "DUE:") {
+			t.Errorf("Unexpected VTODO properties in events:\n%s", body)
+		}
+	})
+}
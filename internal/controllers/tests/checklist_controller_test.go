@@ -0,0 +1,193 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+func newTestChecklistController(database *repositories.Database) *controllers.ChecklistController {
+	return controllers.NewChecklistController(repositories.NewChecklistRepository(database), repositories.NewTaskRepository(database))
+}
+
+// Adds checklist items with the given texts to a task and returns their IDs
+func addTestChecklistItems(t *testing.T, controller *controllers.ChecklistController, taskID int, texts ...string) []int {
+	t.Helper()
+	itemIDs := []int{}
+	for _, text := range texts {
+		item := internalModels.ChecklistItem{TaskID: taskID, Text: text}
+		if err := controller.AddChecklistItem(&item, context.Background()); err != nil {
+			t.Fatalf("could not add checklist item %s: %v", text, err)
+		}
+		itemIDs = append(itemIDs, item.ID)
+	}
+	return itemIDs
+}
+
+func TestChecklistProgressPercentage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		progress internalModels.ChecklistProgress
+		expected int
+	}{
+		{name: "No checklist", progress: internalModels.ChecklistProgress{}, expected: 0},
+		{name: "Nothing done", progress: internalModels.ChecklistProgress{Total: 4}, expected: 0},
+		{name: "Rounded down", progress: internalModels.ChecklistProgress{Total: 3, Done: 2}, expected: 66},
+		{name: "All done", progress: internalModels.ChecklistProgress{Total: 3, Done: 3}, expected: 100},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if percentage := tc.progress.Percentage(); percentage != tc.expected {
+				t.Errorf("expected %d%%, got %d%%", tc.expected, percentage)
+			}
+		})
+	}
+}
+
+func TestReorderChecklistItems(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestChecklistController(database)
+	ctx := context.Background()
+	authorID := insertTestUser(t, database, "author", internalModels.RoleManager)
+	categoryID := insertTestTaskCategory(t, database, "Backend")
+	taskID := insertTestTask(t, database, "Task 1", authorID, categoryID)
+	otherTaskID := insertTestTask(t, database, "Task 2", authorID, categoryID)
+	itemIDs := addTestChecklistItems(t, controller, taskID, "First", "Second", "Third")
+	otherItemIDs := addTestChecklistItems(t, controller, otherTaskID, "Other")
+
+	invalidOrders := map[string][]int{
+		"Missing item":         {itemIDs[0], itemIDs[1]},
+		"Duplicated item":      {itemIDs[0], itemIDs[1], itemIDs[1]},
+		"Item of another task": {itemIDs[0], itemIDs[1], otherItemIDs[0]},
+		"Extra item":           {itemIDs[0], itemIDs[1], itemIDs[2], otherItemIDs[0]},
+	}
+	for name, order := range invalidOrders {
+		t.Run(name, func(t *testing.T) {
+			if _, err := controller.ReorderChecklistItems(taskID, order, ctx); err == nil {
+				t.Errorf("expected the order %v to be refused", order)
+			}
+		})
+	}
+
+	// A refused order leaves the checklist as it was
+	items, err := controller.GetChecklistItems(taskID, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := checklistItemIDs(items); !reflect.DeepEqual(ids, itemIDs) {
+		t.Errorf("expected the order %v, got %v", itemIDs, ids)
+	}
+
+	order := []int{itemIDs[2], itemIDs[0], itemIDs[1]}
+	items, err = controller.ReorderChecklistItems(taskID, order, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids := checklistItemIDs(items); !reflect.DeepEqual(ids, order) {
+		t.Errorf("expected the order %v, got %v", order, ids)
+	}
+	for i, item := range items {
+		if item.Position != i+1 {
+			t.Errorf("expected item %d at position %d, got %d", item.ID, i+1, item.Position)
+		}
+	}
+}
+
+func checklistItemIDs(items []internalModels.ChecklistItem) []int {
+	ids := []int{}
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestChecklistBlocksCompletion(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestChecklistController(database)
+	taskController := newTestTaskController(database)
+	ctx := context.Background()
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	categoryID := insertTestTaskCategory(t, database, "Release")
+	taskID := insertTestTask(t, database, "Release 1.0", managerID, categoryID)
+	itemIDs := addTestChecklistItems(t, controller, taskID, "Changelog", "Tag")
+	actor := internalModels.TaskActor{UserID: managerID, Manager: true}
+
+	complete := func() error {
+		task, err := taskController.GetTaskByID(taskID, ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		taskData := *task
+		taskData.Status = null.StringFrom(string(repositories.Complete))
+		_, err = taskController.UpdateTask(taskID, taskData, actor, ctx)
+		return err
+	}
+	resetStatus := func() {
+		if _, err := database.Conn.Exec(`UPDATE tasks SET status='Not Started' WHERE id=$1;`, taskID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Without the requirement an open checklist does not matter
+	if err := complete(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resetStatus()
+
+	if _, err := database.Conn.Exec(`UPDATE task_categories SET require_checklist_completion=TRUE WHERE id=$1;`, categoryID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := controller.ToggleChecklistItem(taskID, itemIDs[0], ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := complete(); err != repositories.ErrChecklistIncomplete {
+		t.Errorf("expected %v while an item is open, got %v", repositories.ErrChecklistIncomplete, err)
+	}
+	task, err := taskController.GetTaskByID(taskID, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	details, err := taskController.GetTaskDetails(models.TaskSlice{task}, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if details[0].ChecklistCompletion != 50 {
+		t.Errorf("expected the checklist to be 50%% done, got %d%%", details[0].ChecklistCompletion)
+	}
+
+	if _, err := controller.ToggleChecklistItem(taskID, itemIDs[1], ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := complete(); err != nil {
+		t.Errorf("expected a task with a finished checklist to be completed, got %v", err)
+	}
+}
+
+func TestChecklistItemsOfTrashedTask(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestChecklistController(database)
+	ctx := context.Background()
+	authorID := insertTestUser(t, database, "author", internalModels.RoleManager)
+	categoryID := insertTestTaskCategory(t, database, "Backend")
+	taskID := insertTestTask(t, database, "Task 1", authorID, categoryID)
+	itemIDs := addTestChecklistItems(t, controller, taskID, "First")
+	trashTestRow(t, database, "tasks", taskID, time.Now())
+
+	if _, err := controller.ToggleChecklistItem(taskID, itemIDs[0], ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected %v, got %v", repositories.ErrNoMatch, err)
+	}
+	if err := controller.DeleteChecklistItem(taskID, itemIDs[0], ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected %v, got %v", repositories.ErrNoMatch, err)
+	}
+	var done bool
+	if err := database.Conn.QueryRow(`SELECT done FROM checklist_items WHERE id=$1;`, itemIDs[0]).Scan(&done); err != nil || done {
+		t.Errorf("expected the item to be kept as it was, got done %v and %v", done, err)
+	}
+}
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestReadCSVRows(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		mapping     map[string]string
+		expected    []utils.CSVRow
+		expectedErr bool
+	}{
+		{
+			name: "Reads fields by header ignoring case and order",
+			data: "\uFEFFStatus, Name ,extra\nDone,Write docs,x\n,Review\n",
+			expected: []utils.CSVRow{
+				{Number: 1, Values: map[string]string{"name": "Write docs", "status": "Done"}},
+				{Number: 2, Values: map[string]string{"name": "Review", "status": ""}},
+			},
+		},
+		{
+			name:    "Uses the mapping",
+			data:    "Title\nWrite docs\n",
+			mapping: map[string]string{"name": "title"},
+			expected: []utils.CSVRow{
+				{Number: 1, Values: map[string]string{"name": "Write docs"}},
+			},
+		},
+		{
+			name:        "Mapped column is missing",
+			data:        "name\nWrite docs\n",
+			mapping:     map[string]string{"name": "title"},
+			expectedErr: true,
+		},
+		{
+			name:        "Unknown field in the mapping",
+			data:        "name\nWrite docs\n",
+			mapping:     map[string]string{"owner": "name"},
+			expectedErr: true,
+		},
+		{
+			name:        "Empty file",
+			data:        "",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, err := utils.ReadCSVRows(strings.NewReader(tc.data), []string{"name", "status"}, tc.mapping)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("Expected an error, got rows %v", rows)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(rows, tc.expected) {
+				t.Errorf("Unexpected rows: got %v want %v", rows, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseTaskImportRow(t *testing.T) {
+	valid := map[string]string{
+		"name":             "Write docs",
+		"start_date":       "2026-10-01",
+		"end_date":         "2026-10-05T12:00:00Z",
+		"task_category_id": "3",
+	}
+	withValues := func(values map[string]string) utils.CSVRow {
+		row := utils.CSVRow{Number: 4, Values: map[string]string{}}
+		for field, value := range valid {
+			row.Values[field] = value
+		}
+		for field, value := range values {
+			row.Values[field] = value
+		}
+		return row
+	}
+
+	testCases := []struct {
+		name           string
+		row            utils.CSVRow
+		expectedErrors []internalModels.ImportRowError
+	}{
+		{
+			name:           "Valid row",
+			row:            withValues(nil),
+			expectedErrors: []internalModels.ImportRowError{},
+		},
+		{
+			name: "Missing name and invalid status",
+			row:  withValues(map[string]string{"name": "", "status": "Done"}),
+			expectedErrors: []internalModels.ImportRowError{
+				{Row: 4, Column: "name", Message: "the name is required"},
+				{Row: 4, Column: "status", Message: `invalid status "Done"`},
+			},
+		},
+		{
+			name: "End date before start date",
+			row:  withValues(map[string]string{"end_date": "2026-09-30"}),
+			expectedErrors: []internalModels.ImportRowError{
+				{Row: 4, Column: "end_date", Message: "the end date is before the start date"},
+			},
+		},
+		{
+			name: "Invalid IDs",
+			row:  withValues(map[string]string{"author_id": "bob", "task_category_id": "-1"}),
+			expectedErrors: []internalModels.ImportRowError{
+				{Row: 4, Column: "author_id", Message: `invalid user ID "bob"`},
+				{Row: 4, Column: "task_category_id", Message: `invalid task category ID "-1"`},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, rowErrors := controllers.ParseTaskImportRow(tc.row, 7)
+			if !reflect.DeepEqual(rowErrors, tc.expectedErrors) {
+				t.Errorf("Unexpected errors: got %v want %v", rowErrors, tc.expectedErrors)
+			}
+		})
+	}
+
+	task, _ := controllers.ParseTaskImportRow(withValues(nil), 7)
+	if task.AuthorID != 7 || task.Status.String != "Not Started" || task.TaskCategoryID != 3 {
+		t.Errorf("Unexpected defaults: got author %d, status %q, category %d", task.AuthorID, task.Status.String, task.TaskCategoryID)
+	}
+	if !task.StartDate.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected start date: %v", task.StartDate)
+	}
+}
+
+func TestMergeImportedTask(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	current := &models.Task{
+		ID:             5,
+		Name:           "Write docs",
+		StartDate:      start,
+		EndDate:        start.Add(24 * time.Hour),
+		Status:         null.StringFrom("Lock"),
+		AuthorID:       2,
+		TaskCategoryID: 3,
+		ExternalSource: null.StringFrom("tracker"),
+		ExternalID:     null.StringFrom("T-1"),
+		ArchivedAt:     null.TimeFrom(start),
+	}
+	imported := &models.Task{
+		Name:           "Write docs",
+		StartDate:      start.In(time.FixedZone("ICT", 7*60*60)),
+		EndDate:        start.Add(24 * time.Hour),
+		Status:         null.StringFrom("Not Started"),
+		AuthorID:       7,
+		TaskCategoryID: 3,
+	}
+
+	testCases := []struct {
+		name            string
+		values          map[string]string
+		unarchive       bool
+		expectedStatus  string
+		expectedAuthor  int
+		expectedChanged bool
+	}{
+		{
+			name:            "Keeps the author and status the row does not give",
+			values:          map[string]string{},
+			expectedStatus:  "Lock",
+			expectedAuthor:  2,
+			expectedChanged: false,
+		},
+		{
+			name:            "Replaces the author and status the row gives",
+			values:          map[string]string{"author_id": "7", "status": "Not Started"},
+			expectedStatus:  "Not Started",
+			expectedAuthor:  7,
+			expectedChanged: true,
+		},
+		{
+			name:            "Unarchives the task",
+			values:          map[string]string{},
+			unarchive:       true,
+			expectedStatus:  "Lock",
+			expectedAuthor:  2,
+			expectedChanged: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			merged := controllers.MergeImportedTask(current, imported, utils.CSVRow{Number: 1, Values: tc.values}, tc.unarchive)
+			if merged.ID != current.ID || merged.ExternalID != current.ExternalID {
+				t.Errorf("Unexpected identity: got ID %d and external ID %v", merged.ID, merged.ExternalID)
+			}
+			if merged.Status.String != tc.expectedStatus || merged.AuthorID != tc.expectedAuthor {
+				t.Errorf("Unexpected status and author: got %q and %d want %q and %d", merged.Status.String, merged.AuthorID, tc.expectedStatus, tc.expectedAuthor)
+			}
+			if changed := controllers.ImportedTaskChanged(current, merged); changed != tc.expectedChanged {
+				t.Errorf("Unexpected change: got %v want %v", changed, tc.expectedChanged)
+			}
+		})
+	}
+}
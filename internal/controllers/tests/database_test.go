@@ -0,0 +1,165 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Tables of the application that are not created by db/migrations
+const baseSchema = `
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password VARCHAR(255) NOT NULL,
+    role VARCHAR(50) NOT NULL DEFAULT 'user'
+);
+
+CREATE TABLE task_categories (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE tasks (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL,
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    status VARCHAR(50) NULL,
+    author_id INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    task_category_id INTEGER NOT NULL REFERENCES task_categories(id)
+);
+
+CREATE TABLE user_task_details (
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    task_id INTEGER NOT NULL REFERENCES tasks(id),
+    PRIMARY KEY (user_id, task_id)
+);
+`
+
+// Connects to the database of TEST_DATABASE_URL and recreates its public schema with the base tables and
+// every migration. The tests that need it are skipped when the variable is not set. The database is
+// wiped, so it must only be used by the tests.
+func testDatabase(t *testing.T) *repositories.Database {
+	t.Helper()
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	database, err := repositories.Initialize(dbURL)
+	if err != nil {
+		t.Fatalf("could not connect to the test database: %v", err)
+	}
+	t.Cleanup(func() { database.Conn.Close() })
+
+	ctx := context.Background()
+	if _, err := database.Conn.ExecContext(ctx, `DROP SCHEMA public CASCADE; CREATE SCHEMA public;`); err != nil {
+		t.Fatalf("could not reset the test database: %v", err)
+	}
+	if _, err := database.Conn.ExecContext(ctx, baseSchema); err != nil {
+		t.Fatalf("could not create the base schema: %v", err)
+	}
+	migrations, err := filepath.Glob(filepath.Join("..", "..", "..", "db", "migrations", "*.up.sql"))
+	if err != nil || len(migrations) == 0 {
+		t.Fatalf("could not find the migrations: %v", err)
+	}
+	sort.Strings(migrations)
+	for _, migration := range migrations {
+		query, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatalf("could not read %s: %v", migration, err)
+		}
+		if _, err := database.Conn.ExecContext(ctx, string(query)); err != nil {
+			t.Fatalf("could not apply %s: %v", migration, err)
+		}
+	}
+	return database
+}
+
+// Inserts a user with a verified email and returns its ID
+func insertTestUser(t *testing.T, database *repositories.Database, name, role string) int {
+	t.Helper()
+	var id int
+	err := database.Conn.QueryRow(`INSERT INTO users(name, email, password, role, email_verified_at)
+		VALUES($1, $1 || '@example.com', 'password', $2, NOW()) RETURNING id;`, name, role).Scan(&id)
+	if err != nil {
+		t.Fatalf("could not insert user %s: %v", name, err)
+	}
+	return id
+}
+
+// Inserts a task category and returns its ID
+func insertTestTaskCategory(t *testing.T, database *repositories.Database, name string) int {
+	t.Helper()
+	var id int
+	if err := database.Conn.QueryRow(`INSERT INTO task_categories(name) VALUES($1) RETURNING id;`, name).Scan(&id); err != nil {
+		t.Fatalf("could not insert task category %s: %v", name, err)
+	}
+	return id
+}
+
+// Inserts a task that has not started and returns its ID
+func insertTestTask(t *testing.T, database *repositories.Database, name string, authorID, taskCategoryID int) int {
+	t.Helper()
+	var id int
+	err := database.Conn.QueryRow(`INSERT INTO tasks(name, description, start_date, end_date, status, author_id, task_category_id)
+		VALUES($1, '', NOW(), NOW() + INTERVAL '1 day', 'Not Started', $2, $3) RETURNING id;`, name, authorID, taskCategoryID).Scan(&id)
+	if err != nil {
+		t.Fatalf("could not insert task %s: %v", name, err)
+	}
+	return id
+}
+
+// Moves a row of a table to the trash at the given time
+func trashTestRow(t *testing.T, database *repositories.Database, table string, id int, deletedAt time.Time) {
+	t.Helper()
+	if _, err := database.Conn.Exec(`UPDATE `+table+` SET deleted_at=$2 WHERE id=$1;`, id, deletedAt); err != nil {
+		t.Fatalf("could not move %s %d to the trash: %v", table, id, err)
+	}
+}
+
+// Checks whether a row of a table still exists
+func testRowExists(t *testing.T, database *repositories.Database, table string, id int) bool {
+	t.Helper()
+	var exists bool
+	if err := database.Conn.QueryRow(`SELECT EXISTS(SELECT 1 FROM `+table+` WHERE id=$1);`, id).Scan(&exists); err != nil {
+		t.Fatalf("could not check %s %d: %v", table, id, err)
+	}
+	return exists
+}
+
+// Assigns a user to a task
+func assignTestUser(t *testing.T, database *repositories.Database, userID, taskID int) {
+	t.Helper()
+	if _, err := database.Conn.Exec(`INSERT INTO user_task_details(user_id, task_id) VALUES($1, $2);`, userID, taskID); err != nil {
+		t.Fatalf("could not assign user %d to task %d: %v", userID, taskID, err)
+	}
+}
+
+// Gets the IDs of the users assigned to a task
+func testAssigneeIDs(t *testing.T, database *repositories.Database, taskID int) []int {
+	t.Helper()
+	rows, err := database.Conn.Query(`SELECT user_id FROM user_task_details WHERE task_id=$1 ORDER BY user_id;`, taskID)
+	if err != nil {
+		t.Fatalf("could not get the assignees of task %d: %v", taskID, err)
+	}
+	defer rows.Close()
+	userIDs := []int{}
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			t.Fatalf("could not get the assignees of task %d: %v", taskID, err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
+}
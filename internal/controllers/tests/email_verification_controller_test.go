@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+var verificationLinkRegex = regexp.MustCompile(`http://localhost:3000/verify-email\?token=\S+`)
+
+func TestVerificationLinkRejectedTokens(t *testing.T) {
+	issuer := tokenIssuer(t, tokenConfig, tokenKey(t, controllers.TokenAlgorithmHS256, "k1", []byte("test-secret-of-at-least-32-characters")))
+	var mails bytes.Buffer
+	controller := controllers.NewEmailVerificationController(nil, issuer, utils.NewLogMailer(&mails, "no-reply@example.com"),
+		internalModels.EmailVerificationRestrictAssignment, 72*time.Hour, "http://localhost:3000/verify-email")
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	user := &models.User{ID: 7, Name: "John Doe", Email: "john.doe@example.com", Role: internalModels.RoleUser}
+
+	if err := controller.SendVerification(user, now, context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	message := mails.String()
+	if !strings.Contains(message, "To: john.doe@example.com\r\n") {
+		t.Errorf("expected the mail to be sent to the user, got %q", message)
+	}
+	link, err := url.Parse(verificationLinkRegex.FindString(message))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("expected a verification link in %q", message)
+	}
+	accessToken, err := issuer.Issue(user, 1, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Every token is rejected before the user is looked up
+	testCases := []struct {
+		name  string
+		token string
+		now   time.Time
+	}{
+		{name: "expired link", token: link.Query().Get("token"), now: now.Add(73 * time.Hour)},
+		{name: "access token", token: accessToken, now: now},
+		{name: "not a token", token: "not-a-token", now: now},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := controller.VerifyEmail(tc.token, tc.now, context.Background()); err != controllers.ErrInvalidVerificationToken {
+				t.Errorf("expected %v, got %v", controllers.ErrInvalidVerificationToken, err)
+			}
+		})
+	}
+
+	// Unverified users can log in unless the policy blocks them
+	if err := controller.CheckLogin(user.ID, context.Background()); err != nil {
+		t.Errorf("expected the login to be allowed, got %v", err)
+	}
+}
+
+func TestChangedEmailIsPendingUntilVerified(t *testing.T) {
+	database := testDatabase(t)
+	ctx := context.Background()
+	issuer := tokenIssuer(t, tokenConfig, tokenKey(t, controllers.TokenAlgorithmHS256, "k1", []byte("test-secret-of-at-least-32-characters")))
+	userRepository := repositories.NewUserRepository(database)
+	userController := controllers.NewUserController(userRepository)
+	var mails bytes.Buffer
+	verificationController := controllers.NewEmailVerificationController(userRepository, issuer, utils.NewLogMailer(&mails, "no-reply@example.com"),
+		internalModels.EmailVerificationRestrictAssignment, 72*time.Hour, "http://localhost:3000/verify-email")
+
+	userID := insertTestUser(t, database, "john", "user")
+	otherID := insertTestUser(t, database, "jane", "user")
+	other, err := userRepository.GetUserByID(otherID, ctx)
+	if err != nil {
+		t.Fatalf("could not get user %d: %v", otherID, err)
+	}
+	if _, _, err := userController.UpdateUser(userID, models.User{Name: "John", Email: other.Email}, ctx); err != controllers.ErrEmailInUse {
+		t.Errorf("expected %v, got %v", controllers.ErrEmailInUse, err)
+	}
+
+	user, emailChanged, err := userController.UpdateUser(userID, models.User{Name: "John", Email: "john@example.com"}, ctx)
+	if err != nil {
+		t.Fatalf("could not update user %d: %v", userID, err)
+	}
+	oldEmail := user.Email
+	if !emailChanged || user.PendingEmail.String != "john@example.com" || oldEmail == "john@example.com" || !user.EmailVerifiedAt.Valid {
+		t.Errorf("expected the new email to be pending and the old one to stay verified, got %v", user)
+	}
+
+	// The link goes to the new email, which replaces the old one once it is opened
+	if err := verificationController.SendVerification(user, time.Now(), ctx); err != nil {
+		t.Fatalf("could not send the verification link: %v", err)
+	}
+	if !strings.Contains(mails.String(), "To: john@example.com\r\n") {
+		t.Errorf("expected the mail to be sent to the new email, got %q", mails.String())
+	}
+	link, err := url.Parse(verificationLinkRegex.FindString(mails.String()))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("expected a verification link in %q", mails.String())
+	}
+	if _, err := userRepository.GetUserByEmail(oldEmail, ctx); err != nil {
+		t.Fatalf("expected the old email to be kept until the link is opened, got %v", err)
+	}
+	if _, err := verificationController.VerifyEmail(link.Query().Get("token"), time.Now(), ctx); err != nil {
+		t.Fatalf("could not verify the new email: %v", err)
+	}
+	user, err = userRepository.GetUserByID(userID, ctx)
+	if err != nil {
+		t.Fatalf("could not get user %d: %v", userID, err)
+	}
+	if user.Email != "john@example.com" || user.PendingEmail.Valid || !user.EmailVerifiedAt.Valid {
+		t.Errorf("expected the new email to replace the old one and be verified, got %v", user)
+	}
+	if _, err := verificationController.VerifyEmail(link.Query().Get("token"), time.Now(), ctx); err != nil {
+		t.Errorf("expected the link to keep verifying the current email, got %v", err)
+	}
+
+	// Only the name changes when the email stays the same
+	user, emailChanged, err = userController.UpdateUser(userID, models.User{Name: "Johnny", Email: "john@example.com"}, ctx)
+	if err != nil {
+		t.Fatalf("could not update user %d: %v", userID, err)
+	}
+	if emailChanged || user.PendingEmail.Valid || user.Name != "Johnny" {
+		t.Errorf("expected only the name to change, got changed %v and user %v", emailChanged, user)
+	}
+
+	pendingUsers, err := verificationController.GetPendingUsers(ctx)
+	if err != nil {
+		t.Fatalf("could not list the pending users: %v", err)
+	}
+	if len(pendingUsers) != 0 {
+		t.Errorf("expected no unverified users, got %v", pendingUsers)
+	}
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+func TestJobBackoff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{name: "First failure", attempts: 1, expected: 10 * time.Second},
+		{name: "Doubles after each attempt", attempts: 2, expected: 20 * time.Second},
+		{name: "Fourth failure", attempts: 4, expected: 80 * time.Second},
+		{name: "Capped at an hour", attempts: 12, expected: time.Hour},
+		{name: "Many attempts do not overflow", attempts: 200, expected: time.Hour},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := controllers.JobBackoff(tc.attempts); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestJobRegistryTypes(t *testing.T) {
+	run := func(ctx context.Context, job internalModels.Job, progress func(int)) (interface{}, error) {
+		return nil, nil
+	}
+	registry := controllers.NewJobRegistry()
+	registry.Register("send_digest", run, 0, 0)
+	registry.Register("import_tasks", run, 3, time.Minute)
+	registry.Register("send_digest", run, 2, 0)
+
+	expected := []string{"import_tasks", "send_digest"}
+	if got := registry.Types(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+// Hands the emails it sends to a channel
+type channelMailer chan utils.Mail
+
+func (m channelMailer) Send(mail utils.Mail, ctx context.Context) error {
+	m <- mail
+	return nil
+}
+
+func TestMailQueue(t *testing.T) {
+	database := testDatabase(t)
+	ctx := context.Background()
+	mails := make(channelMailer, 10)
+	registry := controllers.NewJobRegistry()
+	queue := controllers.NewMailQueue(controllers.NewJobController(repositories.NewJobRepository(database), registry), mails)
+	queue.RegisterJobs(registry)
+
+	// The limit counts the emails of an address whatever the case it is written in
+	for i := 0; i < 5; i++ {
+		if err := queue.Send(utils.Mail{To: "John@example.com", Subject: "Hello", Body: "Hi"}, ctx); err != nil {
+			t.Fatalf("could not queue email %d: %v", i+1, err)
+		}
+	}
+	if err := queue.Send(utils.Mail{To: "john@example.com", Subject: "Hello", Body: "Hi"}, ctx); err != controllers.ErrJobRateLimited {
+		t.Errorf("expected the sixth email to be refused with %v, got %v", controllers.ErrJobRateLimited, err)
+	}
+	if err := queue.Send(utils.Mail{To: "jane@example.com", Subject: "Hello", Body: "Hi"}, ctx); err != nil {
+		t.Errorf("expected the emails of another address to be queued, got %v", err)
+	}
+
+	// The workers send the queued emails
+	pool := controllers.NewJobWorkerPool(repositories.NewJobRepository(database), registry, 2)
+	pool.Start()
+	defer pool.Stop(5 * time.Second)
+	for i := 0; i < 6; i++ {
+		select {
+		case mail := <-mails:
+			if mail.Subject != "Hello" || mail.Body != "Hi" {
+				t.Errorf("expected the queued email to be sent, got %+v", mail)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("expected 6 emails to be sent, got %d", i)
+		}
+	}
+}
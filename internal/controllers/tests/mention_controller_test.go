@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+)
+
+func TestParseMentions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{
+			name:     "Finds names and emails",
+			text:     "@alice please review with @bob@example.com",
+			expected: []string{"alice", "bob@example.com"},
+		},
+		{
+			name:     "Ignores plain email addresses",
+			text:     "Send the report to carol@example.com",
+			expected: []string{},
+		},
+		{
+			name:     "Drops the dot ending a sentence",
+			text:     "Ask @dave. Then ask @erin@example.org.",
+			expected: []string{"dave", "erin@example.org"},
+		},
+		{
+			name:     "Removes duplicates ignoring case",
+			text:     "@Frank, @frank and (@grace)",
+			expected: []string{"Frank", "grace"},
+		},
+		{
+			name:     "Text without mentions",
+			text:     "Nothing to see @ here",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handles := controllers.ParseMentions(tc.text)
+			if !reflect.DeepEqual(handles, tc.expected) {
+				t.Errorf("Unexpected mentions: got %v want %v", handles, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+func TestMentionNotifications(t *testing.T) {
+	database := testDatabase(t)
+	userRepository := repositories.NewUserRepository(database)
+	notificationRepository := repositories.NewNotificationRepository(database)
+	mentionController := controllers.NewMentionController(repositories.NewMentionRepository(database), notificationRepository, userRepository)
+	controller := controllers.NewNotificationController(notificationRepository)
+	ctx := context.Background()
+
+	authorID := insertTestUser(t, database, "author", internalModels.RoleManager)
+	aliceID := insertTestUser(t, database, "alice", internalModels.RoleUser)
+	categoryID := insertTestTaskCategory(t, database, "Backend")
+	firstTaskID := insertTestTask(t, database, "Task 1", authorID, categoryID)
+	secondTaskID := insertTestTask(t, database, "Task 2", authorID, categoryID)
+	taskRepository := repositories.NewTaskRepository(database)
+
+	// @alice is the part of the email of alice before the @, the author is not notified of their own mention
+	for _, taskID := range []int{firstTaskID, secondTaskID} {
+		task, err := taskRepository.GetTaskByID(taskID, ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		task.Description = "@alice and @author please review, @nobody"
+		report, err := mentionController.ProcessTaskMentions(task, authorID, ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(report.Mentioned, []string{"alice", "author"}) || !reflect.DeepEqual(report.Unknown, []string{"nobody"}) {
+			t.Errorf("unexpected mention report %+v", report)
+		}
+	}
+
+	notifications, err := controller.GetNotificationsOfUser(aliceID, true, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notifications) != 2 || notifications[0].TaskID.Int != secondTaskID || notifications[0].Type != internalModels.NotificationMention {
+		t.Fatalf("expected 2 mention notifications, newest first, got %+v", notifications)
+	}
+	if authorNotifications, err := controller.GetNotificationsOfUser(authorID, false, ctx); err != nil || len(authorNotifications) != 0 {
+		t.Errorf("expected the author not to be notified, got %+v and %v", authorNotifications, err)
+	}
+
+	// The notifications of another user are not found
+	if _, err := controller.MarkNotificationRead(authorID, notifications[0].ID, ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected %v, got %v", repositories.ErrNoMatch, err)
+	}
+	read, err := controller.MarkNotificationRead(aliceID, notifications[0].ID, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !read.ReadAt.Valid {
+		t.Errorf("expected the notification to be read, got %+v", read)
+	}
+	if unread, err := controller.GetNotificationsOfUser(aliceID, true, ctx); err != nil || len(unread) != 1 || unread[0].ID != notifications[1].ID {
+		t.Errorf("expected only notification %d to be unread, got %+v and %v", notifications[1].ID, unread, err)
+	}
+
+	marked, err := controller.MarkAllNotificationsRead(aliceID, ctx)
+	if err != nil || marked != 1 {
+		t.Errorf("expected 1 notification to be marked as read, got %d and %v", marked, err)
+	}
+	all, err := controller.GetNotificationsOfUser(aliceID, false, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, notification := range all {
+		if !notification.ReadAt.Valid {
+			t.Errorf("expected notification %d to be read", notification.ID)
+		}
+	}
+}
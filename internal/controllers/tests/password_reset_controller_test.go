@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+var resetLinkRegex = regexp.MustCompile(`http://localhost:3000/password/reset\?token=\S+`)
+
+// Requests a password reset link for an email and returns the token of the emailed link
+func requestTestPasswordReset(t *testing.T, controller *controllers.PasswordResetController, mails *bytes.Buffer, email string, now time.Time) string {
+	t.Helper()
+	mails.Reset()
+	if err := controller.RequestPasswordReset(email, now, context.Background()); err != nil {
+		t.Fatalf("could not request a password reset: %v", err)
+	}
+	link, err := url.Parse(resetLinkRegex.FindString(mails.String()))
+	if err != nil || link.Query().Get("token") == "" {
+		t.Fatalf("expected a password reset link in %q", mails.String())
+	}
+	return link.Query().Get("token")
+}
+
+func TestPasswordReset(t *testing.T) {
+	database := testDatabase(t)
+	ctx := context.Background()
+	issuer := tokenIssuer(t, tokenConfig, tokenKey(t, controllers.TokenAlgorithmHS256, "k1", []byte("test-secret-of-at-least-32-characters")))
+	sessionController := controllers.NewUserSessionController(repositories.NewUserSessionRepository(database), time.Hour)
+	userRepository := repositories.NewUserRepository(database)
+	var mails bytes.Buffer
+	controller := controllers.NewPasswordResetController(userRepository, repositories.NewPasswordResetRepository(database), sessionController,
+		utils.NewLogMailer(&mails, "no-reply@example.com"), time.Hour, "http://localhost:3000/password/reset")
+
+	userID := insertTestUser(t, database, "john", "user")
+	user, err := userRepository.GetUserByID(userID, ctx)
+	if err != nil {
+		t.Fatalf("could not get user %d: %v", userID, err)
+	}
+
+	t.Run("Unknown email", func(t *testing.T) {
+		mails.Reset()
+		if err := controller.RequestPasswordReset("nobody@example.com", time.Now(), ctx); err != nil {
+			t.Errorf("expected no error for an unknown email, got %v", err)
+		}
+		if mails.Len() != 0 {
+			t.Errorf("expected no mail for an unknown email, got %q", mails.String())
+		}
+	})
+
+	t.Run("Expired link", func(t *testing.T) {
+		token := requestTestPasswordReset(t, controller, &mails, user.Email, time.Now().Add(-2*time.Hour))
+		if _, err := controller.ResetPassword(token, "new-password", ctx); err != controllers.ErrInvalidPasswordResetToken {
+			t.Errorf("expected %v, got %v", controllers.ErrInvalidPasswordResetToken, err)
+		}
+	})
+
+	t.Run("Single use, other links invalidated and sessions revoked", func(t *testing.T) {
+		tokens, err := sessionController.StartSession(user, internalModels.UserSession{}, issuer, time.Now(), ctx)
+		if err != nil {
+			t.Fatalf("could not start a session: %v", err)
+		}
+		if err := sessionController.CheckSession(tokens.SessionID, userID, time.Now(), ctx); err != nil {
+			t.Fatalf("expected the session to be active before the reset, got %v", err)
+		}
+		firstToken := requestTestPasswordReset(t, controller, &mails, user.Email, time.Now())
+		secondToken := requestTestPasswordReset(t, controller, &mails, user.Email, time.Now())
+
+		resetUserID, err := controller.ResetPassword(secondToken, "new-password", ctx)
+		if err != nil {
+			t.Fatalf("could not reset the password: %v", err)
+		}
+		if resetUserID != userID {
+			t.Errorf("expected the password of user %d to be reset, got %d", userID, resetUserID)
+		}
+		if _, err := controller.ResetPassword(secondToken, "other-password", ctx); err != controllers.ErrInvalidPasswordResetToken {
+			t.Errorf("expected a used link to be refused, got %v", err)
+		}
+		if _, err := controller.ResetPassword(firstToken, "other-password", ctx); err != controllers.ErrInvalidPasswordResetToken {
+			t.Errorf("expected the other links of the user to be refused, got %v", err)
+		}
+		// The cached state of the session is not used once the sessions are revoked
+		if err := sessionController.CheckSession(tokens.SessionID, userID, time.Now(), ctx); err != controllers.ErrSessionRevoked {
+			t.Errorf("expected the session to be revoked, got %v", err)
+		}
+	})
+}
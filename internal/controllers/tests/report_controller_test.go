@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+func reportDate(value string) time.Time {
+	date, _ := time.Parse("2006-01-02", value)
+	return date
+}
+
+func TestParseReportRange(t *testing.T) {
+	now := time.Date(2023, 5, 10, 15, 30, 0, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		from          string
+		to            string
+		expectedFrom  string
+		expectedTo    string
+		expectedError string
+	}{
+		{name: "Defaults to the last days", expectedFrom: "2023-04-11", expectedTo: "2023-05-10"},
+		{name: "Default days before to", to: "2023-03-31", expectedFrom: "2023-03-02", expectedTo: "2023-03-31"},
+		{name: "Given range", from: "2023-01-01", to: "2023-01-31", expectedFrom: "2023-01-01", expectedTo: "2023-01-31"},
+		{name: "Single day", from: "2023-01-01", to: "2023-01-01", expectedFrom: "2023-01-01", expectedTo: "2023-01-01"},
+		{name: "Invalid from", from: "01/01/2023", expectedError: "from must be a date formatted as YYYY-MM-DD"},
+		{name: "Invalid to", to: "yesterday", expectedError: "to must be a date formatted as YYYY-MM-DD"},
+		{name: "From after to", from: "2023-02-01", to: "2023-01-01", expectedError: "from must not be after to"},
+		{name: "Range too long", from: "2021-01-01", to: "2023-01-01", expectedError: "a report cannot cover more than 366 days"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reportRange, err := controllers.ParseReportRange(tc.from, tc.to, 3, 30, now)
+			if tc.expectedError != "" {
+				if err == nil || err.Error() != tc.expectedError {
+					t.Fatalf("expected error %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := reportRange.From.Format("2006-01-02"); got != tc.expectedFrom {
+				t.Errorf("expected from %s, got %s", tc.expectedFrom, got)
+			}
+			if got := reportRange.To.Format("2006-01-02"); got != tc.expectedTo {
+				t.Errorf("expected to %s, got %s", tc.expectedTo, got)
+			}
+			if reportRange.TaskCategoryID != 3 {
+				t.Errorf("expected task category 3, got %d", reportRange.TaskCategoryID)
+			}
+		})
+	}
+}
+
+func TestCumulativeFlowAndBurndown(t *testing.T) {
+	reportRange := internalModels.ReportRange{From: reportDate("2023-05-01"), To: reportDate("2023-05-03")}
+	counts := []internalModels.DailyStatusCount{
+		{Date: reportDate("2023-05-01"), Status: "Not Started", Count: 3},
+		{Date: reportDate("2023-05-03"), Status: "Not Started", Count: 1},
+		{Date: reportDate("2023-05-03"), Status: "In Progress", Count: 1},
+		{Date: reportDate("2023-05-03"), Status: "Complete", Count: 2},
+	}
+
+	expectedFlow := []internalModels.CumulativeFlowPoint{
+		{Date: "2023-05-01", Statuses: map[string]int{"Not Started": 3, "In Progress": 0, "Lock": 0, "Complete": 0}},
+		{Date: "2023-05-02", Statuses: map[string]int{"Not Started": 0, "In Progress": 0, "Lock": 0, "Complete": 0}},
+		{Date: "2023-05-03", Statuses: map[string]int{"Not Started": 1, "In Progress": 1, "Lock": 0, "Complete": 2}},
+	}
+	if got := controllers.CumulativeFlowFromCounts(reportRange, counts); !reflect.DeepEqual(got, expectedFlow) {
+		t.Errorf("expected cumulative flow %v, got %v", expectedFlow, got)
+	}
+
+	expectedBurndown := []internalModels.BurndownPoint{
+		{Date: "2023-05-01", Total: 3, Open: 3},
+		{Date: "2023-05-02", Total: 0, Open: 0},
+		{Date: "2023-05-03", Total: 4, Open: 2},
+	}
+	if got := controllers.BurndownFromCounts(reportRange, counts); !reflect.DeepEqual(got, expectedBurndown) {
+		t.Errorf("expected burndown %v, got %v", expectedBurndown, got)
+	}
+}
+
+func TestThroughputFromCompletions(t *testing.T) {
+	// 2023-05-03 is a Wednesday, its week starts on Monday 2023-05-01
+	reportRange := internalModels.ReportRange{From: reportDate("2023-05-03"), To: reportDate("2023-05-21")}
+	completions := map[time.Time]int{
+		reportDate("2023-05-01"): 4,
+		reportDate("2023-05-15"): 2,
+	}
+	expected := []internalModels.ThroughputPoint{
+		{WeekStart: "2023-05-01", Completed: 4},
+		{WeekStart: "2023-05-08", Completed: 0},
+		{WeekStart: "2023-05-15", Completed: 2},
+	}
+	if got := controllers.ThroughputFromCompletions(reportRange, completions); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+func TestCheckRoleAssignment(t *testing.T) {
+	database := testDatabase(t)
+	roleController := controllers.NewRoleController(repositories.NewRoleRepository(database))
+	userRepository := repositories.NewUserRepository(database)
+	ctx := context.Background()
+
+	_, err := roleController.AddRole(internalModels.Role{Name: "assigner", Permissions: []internalModels.Permission{
+		internalModels.PermissionUserRoleAssign,
+		internalModels.PermissionTaskManage,
+	}}, ctx)
+	if err != nil {
+		t.Fatalf("could not add the assigner role: %v", err)
+	}
+	_, err = roleController.AddRole(internalModels.Role{Name: "editor", Permissions: []internalModels.Permission{internalModels.PermissionTaskManage}}, ctx)
+	if err != nil {
+		t.Fatalf("could not add the editor role: %v", err)
+	}
+
+	assignerID := insertTestUser(t, database, "assigner", "assigner")
+	userID := insertTestUser(t, database, "john", internalModels.RoleUser)
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	assigner, err := userRepository.GetUserByID(assignerID, ctx)
+	if err != nil {
+		t.Fatalf("could not get user %d: %v", assignerID, err)
+	}
+	principal := internalModels.Principal{User: assigner, Permissions: []internalModels.Permission{
+		internalModels.PermissionUserRoleAssign,
+		internalModels.PermissionTaskManage,
+	}}
+
+	testCases := []struct {
+		name    string
+		role    string
+		userID  int
+		wantErr error
+	}{
+		{name: "Role within the permissions of the assigner", role: "editor", userID: userID},
+		{name: "Role with permissions the assigner does not hold", role: internalModels.RoleManager, userID: userID, wantErr: controllers.ErrRoleAssignmentForbidden},
+		{name: "User whose role has permissions the assigner does not hold", role: internalModels.RoleUser, userID: managerID, wantErr: controllers.ErrRoleAssignmentForbidden},
+		{name: "Own role", role: internalModels.RoleUser, userID: assignerID, wantErr: controllers.ErrRoleAssignmentForbidden},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := userRepository.GetUserByID(tc.userID, ctx)
+			if err != nil {
+				t.Fatalf("could not get user %d: %v", tc.userID, err)
+			}
+			if err := roleController.CheckRoleAssignment(tc.role, user, principal, ctx); err != tc.wantErr {
+				t.Errorf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("Unknown role", func(t *testing.T) {
+		user, err := userRepository.GetUserByID(userID, ctx)
+		if err != nil {
+			t.Fatalf("could not get user %d: %v", userID, err)
+		}
+		if err := roleController.CheckRoleAssignment("nobody", user, principal, ctx); err == nil {
+			t.Errorf("expected an unknown role to be refused")
+		}
+	})
+}
+
+func TestGetUsersManager(t *testing.T) {
+	database := testDatabase(t)
+	userController := controllers.NewUserController(repositories.NewUserRepository(database))
+	ctx := context.Background()
+
+	if _, err := database.Conn.Exec(`INSERT INTO roles(name) VALUES('lead'); INSERT INTO role_permissions(role, permission) VALUES('lead', 'task.manage');`); err != nil {
+		t.Fatalf("could not add the lead role: %v", err)
+	}
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	leadID := insertTestUser(t, database, "lead", "lead")
+	insertTestUser(t, database, "john", internalModels.RoleUser)
+
+	users, err := userController.GetUsersManager(ctx)
+	if err != nil {
+		t.Fatalf("could not list the managers: %v", err)
+	}
+	got := map[int]bool{}
+	for _, user := range users {
+		got[user.ID] = true
+	}
+	if len(got) != 2 || !got[managerID] || !got[leadID] {
+		t.Errorf("expected users %d and %d to be listed as managers, got %v", managerID, leadID, got)
+	}
+}
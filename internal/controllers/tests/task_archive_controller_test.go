@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Completes a task at the given time, as the last transition of its status
+func completeTestTask(t *testing.T, database *repositories.Database, taskID int, completedAt time.Time) {
+	t.Helper()
+	if _, err := database.Conn.Exec(`UPDATE tasks SET status='Complete' WHERE id=$1;`, taskID); err != nil {
+		t.Fatalf("could not complete task %d: %v", taskID, err)
+	}
+	_, err := database.Conn.Exec(`INSERT INTO task_status_transitions(task_id, from_status, to_status, changed_at)
+		VALUES($1, 'Not Started', 'Complete', $2);`, taskID, completedAt)
+	if err != nil {
+		t.Fatalf("could not record the completion of task %d: %v", taskID, err)
+	}
+}
+
+func TestArchivedTasksList(t *testing.T) {
+	database := testDatabase(t)
+	taskController := newTestTaskController(database)
+	ctx := context.Background()
+
+	authorID := insertTestUser(t, database, "author", "manager")
+	categoryID := insertTestTaskCategory(t, database, "Category")
+	activeID := insertTestTask(t, database, "Active", authorID, categoryID)
+	archivedID := insertTestTask(t, database, "Archived", authorID, categoryID)
+	if err := taskController.ArchiveTask(archivedID, ctx); err != nil {
+		t.Fatalf("could not archive task %d: %v", archivedID, err)
+	}
+
+	// The task list sets archived=false unless include_archived=true or archived is given
+	testCases := []struct {
+		name     string
+		filters  map[string]interface{}
+		expected []int
+	}{
+		{name: "Archived tasks are left out by default", filters: map[string]interface{}{"archived": false}, expected: []int{activeID}},
+		{name: "include_archived=true lists every task", filters: map[string]interface{}{"include_archived": true}, expected: []int{activeID, archivedID}},
+		{name: "archived=true lists only the archived tasks", filters: map[string]interface{}{"archived": true}, expected: []int{archivedID}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.filters["page"] = 1
+			tc.filters["size"] = 10
+			tc.filters["field"] = "id"
+			tc.filters["order"] = "asc"
+			tasks, err := taskController.GetAllTasks(ctx, tc.filters)
+			if err != nil {
+				t.Fatalf("could not list the tasks: %v", err)
+			}
+			got := []int{}
+			for _, task := range tasks {
+				got = append(got, task.ID)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected tasks %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestArchiveCompletedTasks(t *testing.T) {
+	database := testDatabase(t)
+	taskController := newTestTaskController(database)
+	ctx := context.Background()
+
+	authorID := insertTestUser(t, database, "author", "manager")
+	categoryID := insertTestTaskCategory(t, database, "Category")
+	now := time.Now()
+	oldID := insertTestTask(t, database, "Completed long ago", authorID, categoryID)
+	completeTestTask(t, database, oldID, now.Add(-40*24*time.Hour))
+	recentID := insertTestTask(t, database, "Completed recently", authorID, categoryID)
+	completeTestTask(t, database, recentID, now.Add(-2*24*time.Hour))
+	openID := insertTestTask(t, database, "Not completed", authorID, categoryID)
+	if _, err := database.Conn.Exec(`UPDATE tasks SET updated_at=$2 WHERE id=$1;`, openID, now.Add(-40*24*time.Hour)); err != nil {
+		t.Fatalf("could not update task %d: %v", openID, err)
+	}
+
+	count, err := taskController.ArchiveCompletedTasks(30*24*time.Hour, ctx)
+	if err != nil {
+		t.Fatalf("could not archive the completed tasks: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 archived task, got %d", count)
+	}
+
+	rows, err := database.Conn.Query(`SELECT id FROM tasks WHERE archived_at IS NOT NULL;`)
+	if err != nil {
+		t.Fatalf("could not list the archived tasks: %v", err)
+	}
+	defer rows.Close()
+	archived := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("could not read the archived tasks: %v", err)
+		}
+		archived = append(archived, id)
+	}
+	sort.Ints(archived)
+	if !reflect.DeepEqual(archived, []int{oldID}) {
+		t.Errorf("expected only task %d to be archived, got %v", oldID, archived)
+	}
+
+	// Tasks that are already archived are not counted again
+	count, err = taskController.ArchiveCompletedTasks(30*24*time.Hour, ctx)
+	if err != nil {
+		t.Fatalf("could not archive the completed tasks again: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no task to be archived again, got %d", count)
+	}
+}
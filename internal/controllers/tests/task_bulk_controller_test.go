@@ -0,0 +1,219 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+func newTestTaskController(database *repositories.Database) *controllers.TaskController {
+	return controllers.NewTaskController(repositories.NewTaskRepository(database), repositories.NewChecklistRepository(database))
+}
+
+// Gets the status of every task of a bulk report by task ID
+func bulkResultStatuses(report internalModels.BulkTaskReport) map[int]string {
+	statuses := make(map[int]string, len(report.Results))
+	for _, result := range report.Results {
+		statuses[result.TaskID] = result.Status
+	}
+	return statuses
+}
+
+func TestBulkUpdateTasksModes(t *testing.T) {
+	testCases := []struct {
+		name              string
+		mode              string
+		expectedCommitted bool
+		expectedSucceeded int
+		expectedStatuses  []string
+		expectedAssigned  []bool
+	}{
+		{
+			name:              "All or nothing",
+			mode:              internalModels.BulkModeAllOrNothing,
+			expectedStatuses:  []string{internalModels.BulkResultRolledBack, internalModels.BulkResultFailed, internalModels.BulkResultRolledBack},
+			expectedAssigned:  []bool{false, true, false},
+			expectedCommitted: false,
+			expectedSucceeded: 0,
+		},
+		{
+			name:              "Best effort",
+			mode:              internalModels.BulkModeBestEffort,
+			expectedStatuses:  []string{internalModels.BulkResultSuccess, internalModels.BulkResultFailed, internalModels.BulkResultSuccess},
+			expectedAssigned:  []bool{true, true, true},
+			expectedCommitted: true,
+			expectedSucceeded: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			database := testDatabase(t)
+			controller := newTestTaskController(database)
+			managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+			userID := insertTestUser(t, database, "john", internalModels.RoleUser)
+			categoryID := insertTestTaskCategory(t, database, "Backend")
+			taskIDs := []int{
+				insertTestTask(t, database, "Task 1", managerID, categoryID),
+				insertTestTask(t, database, "Task 2", managerID, categoryID),
+				insertTestTask(t, database, "Task 3", managerID, categoryID),
+			}
+			// The second task fails since the user is already assigned to it
+			assignTestUser(t, database, userID, taskIDs[1])
+
+			request := internalModels.BulkTaskRequest{
+				TaskIDs:   taskIDs,
+				Operation: internalModels.BulkTaskOperation{Type: internalModels.BulkAddAssignee, UserID: userID},
+				Mode:      tc.mode,
+			}
+			report, err := controller.BulkUpdateTasks(request, managerID, true, nil, context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Committed != tc.expectedCommitted || report.Succeeded != tc.expectedSucceeded || report.Failed != 1 {
+				t.Errorf("expected committed %v with %d succeeded and 1 failed, got %+v", tc.expectedCommitted, tc.expectedSucceeded, report)
+			}
+			statuses := bulkResultStatuses(report)
+			for i, taskID := range taskIDs {
+				if statuses[taskID] != tc.expectedStatuses[i] {
+					t.Errorf("expected task %d to be %s, got %s", taskID, tc.expectedStatuses[i], statuses[taskID])
+				}
+				assignees := testAssigneeIDs(t, database, taskID)
+				if assigned := reflect.DeepEqual(assignees, []int{userID}); assigned != tc.expectedAssigned[i] {
+					t.Errorf("expected the assignment of task %d to be %v, got the assignees %v", taskID, tc.expectedAssigned[i], assignees)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyBulkOperationSavepointRollback(t *testing.T) {
+	database := testDatabase(t)
+	taskRepository := repositories.NewTaskRepository(database)
+	ctx := context.Background()
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	userID := insertTestUser(t, database, "john", internalModels.RoleUser)
+	categoryID := insertTestTaskCategory(t, database, "Backend")
+	firstTaskID := insertTestTask(t, database, "Task 1", managerID, categoryID)
+	lastTaskID := insertTestTask(t, database, "Task 2", managerID, categoryID)
+	// The assignment of a task that does not exist fails on its foreign key, an error of the database
+	// that aborts the whole transaction unless it is rolled back to the savepoint of the task
+	missingTaskID := lastTaskID + 1000
+	taskIDs := []int{firstTaskID, missingTaskID, lastTaskID}
+	operation := internalModels.BulkTaskOperation{Type: internalModels.BulkAddAssignee, UserID: userID}
+
+	taskErrors, err := taskRepository.ApplyBulkOperation(operation, taskIDs, managerID, false, ctx)
+	if err == nil || taskErrors[missingTaskID] == nil || len(taskErrors) != 1 {
+		t.Fatalf("expected the missing task to fail the transaction, got %v and %v", taskErrors, err)
+	}
+	for _, taskID := range []int{firstTaskID, lastTaskID} {
+		if assignees := testAssigneeIDs(t, database, taskID); len(assignees) != 0 {
+			t.Errorf("expected task %d to be rolled back, got the assignees %v", taskID, assignees)
+		}
+	}
+
+	taskErrors, err = taskRepository.ApplyBulkOperation(operation, taskIDs, managerID, true, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if taskErrors[missingTaskID] == nil || len(taskErrors) != 1 {
+		t.Errorf("expected only the missing task to fail, got %v", taskErrors)
+	}
+	for _, taskID := range []int{firstTaskID, lastTaskID} {
+		if assignees := testAssigneeIDs(t, database, taskID); !reflect.DeepEqual(assignees, []int{userID}) {
+			t.Errorf("expected task %d to be committed, got the assignees %v", taskID, assignees)
+		}
+	}
+}
+
+func TestBulkUpdateTasksUnauthorized(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestTaskController(database)
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	userID := insertTestUser(t, database, "john", internalModels.RoleUser)
+	publicID := insertTestTaskCategory(t, database, "Public")
+	privateID := insertTestTaskCategory(t, database, "Private")
+	assignedTaskID := insertTestTask(t, database, "Assigned", managerID, publicID)
+	otherTaskID := insertTestTask(t, database, "Not assigned", managerID, publicID)
+	privateTaskID := insertTestTask(t, database, "Private", managerID, privateID)
+	assignTestUser(t, database, userID, assignedTaskID)
+
+	accesses := map[int]internalModels.TaskCategoryAccess{
+		publicID:  {TaskCategoryID: publicID, Visibility: internalModels.TaskCategoryVisibilityPublic},
+		privateID: {TaskCategoryID: privateID, Visibility: internalModels.TaskCategoryVisibilityPrivate},
+	}
+	taskIDs := []int{assignedTaskID, otherTaskID, privateTaskID}
+
+	testCases := []struct {
+		name              string
+		operation         internalModels.BulkTaskOperation
+		mode              string
+		expectedCommitted bool
+		expectedStatuses  []string
+		expectedErrors    []string
+	}{
+		{
+			name:             "Status all or nothing",
+			operation:        internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: string(repositories.InProgress)},
+			mode:             internalModels.BulkModeAllOrNothing,
+			expectedStatuses: []string{internalModels.BulkResultRolledBack, internalModels.BulkResultFailed, internalModels.BulkResultFailed},
+			expectedErrors:   []string{"", controllers.ErrTaskForbidden.Error(), repositories.ErrNoMatch.Error()},
+		},
+		{
+			name:              "Status best effort",
+			operation:         internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: string(repositories.InProgress)},
+			mode:              internalModels.BulkModeBestEffort,
+			expectedCommitted: true,
+			expectedStatuses:  []string{internalModels.BulkResultSuccess, internalModels.BulkResultFailed, internalModels.BulkResultFailed},
+			expectedErrors:    []string{"", controllers.ErrTaskForbidden.Error(), repositories.ErrNoMatch.Error()},
+		},
+		{
+			name:              "Delete",
+			operation:         internalModels.BulkTaskOperation{Type: internalModels.BulkDelete},
+			mode:              internalModels.BulkModeBestEffort,
+			expectedCommitted: true,
+			expectedStatuses:  []string{internalModels.BulkResultFailed, internalModels.BulkResultFailed, internalModels.BulkResultFailed},
+			expectedErrors:    []string{controllers.ErrTaskForbidden.Error(), controllers.ErrTaskForbidden.Error(), repositories.ErrNoMatch.Error()},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := database.Conn.Exec(`UPDATE tasks SET status='Not Started', deleted_at=NULL;`); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			request := internalModels.BulkTaskRequest{TaskIDs: taskIDs, Operation: tc.operation, Mode: tc.mode}
+			report, err := controller.BulkUpdateTasks(request, userID, false, accesses, context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.Committed != tc.expectedCommitted {
+				t.Errorf("expected committed %v, got %+v", tc.expectedCommitted, report)
+			}
+			for i, result := range report.Results {
+				if result.TaskID != taskIDs[i] || result.Status != tc.expectedStatuses[i] || !strings.HasPrefix(result.Error, tc.expectedErrors[i]) {
+					t.Errorf("expected task %d to be %s with the error %q, got %+v", taskIDs[i], tc.expectedStatuses[i], tc.expectedErrors[i], result)
+				}
+			}
+
+			// Only the tasks reported as successful were written
+			rows, err := database.Conn.Query(`SELECT id FROM tasks WHERE status <> 'Not Started' OR deleted_at IS NOT NULL;`)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var taskID int
+				if err := rows.Scan(&taskID); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if bulkResultStatuses(report)[taskID] != internalModels.BulkResultSuccess {
+					t.Errorf("expected task %d not to be changed", taskID)
+				}
+			}
+		})
+	}
+}
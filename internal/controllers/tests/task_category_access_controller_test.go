@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+func TestHasTaskCategoryPermission(t *testing.T) {
+	public := internalModels.TaskCategoryVisibilityPublic
+	private := internalModels.TaskCategoryVisibilityPrivate
+	testCases := []struct {
+		name      string
+		access    internalModels.TaskCategoryAccess
+		isManager bool
+		canView   bool
+		canManage bool
+	}{
+		{
+			name:    "Public category",
+			access:  internalModels.TaskCategoryAccess{Visibility: public},
+			canView: true,
+		},
+		{
+			name:   "Private category",
+			access: internalModels.TaskCategoryAccess{Visibility: private},
+		},
+		{
+			name:    "Member of a private category",
+			access:  internalModels.TaskCategoryAccess{Visibility: private, Role: internalModels.TaskCategoryRoleMember},
+			canView: true,
+		},
+		{
+			name:      "Owner of a private category",
+			access:    internalModels.TaskCategoryAccess{Visibility: private, Role: internalModels.TaskCategoryRoleOwner},
+			canView:   true,
+			canManage: true,
+		},
+		{
+			name:      "Owner of a public category",
+			access:    internalModels.TaskCategoryAccess{Visibility: public, Role: internalModels.TaskCategoryRoleOwner},
+			canView:   true,
+			canManage: true,
+		},
+		{
+			name:      "Manager",
+			access:    internalModels.TaskCategoryAccess{Visibility: private},
+			isManager: true,
+			canView:   true,
+			canManage: true,
+		},
+		{
+			name: "Unknown category",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := controllers.HasTaskCategoryPermission(tc.access, tc.isManager, internalModels.TaskCategoryView); got != tc.canView {
+				t.Errorf("expected view %v, got %v", tc.canView, got)
+			}
+			if got := controllers.HasTaskCategoryPermission(tc.access, tc.isManager, internalModels.TaskCategoryManage); got != tc.canManage {
+				t.Errorf("expected manage %v, got %v", tc.canManage, got)
+			}
+		})
+	}
+}
+
+func TestValidateTaskCategoryRole(t *testing.T) {
+	for _, role := range []string{internalModels.TaskCategoryRoleOwner, internalModels.TaskCategoryRoleMember} {
+		if err := controllers.ValidateTaskCategoryRole(role); err != nil {
+			t.Errorf("unexpected error for %q: %v", role, err)
+		}
+	}
+	for _, role := range []string{"", "manager", "Owner"} {
+		if err := controllers.ValidateTaskCategoryRole(role); err == nil {
+			t.Errorf("expected an error for %q", role)
+		}
+	}
+}
+
+func TestValidateTaskCategoryVisibility(t *testing.T) {
+	for _, visibility := range []string{internalModels.TaskCategoryVisibilityPublic, internalModels.TaskCategoryVisibilityPrivate} {
+		if err := controllers.ValidateTaskCategoryVisibility(visibility); err != nil {
+			t.Errorf("unexpected error for %q: %v", visibility, err)
+		}
+	}
+	for _, visibility := range []string{"", "hidden"} {
+		if err := controllers.ValidateTaskCategoryVisibility(visibility); err == nil {
+			t.Errorf("expected an error for %q", visibility)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestValidateTaskCategoryDeletePolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        internalModels.TaskCategoryDeletePolicy
+		expectedError string
+	}{
+		{
+			name: "No policy",
+		},
+		{
+			name:   "Reassigned",
+			policy: internalModels.TaskCategoryDeletePolicy{ReassignTo: null.IntFrom(2)},
+		},
+		{
+			name:   "Cascade",
+			policy: internalModels.TaskCategoryDeletePolicy{Cascade: true, ConfirmedTasksDigest: "d41d8cd98f00b204e9800998ecf8427e"},
+		},
+		{
+			name:          "Reassigned and cascade",
+			policy:        internalModels.TaskCategoryDeletePolicy{ReassignTo: null.IntFrom(2), Cascade: true},
+			expectedError: "the tasks cannot be both reassigned and deleted",
+		},
+		{
+			name:          "Reassigned to the deleted category",
+			policy:        internalModels.TaskCategoryDeletePolicy{ReassignTo: null.IntFrom(1)},
+			expectedError: "the tasks cannot be reassigned to the deleted task category",
+		},
+		{
+			name:          "Invalid category",
+			policy:        internalModels.TaskCategoryDeletePolicy{ReassignTo: null.IntFrom(0)},
+			expectedError: "invalid task category to reassign the tasks to",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := controllers.ValidateTaskCategoryDeletePolicy(1, tc.policy)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestDeleteTaskCategoryCascade(t *testing.T) {
+	database := testDatabase(t)
+	taskCategoryController := controllers.NewTaskCategoryController(repositories.NewTaskCategoryRepository(database))
+	ctx := context.Background()
+
+	managerID := insertTestUser(t, database, "manager", "manager")
+	categoryID := insertTestTaskCategory(t, database, "Category")
+	firstID := insertTestTask(t, database, "First", managerID, categoryID)
+	insertTestTask(t, database, "Second", managerID, categoryID)
+
+	// A cascade deletion is refused with the digest of the tasks to confirm
+	_, err := taskCategoryController.DeleteTaskCategory(categoryID, managerID, internalModels.TaskCategoryDeletePolicy{Cascade: true}, ctx)
+	var hasTasks *repositories.TaskCategoryHasTasksError
+	if !errors.As(err, &hasTasks) || hasTasks.Tasks != 2 || hasTasks.Digest == "" {
+		t.Fatalf("expected the 2 tasks to be confirmed first, got %v", err)
+	}
+	digest := hasTasks.Digest
+
+	// Replacing a task keeps the number of tasks but not their digest
+	trashTestRow(t, database, "tasks", firstID, time.Now())
+	thirdID := insertTestTask(t, database, "Third", managerID, categoryID)
+	_, err = taskCategoryController.DeleteTaskCategory(categoryID, managerID, internalModels.TaskCategoryDeletePolicy{Cascade: true, ConfirmedTasksDigest: digest}, ctx)
+	if !errors.As(err, &hasTasks) || hasTasks.Digest == digest {
+		t.Fatalf("expected the confirmation to be refused once the tasks changed, got %v", err)
+	}
+	if taskIsTrashed(t, database, thirdID) {
+		t.Errorf("expected task %d to be kept when the confirmation is refused", thirdID)
+	}
+
+	result, err := taskCategoryController.DeleteTaskCategory(categoryID, managerID, internalModels.TaskCategoryDeletePolicy{Cascade: true, ConfirmedTasksDigest: hasTasks.Digest}, ctx)
+	if err != nil {
+		t.Fatalf("could not delete the confirmed task category: %v", err)
+	}
+	if result.DeletedTasks != 2 {
+		t.Errorf("expected 2 tasks to be moved to the trash, got %d", result.DeletedTasks)
+	}
+	if !taskIsTrashed(t, database, thirdID) {
+		t.Errorf("expected task %d to be moved to the trash", thirdID)
+	}
+}
+
+// Checks whether a task is in the trash
+func taskIsTrashed(t *testing.T, database *repositories.Database, taskID int) bool {
+	t.Helper()
+	var trashed bool
+	if err := database.Conn.QueryRow(`SELECT deleted_at IS NOT NULL FROM tasks WHERE id=$1;`, taskID).Scan(&trashed); err != nil {
+		t.Fatalf("could not read task %d: %v", taskID, err)
+	}
+	return trashed
+}
+
+func TestMoveTaskCategoryConcurrently(t *testing.T) {
+	database := testDatabase(t)
+	taskCategoryController := controllers.NewTaskCategoryController(repositories.NewTaskCategoryRepository(database))
+	ctx := context.Background()
+
+	// Two trees, moving the root of each one under the leaf of the other one would create a cycle
+	firstRootID := insertTestTaskCategory(t, database, "First root")
+	firstLeafID := insertTestTaskCategory(t, database, "First leaf")
+	secondRootID := insertTestTaskCategory(t, database, "Second root")
+	secondLeafID := insertTestTaskCategory(t, database, "Second leaf")
+	_, err := database.Conn.Exec(`UPDATE task_categories SET parent_id=$2 WHERE id=$1; UPDATE task_categories SET parent_id=$4 WHERE id=$3;`,
+		firstLeafID, firstRootID, secondLeafID, secondRootID)
+	if err != nil {
+		t.Fatalf("could not nest the task categories: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, move := range [][2]int{{firstRootID, secondLeafID}, {secondRootID, firstLeafID}} {
+		wg.Add(1)
+		go func(i, taskCategoryID, parentID int) {
+			defer wg.Done()
+			_, errs[i] = taskCategoryController.MoveTaskCategory(taskCategoryID, null.IntFrom(parentID), ctx)
+		}(i, move[0], move[1])
+	}
+	wg.Wait()
+	if (errs[0] == nil) == (errs[1] == nil) {
+		t.Fatalf("expected exactly one of the moves to be refused, got %v and %v", errs[0], errs[1])
+	}
+
+	// Every category still reaches a root
+	var cycles int
+	query := `WITH RECURSIVE ancestors(id, parent_id, depth) AS (
+			SELECT id, parent_id, 0 FROM task_categories
+			UNION ALL
+			SELECT a.id, c.parent_id, a.depth + 1 FROM ancestors a INNER JOIN task_categories c ON c.id = a.parent_id WHERE a.depth < 10
+		) SELECT COUNT(*) FROM ancestors WHERE depth = 10;`
+	if err := database.Conn.QueryRow(query).Scan(&cycles); err != nil {
+		t.Fatalf("could not check the task category tree: %v", err)
+	}
+	if cycles != 0 {
+		t.Errorf("expected the task category tree to have no cycle")
+	}
+}
@@ -0,0 +1,215 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+func category(id int, name string, parentID int) *models.TaskCategory {
+	taskCategory := &models.TaskCategory{ID: id, Name: name}
+	if parentID != 0 {
+		taskCategory.ParentID = null.IntFrom(parentID)
+	}
+	return taskCategory
+}
+
+// A chain of categories 1 > 2 > ... > n
+func categoryChain(n int) models.TaskCategorySlice {
+	categories := models.TaskCategorySlice{}
+	for i := 1; i <= n; i++ {
+		categories = append(categories, category(i, "Level", i-1))
+	}
+	return categories
+}
+
+func TestBuildTaskCategoryTree(t *testing.T) {
+	categories := models.TaskCategorySlice{
+		category(1, "Work", 0),
+		category(2, "Backend", 1),
+		category(3, "API", 2),
+		category(4, "Frontend", 1),
+		category(5, "Home", 0),
+		// The parent of an orphan is not in the list, so it is a root
+		category(6, "Orphan", 99),
+		category(7, "Backend", 1),
+	}
+	roots := controllers.BuildTaskCategoryTree(categories)
+
+	if len(roots) != 3 || roots[0].Name != "Home" || roots[1].Name != "Orphan" || roots[2].Name != "Work" {
+		t.Fatalf("unexpected roots %+v", roots)
+	}
+	work := roots[2]
+	if len(work.Children) != 3 || work.Children[0].ID != 2 || work.Children[1].ID != 7 || work.Children[2].ID != 4 {
+		t.Fatalf("expected children sorted by name then ID, got %+v", work.Children)
+	}
+	api := work.Children[0].Children[0]
+	if api.Path != "Work / Backend / API" || api.Depth != 3 {
+		t.Errorf("expected path %q at depth 3, got %q at depth %d", "Work / Backend / API", api.Path, api.Depth)
+	}
+	if roots[1].Path != "Orphan" || roots[1].Depth != 1 {
+		t.Errorf("expected the orphan at the root, got %q at depth %d", roots[1].Path, roots[1].Depth)
+	}
+	if roots[0].Children == nil {
+		t.Errorf("expected an empty list of children for a leaf")
+	}
+}
+
+func TestValidateTaskCategoryParent(t *testing.T) {
+	categories := models.TaskCategorySlice{
+		category(1, "Work", 0),
+		category(2, "Backend", 1),
+		category(3, "API", 2),
+		category(4, "Home", 0),
+	}
+	testCases := []struct {
+		name          string
+		categories    models.TaskCategorySlice
+		categoryID    int
+		parentID      null.Int
+		expectedError string
+	}{
+		{
+			name:       "Moved to the root",
+			categories: categories,
+			categoryID: 3,
+		},
+		{
+			name:       "Moved under another branch",
+			categories: categories,
+			categoryID: 2,
+			parentID:   null.IntFrom(4),
+		},
+		{
+			name:       "New category",
+			categories: categories,
+			parentID:   null.IntFrom(3),
+		},
+		{
+			name:          "Parent does not exist",
+			categories:    categories,
+			categoryID:    2,
+			parentID:      null.IntFrom(9),
+			expectedError: "parent task category 9 does not exist",
+		},
+		{
+			name:          "Own parent",
+			categories:    categories,
+			categoryID:    2,
+			parentID:      null.IntFrom(2),
+			expectedError: "a task category cannot be its own parent",
+		},
+		{
+			name:          "Under a descendant",
+			categories:    categories,
+			categoryID:    1,
+			parentID:      null.IntFrom(3),
+			expectedError: "a task category cannot be moved under one of its descendants",
+		},
+		{
+			name:       "New category at the deepest level",
+			categories: categoryChain(4),
+			parentID:   null.IntFrom(4),
+		},
+		{
+			name:          "New category too deep",
+			categories:    categoryChain(5),
+			parentID:      null.IntFrom(5),
+			expectedError: "task categories cannot be nested more than 5 levels deep",
+		},
+		{
+			name:          "Subtree too deep",
+			categories:    append(categoryChain(3), category(10, "Other", 0), category(11, "Other", 10), category(12, "Other", 11)),
+			categoryID:    10,
+			parentID:      null.IntFrom(3),
+			expectedError: "task categories cannot be nested more than 5 levels deep",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := controllers.ValidateTaskCategoryParent(tc.categories, tc.categoryID, tc.parentID)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidateTaskCategoryMerge(t *testing.T) {
+	categories := models.TaskCategorySlice{
+		category(1, "Work", 0),
+		category(2, "Backend", 1),
+		category(3, "API", 2),
+		category(4, "Home", 0),
+	}
+	testCases := []struct {
+		name          string
+		categories    models.TaskCategorySlice
+		sourceID      int
+		targetID      int
+		expectedError string
+	}{
+		{
+			name:       "Into a sibling branch",
+			categories: categories,
+			sourceID:   2,
+			targetID:   4,
+		},
+		{
+			name:       "Into its parent",
+			categories: categories,
+			sourceID:   2,
+			targetID:   1,
+		},
+		{
+			name:          "Target does not exist",
+			categories:    categories,
+			sourceID:      2,
+			targetID:      9,
+			expectedError: "target task category 9 does not exist",
+		},
+		{
+			name:          "Into itself",
+			categories:    categories,
+			sourceID:      2,
+			targetID:      2,
+			expectedError: "a task category cannot be merged into itself",
+		},
+		{
+			name:          "Into a descendant",
+			categories:    categories,
+			sourceID:      1,
+			targetID:      3,
+			expectedError: "a task category cannot be merged into one of its descendants",
+		},
+		{
+			name:          "Children too deep",
+			categories:    append(categoryChain(5), category(10, "Other", 0), category(11, "Other", 10)),
+			sourceID:      10,
+			targetID:      5,
+			expectedError: "task categories cannot be nested more than 5 levels deep",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := controllers.ValidateTaskCategoryMerge(tc.categories, tc.sourceID, tc.targetID)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+)
+
+func TestDashboardWeekEnd(t *testing.T) {
+	testCases := []struct {
+		name     string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Monday",
+			now:      time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Wednesday",
+			now:      time.Date(2023, 5, 3, 18, 30, 0, 0, time.UTC),
+			expected: time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Sunday before midnight",
+			now:      time.Date(2023, 5, 7, 23, 59, 0, 0, time.UTC),
+			expected: time.Date(2023, 5, 8, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Week across months",
+			now:      time.Date(2023, 5, 30, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := controllers.DashboardWeekEnd(tc.now); !got.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+func exportedTasks() []internalModels.TaskExport {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	return []internalModels.TaskExport{
+		{
+			ID:               1,
+			Name:             "Write docs",
+			Description:      "Cover the import, then the export",
+			StartDate:        start,
+			EndDate:          start.Add(48 * time.Hour),
+			Status:           null.StringFrom("In Progress"),
+			AuthorID:         2,
+			TaskCategoryID:   3,
+			TaskCategoryName: "Docs",
+			AssigneeIDs:      []int{2, 5},
+			AssigneeEmails:   []string{"ann@example.com", "bob@example.com"},
+			CreatedAt:        start,
+			UpdatedAt:        start,
+		},
+		{
+			ID:               2,
+			Name:             "Review",
+			StartDate:        start,
+			EndDate:          start,
+			Status:           null.StringFrom("Not Started"),
+			AuthorID:         2,
+			TaskCategoryID:   3,
+			TaskCategoryName: "Docs",
+			AssigneeIDs:      []int{},
+			AssigneeEmails:   []string{},
+			ExternalSource:   null.StringFrom("tracker"),
+			ExternalID:       null.StringFrom("T-2"),
+			CreatedAt:        start,
+			UpdatedAt:        start,
+		},
+	}
+}
+
+func writeExport(t *testing.T, format string, tasks []internalModels.TaskExport) string {
+	buffer := &bytes.Buffer{}
+	writer, err := controllers.NewTaskExportWriter(format, buffer)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := writer.Begin(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, task := range tasks {
+		if err := writer.Write(task); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := writer.End(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return buffer.String()
+}
+
+func TestTaskExportWriter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		tasks    []internalModels.TaskExport
+		expected func(t *testing.T, output string)
+	}{
+		{
+			name:   "CSV has a header and one line per task",
+			format: internalModels.ExportFormatCSV,
+			tasks:  exportedTasks(),
+			expected: func(t *testing.T, output string) {
+				lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+				if len(lines) != 3 {
+					t.Fatalf("Unexpected number of lines: got %d want 3", len(lines))
+				}
+				if lines[0] != strings.Join(controllers.TaskExportColumns, ",") {
+					t.Errorf("Unexpected header: %s", lines[0])
+				}
+				expected := `1,Write docs,"Cover the import, then the export",2026-10-01T09:00:00Z,2026-10-03T09:00:00Z,In Progress,2,3,Docs,2;5,ann@example.com;bob@example.com,,,,2026-10-01T09:00:00Z,2026-10-01T09:00:00Z`
+				if lines[1] != expected {
+					t.Errorf("Unexpected row: got %s want %s", lines[1], expected)
+				}
+			},
+		},
+		{
+			name:   "JSON is an array",
+			format: internalModels.ExportFormatJSON,
+			tasks:  exportedTasks(),
+			expected: func(t *testing.T, output string) {
+				tasks := []internalModels.TaskExport{}
+				if err := json.Unmarshal([]byte(output), &tasks); err != nil {
+					t.Fatalf("Invalid JSON: %v", err)
+				}
+				if len(tasks) != 2 || tasks[1].ExternalID.String != "T-2" {
+					t.Errorf("Unexpected tasks: %v", tasks)
+				}
+			},
+		},
+		{
+			name:   "JSON without tasks is an empty array",
+			format: internalModels.ExportFormatJSON,
+			tasks:  nil,
+			expected: func(t *testing.T, output string) {
+				if output != "[]\n" {
+					t.Errorf("Unexpected output: %q", output)
+				}
+			},
+		},
+		{
+			name:   "NDJSON has one object per line",
+			format: internalModels.ExportFormatNDJSON,
+			tasks:  exportedTasks(),
+			expected: func(t *testing.T, output string) {
+				lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+				if len(lines) != 2 {
+					t.Fatalf("Unexpected number of lines: got %d want 2", len(lines))
+				}
+				for _, line := range lines {
+					task := internalModels.TaskExport{}
+					if err := json.Unmarshal([]byte(line), &task); err != nil {
+						t.Errorf("Invalid line %q: %v", line, err)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.expected(t, writeExport(t, tc.format, tc.tasks))
+		})
+	}
+
+	if _, err := controllers.NewTaskExportWriter("xml", &bytes.Buffer{}); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+func TestTaskExportImportRoundTrip(t *testing.T) {
+	output := writeExport(t, internalModels.ExportFormatCSV, exportedTasks())
+	rows, err := utils.ReadCSVRows(strings.NewReader(output), controllers.TaskUpsertFields, map[string]string{"external_id": "id"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, row := range rows {
+		task, rowErrors := controllers.ParseTaskImportRow(row, 9)
+		if len(rowErrors) > 0 {
+			t.Fatalf("Unexpected errors in row %d: %v", row.Number, rowErrors)
+		}
+		exported := exportedTasks()[i]
+		if task.Name != exported.Name || task.Description != exported.Description || !task.StartDate.Equal(exported.StartDate) ||
+			!task.EndDate.Equal(exported.EndDate) || task.Status != exported.Status || task.AuthorID != exported.AuthorID ||
+			task.TaskCategoryID != exported.TaskCategoryID {
+			t.Errorf("Task %d changed in the round trip: got %+v", exported.ID, task)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+func policyTask() *models.Task {
+	return &models.Task{
+		ID:             1,
+		Name:           "Write the report",
+		Description:    "First draft",
+		StartDate:      time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		EndDate:        time.Date(2026, 10, 20, 17, 0, 0, 0, time.UTC),
+		Status:         null.StringFrom("In Progress"),
+		AuthorID:       2,
+		TaskCategoryID: 3,
+	}
+}
+
+func TestAuthorizeTaskUpdate(t *testing.T) {
+	manager := internalModels.TaskActor{UserID: 1, Manager: true, Locker: true}
+	author := internalModels.TaskActor{UserID: 2, Author: true}
+	assignee := internalModels.TaskActor{UserID: 4, Assignee: true}
+	stranger := internalModels.TaskActor{UserID: 5}
+
+	testCases := []struct {
+		name      string
+		actor     internalModels.TaskActor
+		locked    bool
+		update    func(task *models.Task)
+		forbidden bool
+	}{
+		{name: "assignee changes the status", actor: assignee, update: func(task *models.Task) { task.Status = null.StringFrom("Complete") }},
+		{name: "author changes the description", actor: author, update: func(task *models.Task) { task.Description = "Second draft" }},
+		{name: "assignee changes the name", actor: assignee, update: func(task *models.Task) { task.Name = "Other" }, forbidden: true},
+		{name: "author changes the author", actor: author, update: func(task *models.Task) { task.AuthorID = 4 }, forbidden: true},
+		{name: "assignee changes the category", actor: assignee, update: func(task *models.Task) { task.TaskCategoryID = 6 }, forbidden: true},
+		{name: "author changes the end date", actor: author, update: func(task *models.Task) { task.EndDate = task.EndDate.Add(time.Hour) }, forbidden: true},
+		{name: "assignee sends the dates with another precision", actor: assignee, update: func(task *models.Task) { task.StartDate = task.StartDate.Add(time.Millisecond) }},
+		{name: "assignee locks the task", actor: assignee, update: func(task *models.Task) { task.Status = null.StringFrom("Lock") }, forbidden: true},
+		{name: "assignee changes a locked task", actor: assignee, locked: true, update: func(task *models.Task) { task.Description = "Second draft" }, forbidden: true},
+		{name: "stranger changes the status", actor: stranger, update: func(task *models.Task) { task.Status = null.StringFrom("Complete") }, forbidden: true},
+		{name: "stranger sends the task unchanged", actor: stranger, update: func(task *models.Task) {}, forbidden: true},
+		{name: "manager changes every field", actor: manager, update: func(task *models.Task) {
+			task.Name, task.AuthorID, task.TaskCategoryID = "Other", 4, 6
+			task.StartDate, task.EndDate = task.StartDate.Add(time.Hour), task.EndDate.Add(time.Hour)
+		}},
+		{name: "manager locks the task", actor: manager, update: func(task *models.Task) { task.Status = null.StringFrom("Lock") }},
+		{name: "manager unlocks the task", actor: manager, locked: true, update: func(task *models.Task) { task.Status = null.StringFrom("In Progress") }},
+		{name: "manager without the lock permission changes a locked task", actor: internalModels.TaskActor{UserID: 1, Manager: true}, locked: true,
+			update: func(task *models.Task) { task.Name = "Other" }, forbidden: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := policyTask()
+			if tc.locked {
+				task.Status = null.StringFrom("Lock")
+			}
+			taskData := *task
+			tc.update(&taskData)
+			err := controllers.AuthorizeTaskUpdate(tc.actor, task, taskData)
+			if tc.forbidden != (err != nil) {
+				t.Fatalf("expected forbidden to be %v, got %v", tc.forbidden, err)
+			}
+			if err != nil && !errors.Is(err, controllers.ErrTaskForbidden) {
+				t.Errorf("expected an error wrapping %v, got %v", controllers.ErrTaskForbidden, err)
+			}
+		})
+	}
+}
+
+func TestAuthorizeTaskAssignment(t *testing.T) {
+	if err := controllers.AuthorizeTaskAssignment(internalModels.TaskActor{UserID: 1, Manager: true}); err != nil {
+		t.Errorf("expected a manager to change the assignees, got %v", err)
+	}
+	for _, actor := range []internalModels.TaskActor{{UserID: 2, Author: true}, {UserID: 4, Assignee: true}, {UserID: 5}} {
+		if err := controllers.AuthorizeTaskAssignment(actor); !errors.Is(err, controllers.ErrTaskForbidden) {
+			t.Errorf("expected %+v not to change the assignees, got %v", actor, err)
+		}
+	}
+}
+
+func TestAuthorizeBulkOperation(t *testing.T) {
+	manager := internalModels.TaskActor{UserID: 1, Manager: true, Locker: true}
+	assignee := internalModels.TaskActor{UserID: 4, Assignee: true}
+	stranger := internalModels.TaskActor{UserID: 5}
+
+	testCases := []struct {
+		actor     internalModels.TaskActor
+		operation internalModels.BulkTaskOperation
+		forbidden bool
+	}{
+		{actor: assignee, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: "Complete"}},
+		{actor: stranger, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: "Complete"}, forbidden: true},
+		{actor: assignee, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetStatus, Status: "Lock"}, forbidden: true},
+		{actor: assignee, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetCategory, TaskCategoryID: 6}, forbidden: true},
+		{actor: manager, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkSetCategory, TaskCategoryID: 6}},
+		{actor: assignee, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkAddAssignee, UserID: 5}, forbidden: true},
+		{actor: manager, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkRemoveAssignee, UserID: 4}},
+		{actor: assignee, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkLock}, forbidden: true},
+		{actor: manager, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkUnlock}},
+		{actor: assignee, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkDelete}, forbidden: true},
+		{actor: manager, operation: internalModels.BulkTaskOperation{Type: internalModels.BulkDelete}},
+	}
+	for _, tc := range testCases {
+		err := controllers.AuthorizeBulkOperation(tc.actor, tc.operation, policyTask())
+		if tc.forbidden != (err != nil) {
+			t.Errorf("%s by %+v: expected forbidden to be %v, got %v", tc.operation.Type, tc.actor, tc.forbidden, err)
+		}
+	}
+}
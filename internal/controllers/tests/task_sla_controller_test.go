@@ -0,0 +1,257 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestAddBusinessHours(t *testing.T) {
+	testCases := []struct {
+		name     string
+		start    time.Time
+		hours    int
+		expected time.Time
+	}{
+		{
+			name:     "Within the same day",
+			start:    time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+			hours:    4,
+			expected: time.Date(2023, 5, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Ends at the end of the day",
+			start:    time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC),
+			hours:    8,
+			expected: time.Date(2023, 5, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Continues the next morning",
+			start:    time.Date(2023, 5, 1, 15, 30, 0, 0, time.UTC),
+			hours:    4,
+			expected: time.Date(2023, 5, 2, 11, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "Created before opening",
+			start:    time.Date(2023, 5, 1, 6, 0, 0, 0, time.UTC),
+			hours:    2,
+			expected: time.Date(2023, 5, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Skips the weekend",
+			start:    time.Date(2023, 5, 5, 16, 0, 0, 0, time.UTC),
+			hours:    3,
+			expected: time.Date(2023, 5, 8, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Created on a Saturday",
+			start:    time.Date(2023, 5, 6, 12, 0, 0, 0, time.UTC),
+			hours:    1,
+			expected: time.Date(2023, 5, 8, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Several days",
+			start:    time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC),
+			hours:    40,
+			expected: time.Date(2023, 5, 5, 17, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := controllers.AddBusinessHours(tc.start, tc.hours); !got.Equal(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestComputeTaskSLA(t *testing.T) {
+	createdAt := time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC)
+	policy := internalModels.SLAPolicy{StartWithinHours: null.IntFrom(4), CompleteWithinBusinessHours: null.IntFrom(16)}
+	startDue := time.Date(2023, 5, 1, 13, 0, 0, 0, time.UTC)
+	completeDue := time.Date(2023, 5, 2, 17, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		state    internalModels.TaskSLAState
+		now      time.Time
+		expected internalModels.TaskSLA
+	}{
+		{
+			name:     "No policy",
+			state:    internalModels.TaskSLAState{CreatedAt: createdAt},
+			now:      createdAt,
+			expected: internalModels.TaskSLA{Status: internalModels.SLAStatusNone, Breaches: []string{}},
+		},
+		{
+			name:  "Waiting to be started",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy},
+			now:   createdAt.Add(time.Hour),
+			expected: internalModels.TaskSLA{
+				Status:           internalModels.SLAStatusOnTrack,
+				DueAt:            null.TimeFrom(startDue),
+				RemainingSeconds: null.Int64From(3 * 3600),
+				Breaches:         []string{},
+			},
+		},
+		{
+			name:  "Close to the start deadline",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy},
+			now:   startDue.Add(-30 * time.Minute),
+			expected: internalModels.TaskSLA{
+				Status:           internalModels.SLAStatusAtRisk,
+				DueAt:            null.TimeFrom(startDue),
+				RemainingSeconds: null.Int64From(1800),
+				Breaches:         []string{},
+			},
+		},
+		{
+			name:  "Not started in time",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy},
+			now:   startDue.Add(time.Hour),
+			expected: internalModels.TaskSLA{
+				Status:           internalModels.SLAStatusBreached,
+				DueAt:            null.TimeFrom(startDue),
+				RemainingSeconds: null.Int64From(-3600),
+				Breaches:         []string{internalModels.SLABreachStart},
+			},
+		},
+		{
+			name:  "Started in time, waiting to be completed",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy, StartedAt: null.TimeFrom(createdAt.Add(time.Hour))},
+			now:   startDue,
+			expected: internalModels.TaskSLA{
+				Status:           internalModels.SLAStatusOnTrack,
+				DueAt:            null.TimeFrom(completeDue),
+				RemainingSeconds: null.Int64From(28 * 3600),
+				Breaches:         []string{},
+			},
+		},
+		{
+			name:  "Started late stays breached",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy, StartedAt: null.TimeFrom(startDue.Add(time.Minute))},
+			now:   startDue.Add(time.Hour),
+			expected: internalModels.TaskSLA{
+				Status:           internalModels.SLAStatusBreached,
+				DueAt:            null.TimeFrom(completeDue),
+				RemainingSeconds: null.Int64From(27 * 3600),
+				Breaches:         []string{internalModels.SLABreachStart},
+			},
+		},
+		{
+			name: "Completed in time",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy,
+				StartedAt: null.TimeFrom(createdAt.Add(time.Hour)), CompletedAt: null.TimeFrom(completeDue.Add(-time.Hour))},
+			now:      completeDue.Add(48 * time.Hour),
+			expected: internalModels.TaskSLA{Status: internalModels.SLAStatusMet, Breaches: []string{}},
+		},
+		{
+			name: "Completed late",
+			state: internalModels.TaskSLAState{CreatedAt: createdAt, Policy: policy,
+				StartedAt: null.TimeFrom(createdAt.Add(time.Hour)), CompletedAt: null.TimeFrom(completeDue.Add(time.Hour))},
+			now:      completeDue.Add(2 * time.Hour),
+			expected: internalModels.TaskSLA{Status: internalModels.SLAStatusBreached, Breaches: []string{internalModels.SLABreachComplete}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := controllers.ComputeTaskSLA(tc.state, tc.now); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateSLAPolicy(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        internalModels.SLAPolicy
+		expectedError string
+	}{
+		{name: "Empty policy", policy: internalModels.SLAPolicy{}},
+		{name: "Valid policy", policy: internalModels.SLAPolicy{StartWithinHours: null.IntFrom(2), EscalateToUserID: null.IntFrom(1)}},
+		{name: "Zero start hours", policy: internalModels.SLAPolicy{StartWithinHours: null.IntFrom(0)}, expectedError: "start_within_hours must be positive"},
+		{name: "Negative business hours", policy: internalModels.SLAPolicy{CompleteWithinBusinessHours: null.IntFrom(-8)}, expectedError: "complete_within_business_hours must be positive"},
+		{name: "Escalation without response time", policy: internalModels.SLAPolicy{EscalateToUserID: null.IntFrom(1)}, expectedError: "an escalation user needs a response time"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := controllers.ValidateSLAPolicy(tc.policy)
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("expected error %q, got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestEscalateBreachesNotifies(t *testing.T) {
+	database := testDatabase(t)
+	controller := controllers.NewSLAController(repositories.NewTaskRepository(database))
+	notificationController := controllers.NewNotificationController(repositories.NewNotificationRepository(database))
+	ctx := context.Background()
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	escalationUserID := insertTestUser(t, database, "lead", internalModels.RoleUser)
+	userID := insertTestUser(t, database, "john", internalModels.RoleUser)
+	ownerID := insertTestUser(t, database, "owner", internalModels.RoleUser)
+	// A custom role is notified through its permission, the manager role is not looked up by name
+	if _, err := database.Conn.Exec(`INSERT INTO roles(name) VALUES('lead'); INSERT INTO role_permissions(role, permission) VALUES('lead', 'task.manage');`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	teamLeadID := insertTestUser(t, database, "team-lead", "lead")
+	categoryID := insertTestTaskCategory(t, database, "Support")
+	otherCategoryID := insertTestTaskCategory(t, database, "Sales")
+	otherOwnerID := insertTestUser(t, database, "other-owner", internalModels.RoleUser)
+	_, err := database.Conn.Exec(`INSERT INTO task_category_members(task_category_id, user_id, role) VALUES($1, $2, 'owner'), ($3, $4, 'owner');`,
+		categoryID, ownerID, otherCategoryID, otherOwnerID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	taskID := insertTestTask(t, database, "Ticket", managerID, categoryID)
+	_, err = database.Conn.Exec(`UPDATE task_categories SET sla_start_within_hours=1, sla_escalate_to_user_id=$2 WHERE id=$1;`, categoryID, escalationUserID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := database.Conn.Exec(`UPDATE tasks SET created_at=NOW() - INTERVAL '3 hours' WHERE id=$1;`, taskID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The breach is escalated once, even when the escalation runs again
+	for i, expected := range []int{1, 0} {
+		escalated, err := controller.EscalateBreaches(time.Now(), ctx)
+		if err != nil || escalated != expected {
+			t.Fatalf("expected run %d to escalate %d breaches, got %d and %v", i+1, expected, escalated, err)
+		}
+	}
+
+	// The escalation is listed with the unread notifications of the managers, the owners of the category
+	// and the escalation user
+	expectedNotifications := map[int]int{managerID: 1, teamLeadID: 1, ownerID: 1, escalationUserID: 1, userID: 0, otherOwnerID: 0}
+	for recipientID, expected := range expectedNotifications {
+		notifications, err := notificationController.GetNotificationsOfUser(recipientID, true, ctx)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(notifications) != expected {
+			t.Errorf("expected user %d to have %d notifications, got %+v", recipientID, expected, notifications)
+		}
+		for _, notification := range notifications {
+			if notification.Type != internalModels.NotificationSLABreach || notification.TaskID.Int != taskID {
+				t.Errorf("expected an SLA breach notification of task %d, got %+v", taskID, notification)
+			}
+		}
+	}
+	if assignees := testAssigneeIDs(t, database, taskID); !reflect.DeepEqual(assignees, []int{escalationUserID}) {
+		t.Errorf("expected the escalation user to be assigned, got %v", assignees)
+	}
+}
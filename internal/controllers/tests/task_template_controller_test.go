@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+func TestRenderTemplateText(t *testing.T) {
+	testCases := []struct {
+		name          string
+		text          string
+		variables     map[string]string
+		expected      string
+		expectedError string
+	}{
+		{
+			name:      "Replaces placeholders",
+			text:      "Onboard {{name}} on {{ anchor_date }}",
+			variables: map[string]string{"name": "Alice", "anchor_date": "2023-05-01"},
+			expected:  "Onboard Alice on 2023-05-01",
+		},
+		{
+			name:      "Text without placeholders",
+			text:      "Release checklist",
+			variables: map[string]string{},
+			expected:  "Release checklist",
+		},
+		{
+			name:          "Reports every missing placeholder once",
+			text:          "{{version}} for {{team}} and {{version}}",
+			variables:     map[string]string{},
+			expected:      "{{version}} for {{team}} and {{version}}",
+			expectedError: "missing values for placeholders: team, version",
+		},
+		{
+			name:      "Ignores malformed placeholders",
+			text:      "{{not closed and {single}",
+			variables: map[string]string{},
+			expected:  "{{not closed and {single}",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rendered, err := controllers.RenderTemplateText(tc.text, tc.variables)
+			if tc.expectedError == "" && err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || err.Error() != tc.expectedError) {
+				t.Errorf("Unexpected error: got %v want %s", err, tc.expectedError)
+			}
+			if rendered != tc.expected {
+				t.Errorf("Unexpected text: got %q want %q", rendered, tc.expected)
+			}
+		})
+	}
+}
+
+func TestInstantiateTaskTemplate(t *testing.T) {
+	database := testDatabase(t)
+	controller := controllers.NewTaskTemplateController(repositories.NewTaskTemplateRepository(database), repositories.NewTaskRepository(database), repositories.NewUserRepository(database))
+	ctx := context.Background()
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	aliceID := insertTestUser(t, database, "alice", internalModels.RoleUser)
+	bobID := insertTestUser(t, database, "bob", internalModels.RoleUser)
+	categoryID := insertTestTaskCategory(t, database, "Onboarding")
+
+	template := internalModels.TaskTemplate{
+		Name:           "Onboarding",
+		TaskName:       "Onboard {{name}}",
+		EndOffsetHours: 24,
+		AssigneeIDs:    []int{aliceID},
+		Subtasks: []internalModels.TaskTemplateSubtask{
+			{Name: "Create the accounts of {{name}}", EndOffsetHours: 8},
+			{Name: "Introduce {{name}} to the team", EndOffsetHours: 24, AssigneeIDs: []int{bobID}},
+		},
+	}
+	if err := controller.AddTaskTemplate(&template, managerID, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instantiation := internalModels.TaskTemplateInstantiation{
+		AnchorDate:     time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC),
+		Variables:      map[string]string{"name": "Carol"},
+		TaskCategoryID: categoryID,
+		AssigneeIDs:    []int{bobID + 1000},
+	}
+
+	// An assignee that does not exist fails the instantiation before any task is added
+	if _, err := controller.InstantiateTaskTemplate(template.ID, instantiation, managerID, ctx); err == nil {
+		t.Fatal("expected an error for an assignee that does not exist")
+	}
+	var count int
+	if err := database.Conn.QueryRow(`SELECT COUNT(*) FROM tasks;`).Scan(&count); err != nil || count != 0 {
+		t.Fatalf("expected no task to be added, got %d tasks and %v", count, err)
+	}
+
+	instantiation.AssigneeIDs = nil
+	instance, err := controller.InstantiateTaskTemplate(template.ID, instantiation, managerID, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instance.Task.Name != "Onboard Carol" || instance.Task.ParentTaskID.Valid {
+		t.Errorf("expected the main task without a parent, got %+v", instance.Task)
+	}
+	if len(instance.Subtasks) != 2 {
+		t.Fatalf("expected 2 subtasks, got %d", len(instance.Subtasks))
+	}
+	for _, subtask := range instance.Subtasks {
+		var parentTaskID int
+		if err := database.Conn.QueryRow(`SELECT parent_task_id FROM tasks WHERE id=$1;`, subtask.ID).Scan(&parentTaskID); err != nil || parentTaskID != instance.Task.ID {
+			t.Errorf("expected subtask %d to have the parent %d, got %d and %v", subtask.ID, instance.Task.ID, parentTaskID, err)
+		}
+	}
+
+	// The author is assigned to every task, the subtasks without assignees get those of the main task
+	expectedAssignees := map[int][]int{
+		instance.Task.ID:        {managerID, aliceID},
+		instance.Subtasks[0].ID: {managerID, aliceID},
+		instance.Subtasks[1].ID: {managerID, bobID},
+	}
+	for taskID, expected := range expectedAssignees {
+		if assignees := testAssigneeIDs(t, database, taskID); !reflect.DeepEqual(assignees, expected) {
+			t.Errorf("expected task %d to be assigned to %v, got %v", taskID, expected, assignees)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+func newTestTrashController(database *repositories.Database) *controllers.TrashController {
+	return controllers.NewTrashController(repositories.NewTaskRepository(database), repositories.NewTaskCategoryRepository(database), repositories.NewUserRepository(database))
+}
+
+func TestTrashListAndRestore(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestTrashController(database)
+	ctx := context.Background()
+
+	managerID := insertTestUser(t, database, "manager", internalModels.RoleManager)
+	userID := insertTestUser(t, database, "john", internalModels.RoleUser)
+	categoryID := insertTestTaskCategory(t, database, "Backend")
+	taskID := insertTestTask(t, database, "Task 1", managerID, categoryID)
+
+	if err := repositories.NewTaskRepository(database).DeleteTask(taskID, managerID, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repositories.NewUserRepository(database).DeleteUser(userID, managerID, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	trash, err := controller.GetTrash(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trash.Tasks) != 1 || trash.Tasks[0].ID != taskID || trash.Tasks[0].Type != internalModels.TrashTypeTask {
+		t.Errorf("expected task %d in the trash, got %+v", taskID, trash.Tasks)
+	}
+	if !trash.Tasks[0].DeletedBy.Valid || trash.Tasks[0].DeletedBy.Int != managerID {
+		t.Errorf("expected the task to be deleted by %d, got %v", managerID, trash.Tasks[0].DeletedBy)
+	}
+	if len(trash.Users) != 1 || trash.Users[0].ID != userID {
+		t.Errorf("expected user %d in the trash, got %+v", userID, trash.Users)
+	}
+	if len(trash.TaskCategories) != 0 {
+		t.Errorf("expected no task category in the trash, got %+v", trash.TaskCategories)
+	}
+
+	if err := controller.RestoreTask(taskID, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := controller.RestoreTask(taskID, ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected a task out of the trash not to be restored again, got %v", err)
+	}
+	if err := controller.RestoreUser(userID, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := controller.RestoreTaskCategory(categoryID, ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected a task category out of the trash not to be restored, got %v", err)
+	}
+
+	trash, err = controller.GetTrash(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(trash.Tasks)+len(trash.TaskCategories)+len(trash.Users) != 0 {
+		t.Errorf("expected the trash to be empty, got %+v", trash)
+	}
+}
+
+func TestTrashPurgeItem(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestTrashController(database)
+	ctx := context.Background()
+	deletedAt := time.Now()
+
+	authorID := insertTestUser(t, database, "author", internalModels.RoleManager)
+	categoryID := insertTestTaskCategory(t, database, "Backend")
+	taskID := insertTestTask(t, database, "Task 1", authorID, categoryID)
+	trashTestRow(t, database, "task_categories", categoryID, deletedAt)
+	trashTestRow(t, database, "users", authorID, deletedAt)
+
+	// The live task still references the category and the user
+	if err := controller.PurgeTaskCategory(categoryID, ctx); err != repositories.ErrPurgeBlocked {
+		t.Errorf("expected %v, got %v", repositories.ErrPurgeBlocked, err)
+	}
+	if err := controller.PurgeUser(authorID, ctx); err != repositories.ErrPurgeBlocked {
+		t.Errorf("expected %v, got %v", repositories.ErrPurgeBlocked, err)
+	}
+	if err := controller.PurgeTask(taskID, ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected a task out of the trash not to be purged, got %v", err)
+	}
+
+	trashTestRow(t, database, "tasks", taskID, deletedAt)
+	if err := controller.PurgeTask(taskID, ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := controller.PurgeTaskCategory(categoryID, ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := controller.PurgeUser(authorID, ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for table, id := range map[string]int{"tasks": taskID, "task_categories": categoryID, "users": authorID} {
+		if testRowExists(t, database, table, id) {
+			t.Errorf("expected %s %d to be purged", table, id)
+		}
+	}
+	if err := controller.PurgeUser(authorID, ctx); err != repositories.ErrNoMatch {
+		t.Errorf("expected %v, got %v", repositories.ErrNoMatch, err)
+	}
+}
+
+func TestTrashPurgeExpired(t *testing.T) {
+	database := testDatabase(t)
+	controller := newTestTrashController(database)
+	ctx := context.Background()
+	expired := time.Now().Add(-48 * time.Hour)
+
+	authorID := insertTestUser(t, database, "author", internalModels.RoleManager)
+	assigneeID := insertTestUser(t, database, "assignee", internalModels.RoleUser)
+	referencedCategoryID := insertTestTaskCategory(t, database, "Referenced")
+	emptyCategoryID := insertTestTaskCategory(t, database, "Empty")
+	expiredTaskID := insertTestTask(t, database, "Expired", assigneeID, emptyCategoryID)
+	liveTaskID := insertTestTask(t, database, "Live", authorID, referencedCategoryID)
+	recentTaskID := insertTestTask(t, database, "Recent", authorID, referencedCategoryID)
+	assignTestUser(t, database, assigneeID, liveTaskID)
+
+	trashTestRow(t, database, "tasks", expiredTaskID, expired)
+	trashTestRow(t, database, "tasks", recentTaskID, time.Now())
+	trashTestRow(t, database, "task_categories", referencedCategoryID, expired)
+	trashTestRow(t, database, "task_categories", emptyCategoryID, expired)
+	trashTestRow(t, database, "users", authorID, expired)
+	trashTestRow(t, database, "users", assigneeID, expired)
+
+	report, err := controller.PurgeExpired(24*time.Hour, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The expired task is purged first, which frees its category and its author
+	if report.Purged != 3 {
+		t.Errorf("expected 3 purged items, got %d", report.Purged)
+	}
+	blocked := map[string]int{}
+	for _, item := range report.Blocked {
+		blocked[item.Type] = item.ID
+	}
+	if len(report.Blocked) != 2 || blocked[internalModels.TrashTypeTaskCategory] != referencedCategoryID || blocked[internalModels.TrashTypeUser] != authorID {
+		t.Errorf("expected category %d and user %d to be blocked, got %+v", referencedCategoryID, authorID, report.Blocked)
+	}
+	for table, id := range map[string]int{"tasks": expiredTaskID, "task_categories": emptyCategoryID, "users": assigneeID} {
+		if testRowExists(t, database, table, id) {
+			t.Errorf("expected %s %d to be purged", table, id)
+		}
+	}
+	for table, id := range map[string]int{"tasks": recentTaskID, "task_categories": referencedCategoryID, "users": authorID} {
+		if !testRowExists(t, database, table, id) {
+			t.Errorf("expected %s %d to be kept", table, id)
+		}
+	}
+
+	// Nothing is left to purge, and the blocked items are reported again
+	report, err = controller.PurgeExpired(24*time.Hour, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Purged != 0 || len(report.Blocked) != 2 {
+		t.Errorf("expected only the 2 blocked items, got %+v", report)
+	}
+}
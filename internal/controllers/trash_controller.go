@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Job type purging the trash, each run queues the next one
+const JobTypePurgeTrash = "purge_trash"
+
+// Delay between the runs of the purge job
+const purgeJobInterval = time.Hour
+
+type TrashController struct {
+	TaskRepository         *repositories.TaskRepository
+	TaskCategoryRepository *repositories.TaskCategoryRepository
+	UserRepository         *repositories.UserRepository
+}
+
+func NewTrashController(taskRepository *repositories.TaskRepository, taskCategoryRepository *repositories.TaskCategoryRepository, userRepository *repositories.UserRepository) *TrashController {
+	return &TrashController{TaskRepository: taskRepository, TaskCategoryRepository: taskCategoryRepository, UserRepository: userRepository}
+}
+
+// Gets every item in the trash grouped by type
+func (c *TrashController) GetTrash(ctx context.Context) (models.Trash, error) {
+	trash := models.Trash{}
+	var err error
+	trash.Tasks, err = c.TaskRepository.GetDeletedTasks(ctx)
+	if err != nil {
+		return trash, err
+	}
+	trash.TaskCategories, err = c.TaskCategoryRepository.GetDeletedTaskCategories(ctx)
+	if err != nil {
+		return trash, err
+	}
+	trash.Users, err = c.UserRepository.GetDeletedUsers(ctx)
+	if err != nil {
+		return trash, err
+	}
+	return trash, nil
+}
+
+func (c *TrashController) RestoreTask(taskID int, ctx context.Context) error {
+	return c.TaskRepository.RestoreTask(taskID, ctx)
+}
+
+func (c *TrashController) PurgeTask(taskID int, ctx context.Context) error {
+	return c.TaskRepository.PurgeTask(taskID, ctx)
+}
+
+func (c *TrashController) RestoreTaskCategory(taskCategoryID int, ctx context.Context) error {
+	return c.TaskCategoryRepository.RestoreTaskCategory(taskCategoryID, ctx)
+}
+
+func (c *TrashController) PurgeTaskCategory(taskCategoryID int, ctx context.Context) error {
+	return c.TaskCategoryRepository.PurgeTaskCategory(taskCategoryID, ctx)
+}
+
+func (c *TrashController) RestoreUser(userID int, ctx context.Context) error {
+	return c.UserRepository.RestoreUser(userID, ctx)
+}
+
+func (c *TrashController) PurgeUser(userID int, ctx context.Context) error {
+	return c.UserRepository.PurgeUser(userID, ctx)
+}
+
+// Permanently deletes every item that has been in the trash for longer than the retention period.
+// Tasks are purged first so that categories and users they reference can be removed afterwards, the
+// categories and users that remaining tasks still reference are skipped and reported.
+func (c *TrashController) PurgeExpired(retention time.Duration, ctx context.Context) (models.PurgeReport, error) {
+	before := time.Now().Add(-retention)
+	report := models.PurgeReport{Blocked: []models.TrashItem{}}
+
+	count, err := c.TaskRepository.PurgeTasksDeletedBefore(before, ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Purged += count
+
+	count, blocked, err := c.TaskCategoryRepository.PurgeTaskCategoriesDeletedBefore(before, ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Purged += count
+	report.Blocked = append(report.Blocked, blocked...)
+
+	count, blocked, err = c.UserRepository.PurgeUsersDeletedBefore(before, ctx)
+	if err != nil {
+		return report, err
+	}
+	report.Purged += count
+	report.Blocked = append(report.Blocked, blocked...)
+	return report, nil
+}
+
+// Registers the job type purging the trash. A run queues the next one an hour later with the same payload
+// before purging, so a failed attempt does not stop the schedule.
+func (c *TrashController) RegisterJobs(registry *JobRegistry, jobController *JobController) {
+	registry.Register(JobTypePurgeTrash, func(ctx context.Context, job models.Job, progress func(int)) (interface{}, error) {
+		payload := models.PurgeTrashJob{}
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return nil, err
+		}
+		if _, err := jobController.ScheduleJob(models.NewJob{Type: JobTypePurgeTrash, Payload: payload, RunAt: time.Now().Add(purgeJobInterval)}, ctx); err != nil {
+			return nil, err
+		}
+		report, err := c.PurgeExpired(time.Duration(payload.RetentionDays)*24*time.Hour, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if report.Purged > 0 {
+			log.Printf("Purged %d items from the trash", report.Purged)
+		}
+		for _, item := range report.Blocked {
+			log.Printf("Could not purge %s %d from the trash, tasks still reference it", item.Type, item.ID)
+		}
+		return report, nil
+	}, 0, 0)
+}
@@ -2,18 +2,20 @@ package controllers
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"html"
-	"net/http"
 	"strings"
 
-	"github.com/go-chi/jwtauth/v5"
-	"github.com/lestrrat-go/jwx/v2/jwt"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/volatiletech/null/v8"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var ErrEmailInUse = errors.New("the email is already used by another user")
+
 type UserController struct {
 	UserRepository *repositories.UserRepository
 }
@@ -47,6 +49,9 @@ func (c *UserController) AddUser(user *models.User, ctx context.Context) error {
 func (c *UserController) GetUserByID(userID int, ctx context.Context) (*models.User, error) {
 	user, err := c.UserRepository.GetUserByID(userID, ctx)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, repositories.ErrNoMatch
+		}
 		return nil, err
 	}
 	return user, nil
@@ -59,8 +64,8 @@ func (c *UserController) GetUserByEmail(userEmail string, ctx context.Context) (
 	return user, nil
 }
 
-func (c *UserController) DeleteUser(userID int, ctx context.Context, r *http.Request, tokenAuth *jwtauth.JWTAuth, token jwt.Token) error {
-	rowsAff, err := c.UserRepository.DeleteUser(userID, ctx)
+func (c *UserController) DeleteUser(userID, deletedBy int, ctx context.Context) error {
+	rowsAff, err := c.UserRepository.DeleteUser(userID, deletedBy, ctx)
 	if err != nil {
 		return err
 	}
@@ -70,22 +75,40 @@ func (c *UserController) DeleteUser(userID int, ctx context.Context, r *http.Req
 	return nil
 }
 
-func (c *UserController) UpdateUser(userID int, userData models.User, ctx context.Context) (*models.User, error) {
+// Changes the name of a user and asks for a new email, and reports whether a new email is pending. The new email
+// replaces the current one only once the user opens the link sent to it.
+func (c *UserController) UpdateUser(userID int, userData models.User, ctx context.Context) (*models.User, bool, error) {
 	user, err := c.UserRepository.GetUserByID(userID, ctx)
 	if err != nil {
-		return user, err
+		if err == sql.ErrNoRows {
+			return user, false, repositories.ErrNoMatch
+		}
+		return user, false, err
 	}
 
+	emailChanged := user.Email != userData.Email
+	if emailChanged {
+		other, err := c.UserRepository.GetUserByEmail(userData.Email, ctx)
+		if err == nil && other.ID != user.ID {
+			return user, false, ErrEmailInUse
+		}
+		if err != nil && err != sql.ErrNoRows {
+			return user, false, err
+		}
+		user.PendingEmail = null.StringFrom(userData.Email)
+	} else {
+		// Asking for the current email again drops the pending one
+		user.PendingEmail = null.String{}
+	}
 	user.Name = userData.Name
-	user.Email = userData.Email
 	userUpdated, err := c.UserRepository.UpdateUser(user, ctx)
 	if err != nil {
-		return user, err
+		return user, false, err
 	}
-	return userUpdated, nil
+	return userUpdated, emailChanged, nil
 }
 
-func (c *UserController) UpdateRole(userID int, role string, ctx context.Context, r *http.Request, tokenAuth *jwtauth.JWTAuth) (*models.User, error) {
+func (c *UserController) UpdateRole(userID int, role string, ctx context.Context) (*models.User, error) {
 	user, err := c.UserRepository.GetUserByID(userID, ctx)
 	if err != nil {
 		return user, err
@@ -99,33 +122,6 @@ func (c *UserController) UpdateRole(userID int, role string, ctx context.Context
 	return userUpdated, nil
 }
 
-// Checks if a user is a manager
-func (c *UserController) IsManager(ctx context.Context, r *http.Request, tokenAuth *jwtauth.JWTAuth) error {
-	token, err := tokenAuth.Decode(jwtauth.TokenFromCookie(r))
-	if err != nil {
-		return err
-	}
-
-	email, _ := token.Get("email")
-
-	// Convert email from interface{} to string
-	emailStr, ok := email.(string)
-	if !ok {
-		return errors.New("cannot convert email from interface to string")
-	}
-
-	isManager, err := c.UserRepository.IsManager(ctx, emailStr)
-
-	if err != nil {
-		return err
-	}
-	if !isManager {
-		return errors.New("you are not the manager")
-	}
-
-	return nil
-}
-
 func (c *UserController) CompareEmailAndPassword(email, password string, ctx context.Context) (bool, error) {
 	users, err := c.UserRepository.GetAllUsers(ctx)
 	if err != nil {
@@ -143,7 +139,7 @@ func (c *UserController) CompareEmailAndPassword(email, password string, ctx con
 	return false, errors.New("your email is wrong")
 }
 
-func (c *UserController) ChangeUserPassword(oldPassword, newPassword, email string, ctx context.Context, r *http.Request, tokenAuth *jwtauth.JWTAuth) error {
+func (c *UserController) ChangeUserPassword(oldPassword, newPassword, email string, ctx context.Context) error {
 	newPassword = html.EscapeString(strings.TrimSpace(newPassword))
 	oldPassword = html.EscapeString(strings.TrimSpace(oldPassword))
 
@@ -172,8 +168,9 @@ func (c *UserController) ChangeUserPassword(oldPassword, newPassword, email stri
 	return nil
 }
 
+// Gets the managers, the users whose role grants task.manage
 func (c *UserController) GetUsersManager(ctx context.Context) (models.UserSlice, error) {
-	users, err := c.UserRepository.GetUsersManager(ctx)
+	users, err := c.UserRepository.GetUsersWithPermission(internalModels.PermissionTaskManage, ctx)
 	if err != nil {
 		return users, err
 	}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+var ErrSessionRevoked = errors.New("the session is revoked or expired")
+
+// How long the state of a session is trusted before it is read again, a session revoked by another server
+// is accepted for at most this long
+const sessionCheckInterval = 30 * time.Second
+
+// How often the last seen time of a session is written
+const sessionTouchInterval = time.Minute
+
+// The cache is swept of stale sessions when it grows over this size
+const sessionCacheSweepSize = 10000
+
+type sessionState struct {
+	userID    int
+	active    bool
+	checkedAt time.Time
+	touchedAt time.Time
+}
+
+// Starts, renews and revokes the sessions of the users. The state of the sessions is cached, so the access
+// tokens are checked on every request without reading the database every time.
+type UserSessionController struct {
+	UserSessionRepository *repositories.UserSessionRepository
+	// How long a session lasts without being refreshed
+	RefreshTTL time.Duration
+
+	mu     sync.Mutex
+	states map[int]sessionState
+}
+
+func NewUserSessionController(userSessionRepository *repositories.UserSessionRepository, refreshTTL time.Duration) *UserSessionController {
+	return &UserSessionController{UserSessionRepository: userSessionRepository, RefreshTTL: refreshTTL, states: make(map[int]sessionState)}
+}
+
+// Starts a session of a user on a device, and returns its first tokens
+func (c *UserSessionController) StartSession(user *models.User, device internalModels.UserSession, tokenAuth *TokenIssuer, now time.Time, ctx context.Context) (internalModels.SessionTokens, error) {
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return internalModels.SessionTokens{}, err
+	}
+	device.UserID = user.ID
+	device.ExpiresAt = now.Add(c.RefreshTTL)
+	session, err := c.UserSessionRepository.AddUserSession(device, hashRefreshToken(refreshToken), ctx)
+	if err != nil {
+		return internalModels.SessionTokens{}, err
+	}
+	return sessionTokens(user, session.ID, refreshToken, tokenAuth, now)
+}
+
+// Exchanges a refresh token for new tokens, the refresh token cannot be used again. Reusing a refresh
+// token revokes its session, since either the user or a thief holds a copy.
+func (c *UserSessionController) Refresh(refreshToken string, device internalModels.UserSession, userController *UserController, tokenAuth *TokenIssuer, now time.Time, ctx context.Context) (internalModels.SessionTokens, error) {
+	newToken, err := newRefreshToken()
+	if err != nil {
+		return internalModels.SessionTokens{}, err
+	}
+	session, err := c.UserSessionRepository.RotateRefreshToken(hashRefreshToken(refreshToken), hashRefreshToken(newToken), device.UserAgent, device.IPAddress, now.Add(c.RefreshTTL), ctx)
+	if err != nil {
+		if err == repositories.ErrRefreshTokenReused {
+			c.forgetSession(session.ID)
+		}
+		return internalModels.SessionTokens{}, err
+	}
+	user, err := userController.GetUserByID(session.UserID, ctx)
+	if err != nil {
+		return internalModels.SessionTokens{}, err
+	}
+	return sessionTokens(user, session.ID, newToken, tokenAuth, now)
+}
+
+func sessionTokens(user *models.User, sessionID int, refreshToken string, tokenAuth *TokenIssuer, now time.Time) (internalModels.SessionTokens, error) {
+	accessToken, err := tokenAuth.Issue(user, sessionID, now)
+	if err != nil {
+		return internalModels.SessionTokens{}, err
+	}
+	return internalModels.SessionTokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(tokenAuth.TTL().Seconds()),
+		SessionID:    sessionID,
+	}, nil
+}
+
+// Checks that the session of an access token is still active. The state of a session is read again after
+// sessionCheckInterval, and its last seen time is written at most every sessionTouchInterval.
+func (c *UserSessionController) CheckSession(sessionID, userID int, now time.Time, ctx context.Context) error {
+	c.mu.Lock()
+	state, ok := c.states[sessionID]
+	c.mu.Unlock()
+
+	if !ok || now.Sub(state.checkedAt) >= sessionCheckInterval {
+		sessionUserID, err := c.UserSessionRepository.GetActiveSessionUserID(sessionID, ctx)
+		if err != nil && err != repositories.ErrNoMatch {
+			return err
+		}
+		state = sessionState{userID: sessionUserID, active: err == nil, checkedAt: now, touchedAt: state.touchedAt}
+	}
+	if state.active && now.Sub(state.touchedAt) >= sessionTouchInterval {
+		if err := c.UserSessionRepository.TouchUserSession(sessionID, ctx); err != nil {
+			return err
+		}
+		state.touchedAt = now
+	}
+
+	c.mu.Lock()
+	if len(c.states) >= sessionCacheSweepSize {
+		for id, cached := range c.states {
+			if now.Sub(cached.checkedAt) >= sessionCheckInterval {
+				delete(c.states, id)
+			}
+		}
+	}
+	c.states[sessionID] = state
+	c.mu.Unlock()
+
+	if !state.active || state.userID != userID {
+		return ErrSessionRevoked
+	}
+	return nil
+}
+
+// Gets the active sessions of a user, marking the current one
+func (c *UserSessionController) GetSessions(userID, currentSessionID int, ctx context.Context) ([]internalModels.UserSession, error) {
+	sessions, err := c.UserSessionRepository.GetActiveSessionsOfUser(userID, ctx)
+	if err != nil {
+		return sessions, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentSessionID
+	}
+	return sessions, nil
+}
+
+// Revokes a session of a user, its access tokens stop working immediately on this server
+func (c *UserSessionController) RevokeSession(sessionID, userID int, reason string, ctx context.Context) error {
+	if err := c.UserSessionRepository.RevokeUserSession(sessionID, userID, reason, ctx); err != nil {
+		return err
+	}
+	c.forgetSession(sessionID)
+	return nil
+}
+
+// Revokes the session of a refresh token
+func (c *UserSessionController) RevokeRefreshToken(refreshToken, reason string, ctx context.Context) error {
+	session, err := c.UserSessionRepository.RevokeUserSessionByRefreshToken(hashRefreshToken(refreshToken), reason, ctx)
+	if err != nil {
+		return err
+	}
+	c.forgetSession(session.ID)
+	return nil
+}
+
+// Revokes every session of a user, such as when their password or role changes
+func (c *UserSessionController) RevokeAllSessions(userID int, reason string, ctx context.Context) (int64, error) {
+	count, err := c.UserSessionRepository.RevokeAllUserSessions(userID, reason, ctx)
+	if err != nil {
+		return count, err
+	}
+	c.ForgetUserSessions(userID)
+	return count, nil
+}
+
+// Removes the sessions of a user from the cache, after they were revoked without the controller
+func (c *UserSessionController) ForgetUserSessions(userID int) {
+	c.mu.Lock()
+	for id, state := range c.states {
+		if state.userID == userID {
+			delete(c.states, id)
+		}
+	}
+	c.mu.Unlock()
+}
+
+// Removes a session from the cache, so its state is read again
+func (c *UserSessionController) forgetSession(sessionID int) {
+	c.mu.Lock()
+	delete(c.states, sessionID)
+	c.mu.Unlock()
+}
+
+func newRefreshToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
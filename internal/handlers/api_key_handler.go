@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+func (h *UserHandler) apiKeys(router chi.Router) {
+	router.Get("/", h.getMyAPIKeys)
+	router.Post("/", h.addAPIKey)
+	router.Delete("/{apiKeyID}", h.revokeAPIKey)
+}
+
+func (h *UserHandler) getMyAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	apiKeys, err := h.AuthController.GetAPIKeys(user.ID, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, apiKeys)
+}
+
+// Creates an API key of the logged in user, the key is only shown in this response
+func (h *UserHandler) addAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	keyData := struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&keyData); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	apiKey, err := h.AuthController.CreateAPIKey(user.ID, keyData.Name, ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	utils.RenderJsonStatus(w, http.StatusCreated, apiKey)
+}
+
+func (h *UserHandler) revokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	apiKeyID, err := strconv.Atoi(chi.URLParam(r, "apiKeyID"))
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(errors.New("invalid API key ID")))
+		return
+	}
+	if err := h.AuthController.RevokeAPIKey(apiKeyID, user.ID, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, success{Status: "success"})
+}
@@ -0,0 +1,691 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+)
+
+// Path the CalDAV server is mounted at
+const calDAVRoot = "/caldav"
+
+const (
+	davNamespace            = "DAV:"
+	calDAVNamespace         = "urn:ietf:params:xml:ns:caldav"
+	calendarServerNamespace = "http://calendarserver.org/ns/"
+)
+
+var davPrefixes = map[string]string{davNamespace: "d", calDAVNamespace: "c", calendarServerNamespace: "cs"}
+
+// Largest calendar object a client can upload
+const maxCalDAVObjectSize = 1 << 20
+
+func init() {
+	chi.RegisterMethod("PROPFIND")
+	chi.RegisterMethod("REPORT")
+}
+
+type CalDAVHandler struct {
+	CalDAVController            *controllers.CalDAVController
+	TaskCategoryController      *controllers.TaskCategoryController
+	UserController              *controllers.UserController
+	AuthController              *controllers.AuthController
+	EmailVerificationController *controllers.EmailVerificationController
+}
+
+func NewCalDAVHandler(database *repositories.Database, authController *controllers.AuthController, emailVerificationController *controllers.EmailVerificationController) *CalDAVHandler {
+	taskRepository := repositories.NewTaskRepository(database)
+	checklistRepository := repositories.NewChecklistRepository(database)
+	taskController := controllers.NewTaskController(taskRepository, checklistRepository)
+	userRepository := repositories.NewUserRepository(database)
+	userController := controllers.NewUserController(userRepository)
+	mentionRepository := repositories.NewMentionRepository(database)
+	notificationRepository := repositories.NewNotificationRepository(database)
+	mentionController := controllers.NewMentionController(mentionRepository, notificationRepository, userRepository)
+	taskCategoryRepository := repositories.NewTaskCategoryRepository(database)
+	calDAVRepository := repositories.NewCalDAVRepository(database)
+	calDAVController := controllers.NewCalDAVController(taskController, mentionController, taskCategoryRepository, calDAVRepository)
+	taskCategoryController := controllers.NewTaskCategoryController(taskCategoryRepository)
+	return &CalDAVHandler{
+		CalDAVController:            calDAVController,
+		TaskCategoryController:      taskCategoryController,
+		UserController:              userController,
+		AuthController:              authController,
+		EmailVerificationController: emailVerificationController,
+	}
+}
+
+func (h *CalDAVHandler) calDAV(router chi.Router) {
+	router.Use(h.basicAuth)
+	router.Options("/*", h.options)
+	router.MethodFunc("PROPFIND", "/", h.propfindHome)
+	router.Route("/{taskCategoryID}", func(router chi.Router) {
+		router.Use(h.authorizeCalendar)
+		router.Options("/*", h.options)
+		router.MethodFunc("PROPFIND", "/", h.propfindCalendar)
+		router.MethodFunc("REPORT", "/", h.report)
+		router.Get("/{objectName}", h.getCalendarObject)
+		router.Put("/{objectName}", h.putCalendarObject)
+		router.Delete("/{objectName}", h.deleteCalendarObject)
+	})
+}
+
+// Redirects clients discovering the server (RFC 6764) to the CalDAV root
+func calDAVWellKnown(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, calDAVRoot+"/", http.StatusMovedPermanently)
+}
+
+// Calendar clients cannot use the JWT cookie, so CalDAV requests log in with the email and password of the user
+func (h *CalDAVHandler) basicAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		email, password, ok := r.BasicAuth()
+		if ok {
+			if valid, _ := h.UserController.CompareEmailAndPassword(email, password, ctx); valid {
+				user, err := h.UserController.GetUserByEmail(email, ctx)
+				if err != nil {
+					render.Render(w, r, ServerErrorRenderer(err))
+					return
+				}
+				err = h.EmailVerificationController.CheckLogin(user.ID, ctx)
+				if errors.Is(err, repositories.ErrEmailNotVerified) {
+					http.Error(w, err.Error(), http.StatusForbidden)
+					return
+				}
+				if err != nil {
+					render.Render(w, r, ServerErrorRenderer(err))
+					return
+				}
+				principal, err := h.AuthController.NewPrincipal(user, internalModels.AuthMethodBasic, time.Now(), ctx)
+				if err != nil {
+					render.Render(w, r, ServerErrorRenderer(err))
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(controllers.WithAuthentication(ctx, principal, nil)))
+				return
+			}
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="tasks", charset="UTF-8"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	})
+}
+
+// Every task category the user can see is a calendar, the others are not found
+func (h *CalDAVHandler) authorizeCalendar(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+		if err != nil {
+			render.Render(w, r, ErrNotFound)
+			return
+		}
+		if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+			if err == repositories.ErrNoMatch {
+				render.Render(w, r, ErrNotFound)
+			} else {
+				render.Render(w, r, ServerErrorRenderer(err))
+			}
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Checks if the user manages the tasks of a calendar, as a holder of the given permission or an owner of the task category
+func (h *CalDAVHandler) managesCalendar(r *http.Request, taskCategoryID int, override internalModels.Permission) bool {
+	_, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, override)
+	return err == nil
+}
+
+func calDAVUser(r *http.Request) *models.User {
+	user, _ := controllers.CurrentUser(r.Context())
+	return user
+}
+
+func (h *CalDAVHandler) options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, PROPFIND, REPORT, GET, PUT, DELETE")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *CalDAVHandler) validateTaskCategoryIDFromURLParam(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "taskCategoryID"))
+	if err != nil || id <= 0 {
+		return 0, errors.New("invalid task category ID")
+	}
+	return id, nil
+}
+
+// Gets the name of the calendar object in the URL without the .ics extension
+func (h *CalDAVHandler) objectNameFromURLParam(r *http.Request) (string, error) {
+	name := chi.URLParam(r, "objectName")
+	// The router only gives the escaped name when the path has characters that must stay escaped
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	if !strings.HasSuffix(name, ".ics") || name == ".ics" {
+		return "", repositories.ErrNoMatch
+	}
+	return strings.TrimSuffix(name, ".ics"), nil
+}
+
+func (h *CalDAVHandler) propfindHome(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requested, err := requestedDAVProps(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	responses := []davResponse{{
+		Href: calDAVRoot + "/",
+		Props: []davProperty{
+			{Name: xml.Name{Space: davNamespace, Local: "resourcetype"}, Value: davElement(davNamespace, "collection", "")},
+			{Name: xml.Name{Space: davNamespace, Local: "displayname"}, Value: "Tasks"},
+			{Name: xml.Name{Space: davNamespace, Local: "current-user-principal"}, Value: davHref(calDAVRoot + "/")},
+			{Name: xml.Name{Space: calDAVNamespace, Local: "calendar-home-set"}, Value: davHref(calDAVRoot + "/")},
+		},
+	}}
+	if r.Header.Get("Depth") != "0" {
+		user := calDAVUser(r)
+		taskCategories, err := h.TaskCategoryController.GetVisibleTaskCategories(user.ID, controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll), ctx)
+		if err != nil {
+			render.Render(w, r, ServerErrorRenderer(err))
+			return
+		}
+		accesses, err := h.TaskCategoryController.GetTaskCategoryAccesses(user.ID, ctx)
+		if err != nil {
+			render.Render(w, r, ServerErrorRenderer(err))
+			return
+		}
+		for _, taskCategory := range taskCategories {
+			objects, err := h.CalDAVController.GetCalendarObjects(taskCategory.ID, ctx)
+			if err != nil {
+				render.Render(w, r, ServerErrorRenderer(err))
+				return
+			}
+			responses = append(responses, calendarDAVResponse(taskCategory, objects, calDAVPrivileges(r, accesses[taskCategory.ID])))
+		}
+	}
+	writeMultiStatus(w, responses, requested)
+}
+
+func (h *CalDAVHandler) propfindCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrNotFound)
+		return
+	}
+	requested, err := requestedDAVProps(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	taskCategory, objects, err := h.getCalendar(taskCategoryID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	access, err := h.TaskCategoryController.GetTaskCategoryAccess(taskCategoryID, calDAVUser(r).ID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	responses := []davResponse{calendarDAVResponse(taskCategory, objects, calDAVPrivileges(r, access))}
+	if r.Header.Get("Depth") != "0" {
+		for _, object := range objects {
+			responses = append(responses, objectDAVResponse(taskCategoryID, object))
+		}
+	}
+	writeMultiStatus(w, responses, requested)
+}
+
+func (h *CalDAVHandler) getCalendar(taskCategoryID int, ctx context.Context) (*models.TaskCategory, []internalModels.CalDAVObject, error) {
+	taskCategory, err := h.CalDAVController.GetCalendar(taskCategoryID, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	objects, err := h.CalDAVController.GetCalendarObjects(taskCategoryID, ctx)
+	return taskCategory, objects, err
+}
+
+// Serves the calendar-query and calendar-multiget reports of RFC 4791
+func (h *CalDAVHandler) report(w http.ResponseWriter, r *http.Request) {
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrNotFound)
+		return
+	}
+	report := calDAVReport{}
+	if err := xml.NewDecoder(io.LimitReader(r.Body, maxCalDAVObjectSize)).Decode(&report); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	requested := davPropNames(report.Prop.Names)
+	_, objects, err := h.getCalendar(taskCategoryID, r.Context())
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+
+	responses := []davResponse{}
+	switch report.XMLName {
+	case xml.Name{Space: calDAVNamespace, Local: "calendar-query"}:
+		// Only to-dos are served, so a query for other components matches nothing
+		if !report.Filter.CompFilter.matchesTodos() {
+			break
+		}
+		for _, object := range objects {
+			responses = append(responses, objectDAVResponse(taskCategoryID, object))
+		}
+	case xml.Name{Space: calDAVNamespace, Local: "calendar-multiget"}:
+		byName := make(map[string]internalModels.CalDAVObject, len(objects))
+		for _, object := range objects {
+			byName[object.Name] = object
+		}
+		for _, href := range report.Hrefs {
+			name, _ := url.PathUnescape(path.Base(strings.TrimSpace(href)))
+			object, ok := byName[strings.TrimSuffix(name, ".ics")]
+			if !ok {
+				responses = append(responses, davResponse{Href: href, Status: http.StatusNotFound})
+				continue
+			}
+			responses = append(responses, objectDAVResponse(taskCategoryID, object))
+		}
+	default:
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(xml.Header + `<d:error xmlns:d="DAV:"><d:supported-report/></d:error>`))
+		return
+	}
+	writeMultiStatus(w, responses, requested)
+}
+
+func (h *CalDAVHandler) getCalendarObject(w http.ResponseWriter, r *http.Request) {
+	object, err := h.calendarObjectFromURL(r)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	w.Header().Set("ETag", object.ETag)
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write([]byte(object.Data))
+}
+
+func (h *CalDAVHandler) putCalendarObject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrNotFound)
+		return
+	}
+	name, err := h.objectNameFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrNotFound)
+		return
+	}
+	if _, err := h.CalDAVController.GetCalendar(taskCategoryID, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	existing, err := h.CalDAVController.GetCalendarObject(taskCategoryID, name, ctx)
+	if err != nil && err != repositories.ErrNoMatch {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	if !calDAVPreconditionsMet(r, existing, err == nil) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCalDAVObjectSize))
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+
+	// Owners of the task category manage its tasks like the managers
+	actor := internalModels.TaskActor{
+		UserID:  calDAVUser(r).ID,
+		Manager: h.managesCalendar(r, taskCategoryID, internalModels.PermissionTaskManage),
+		Locker:  h.managesCalendar(r, taskCategoryID, internalModels.PermissionTaskLock),
+	}
+	object, created, err := h.CalDAVController.PutCalendarObject(taskCategoryID, name, string(body), actor, ctx)
+	if err != nil {
+		switch {
+		case err == controllers.ErrCalDAVForbidden, errors.Is(err, controllers.ErrTaskForbidden):
+			render.Render(w, r, &ErrorResponse{Err: err, StatusCode: http.StatusForbidden, StatusText: "Forbidden", Message: err.Error()})
+		case err == repositories.ErrNoMatch:
+			render.Render(w, r, ErrNotFound)
+		default:
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	w.Header().Set("ETag", object.ETag)
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (h *CalDAVHandler) deleteCalendarObject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	object, err := h.calendarObjectFromURL(r)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	if !calDAVPreconditionsMet(r, object, true) {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	user := calDAVUser(r)
+	isManager := h.managesCalendar(r, object.Task.TaskCategoryID, internalModels.PermissionTaskDelete)
+	err = h.CalDAVController.DeleteCalendarObject(object.Task.TaskCategoryID, object.Name, user.ID, isManager, ctx)
+	if err != nil {
+		switch err {
+		case controllers.ErrCalDAVForbidden:
+			render.Render(w, r, &ErrorResponse{Err: err, StatusCode: http.StatusForbidden, StatusText: "Forbidden", Message: err.Error()})
+		case repositories.ErrNoMatch:
+			render.Render(w, r, ErrNotFound)
+		default:
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CalDAVHandler) calendarObjectFromURL(r *http.Request) (internalModels.CalDAVObject, error) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		return internalModels.CalDAVObject{}, repositories.ErrNoMatch
+	}
+	name, err := h.objectNameFromURLParam(r)
+	if err != nil {
+		return internalModels.CalDAVObject{}, err
+	}
+	return h.CalDAVController.GetCalendarObject(taskCategoryID, name, ctx)
+}
+
+// Checks the If-Match and If-None-Match headers, so clients do not overwrite changes they have not seen
+func calDAVPreconditionsMet(r *http.Request, object internalModels.CalDAVObject, exists bool) bool {
+	if match := r.Header.Get("If-Match"); match != "" {
+		if !exists || (match != "*" && match != object.ETag) {
+			return false
+		}
+	}
+	if noneMatch := r.Header.Get("If-None-Match"); noneMatch != "" && exists {
+		if noneMatch == "*" || noneMatch == object.ETag {
+			return false
+		}
+	}
+	return true
+}
+
+// A property of a resource, the value is XML that is written as is
+type davProperty struct {
+	Name  xml.Name
+	Value string
+}
+
+// A resource in a multistatus response, a response with a status has no properties
+type davResponse struct {
+	Href   string
+	Status int
+	Props  []davProperty
+}
+
+type davAnyElement struct {
+	XMLName xml.Name
+}
+
+type davPropfind struct {
+	AllProp  *struct{} `xml:"DAV: allprop"`
+	PropName *struct{} `xml:"DAV: propname"`
+	Prop     struct {
+		Names []davAnyElement `xml:",any"`
+	} `xml:"DAV: prop"`
+}
+
+type calDAVCompFilter struct {
+	Name        string             `xml:"name,attr"`
+	CompFilters []calDAVCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// Checks if a filter on a VCALENDAR can match VTODO components
+func (f calDAVCompFilter) matchesTodos() bool {
+	if len(f.CompFilters) == 0 {
+		return true
+	}
+	for _, filter := range f.CompFilters {
+		if strings.EqualFold(filter.Name, internalModels.CalendarComponentTodo) {
+			return true
+		}
+	}
+	return false
+}
+
+type calDAVReport struct {
+	XMLName xml.Name
+	Prop    struct {
+		Names []davAnyElement `xml:",any"`
+	} `xml:"DAV: prop"`
+	Hrefs  []string `xml:"DAV: href"`
+	Filter struct {
+		CompFilter calDAVCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+// Gets the names of the properties asked for in a PROPFIND body, nil means all properties
+func requestedDAVProps(r *http.Request) ([]xml.Name, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCalDAVObjectSize))
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil, nil
+	}
+	propfind := davPropfind{}
+	if err := xml.Unmarshal(body, &propfind); err != nil {
+		return nil, err
+	}
+	if propfind.AllProp != nil || propfind.PropName != nil {
+		return nil, nil
+	}
+	return davPropNames(propfind.Prop.Names), nil
+}
+
+func davPropNames(elements []davAnyElement) []xml.Name {
+	if len(elements) == 0 {
+		return nil
+	}
+	names := make([]xml.Name, 0, len(elements))
+	for _, element := range elements {
+		names = append(names, element.XMLName)
+	}
+	return names
+}
+
+// Gets the privileges of the caller on a calendar, tasks are created and deleted with the permissions of
+// their role or as an owner of the task category
+func calDAVPrivileges(r *http.Request, access internalModels.TaskCategoryAccess) []string {
+	ctx := r.Context()
+	privileges := []string{"read", "write-content"}
+	if controllers.HasTaskCategoryPermission(access, controllers.HasPermission(ctx, internalModels.PermissionTaskManage), internalModels.TaskCategoryManage) {
+		privileges = append(privileges, "bind")
+	}
+	if controllers.HasTaskCategoryPermission(access, controllers.HasPermission(ctx, internalModels.PermissionTaskDelete), internalModels.TaskCategoryManage) {
+		privileges = append(privileges, "unbind")
+	}
+	return privileges
+}
+
+func calendarDAVResponse(taskCategory *models.TaskCategory, objects []internalModels.CalDAVObject, privileges []string) davResponse {
+	// The tag of a calendar changes whenever one of its objects is added, changed or removed
+	tag := sha256.New()
+	for _, object := range objects {
+		tag.Write([]byte(object.Name + object.ETag))
+	}
+	ctag := `"` + hex.EncodeToString(tag.Sum(nil)[:16]) + `"`
+
+	privilegeSet := ""
+	for _, privilege := range privileges {
+		privilegeSet += davElement(davNamespace, "privilege", davElement(davNamespace, privilege, ""))
+	}
+	reports := ""
+	for _, report := range []string{"calendar-query", "calendar-multiget"} {
+		reports += davElement(davNamespace, "supported-report", davElement(davNamespace, "report", davElement(calDAVNamespace, report, "")))
+	}
+
+	return davResponse{
+		Href: fmt.Sprintf("%s/%d/", calDAVRoot, taskCategory.ID),
+		Props: []davProperty{
+			{Name: xml.Name{Space: davNamespace, Local: "resourcetype"}, Value: davElement(davNamespace, "collection", "") + davElement(calDAVNamespace, "calendar", "")},
+			{Name: xml.Name{Space: davNamespace, Local: "displayname"}, Value: xmlEscape(taskCategory.Name)},
+			{Name: xml.Name{Space: davNamespace, Local: "current-user-principal"}, Value: davHref(calDAVRoot + "/")},
+			{Name: xml.Name{Space: davNamespace, Local: "current-user-privilege-set"}, Value: privilegeSet},
+			{Name: xml.Name{Space: davNamespace, Local: "supported-report-set"}, Value: reports},
+			{Name: xml.Name{Space: davNamespace, Local: "getetag"}, Value: xmlEscape(ctag)},
+			{Name: xml.Name{Space: calendarServerNamespace, Local: "getctag"}, Value: xmlEscape(ctag)},
+			{Name: xml.Name{Space: calDAVNamespace, Local: "supported-calendar-component-set"}, Value: `<c:comp name="VTODO"/>`},
+		},
+	}
+}
+
+func objectDAVResponse(taskCategoryID int, object internalModels.CalDAVObject) davResponse {
+	return davResponse{
+		Href: fmt.Sprintf("%s/%d/%s.ics", calDAVRoot, taskCategoryID, url.PathEscape(object.Name)),
+		Props: []davProperty{
+			{Name: xml.Name{Space: davNamespace, Local: "resourcetype"}, Value: ""},
+			{Name: xml.Name{Space: davNamespace, Local: "getetag"}, Value: xmlEscape(object.ETag)},
+			{Name: xml.Name{Space: davNamespace, Local: "getcontenttype"}, Value: "text/calendar; charset=utf-8; component=vtodo"},
+			{Name: xml.Name{Space: calDAVNamespace, Local: "calendar-data"}, Value: xmlEscape(object.Data)},
+		},
+	}
+}
+
+// Writes a 207 Multi-Status response with the requested properties of each resource, nil asks for all
+// properties except the calendar data, which is only sent when asked for
+func writeMultiStatus(w http.ResponseWriter, responses []davResponse, requested []xml.Name) {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, response := range responses {
+		b.WriteString("<d:response>" + davHref(response.Href))
+		if response.Status != 0 {
+			b.WriteString(davStatus(response.Status) + "</d:response>")
+			continue
+		}
+		found := ""
+		notFound := ""
+		if requested == nil {
+			for _, prop := range response.Props {
+				if prop.Name.Local != "calendar-data" {
+					found += davElement(prop.Name.Space, prop.Name.Local, prop.Value)
+				}
+			}
+		} else {
+			for _, name := range requested {
+				prop, ok := findDAVProperty(response.Props, name)
+				if ok {
+					found += davElement(name.Space, name.Local, prop.Value)
+				} else {
+					notFound += davElement(name.Space, name.Local, "")
+				}
+			}
+		}
+		if found != "" {
+			b.WriteString("<d:propstat><d:prop>" + found + "</d:prop>" + davStatus(http.StatusOK) + "</d:propstat>")
+		}
+		if notFound != "" {
+			b.WriteString("<d:propstat><d:prop>" + notFound + "</d:prop>" + davStatus(http.StatusNotFound) + "</d:propstat>")
+		}
+		b.WriteString("</d:response>")
+	}
+	b.WriteString("</d:multistatus>")
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write([]byte(b.String()))
+}
+
+func findDAVProperty(props []davProperty, name xml.Name) (davProperty, bool) {
+	for _, prop := range props {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+	return davProperty{}, false
+}
+
+// Writes an element, using the prefixes declared on the multistatus element for the known namespaces
+func davElement(space, local, inner string) string {
+	prefix, ok := davPrefixes[space]
+	if !ok {
+		if inner == "" {
+			return fmt.Sprintf(`<x:%s xmlns:x="%s"/>`, local, xmlEscape(space))
+		}
+		return fmt.Sprintf(`<x:%s xmlns:x="%s">%s</x:%s>`, local, xmlEscape(space), inner, local)
+	}
+	if inner == "" {
+		return "<" + prefix + ":" + local + "/>"
+	}
+	return "<" + prefix + ":" + local + ">" + inner + "</" + prefix + ":" + local + ">"
+}
+
+func davHref(href string) string {
+	return davElement(davNamespace, "href", xmlEscape(href))
+}
+
+func davStatus(statusCode int) string {
+	return davElement(davNamespace, "status", fmt.Sprintf("HTTP/1.1 %d %s", statusCode, http.StatusText(statusCode)))
+}
+
+func xmlEscape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+type CalendarFeedHandler struct {
+	CalendarFeedController *controllers.CalendarFeedController
+	UserController         *controllers.UserController
+}
+
+func NewCalendarFeedHandler(database *repositories.Database, roleController *controllers.RoleController) *CalendarFeedHandler {
+	calendarFeedRepository := repositories.NewCalendarFeedRepository(database)
+	userTaskDetailRepository := repositories.NewUserTaskDetailRepository(database)
+	taskCategoryRepository := repositories.NewTaskCategoryRepository(database)
+	userRepository := repositories.NewUserRepository(database)
+	calendarFeedController := controllers.NewCalendarFeedController(calendarFeedRepository, userTaskDetailRepository, taskCategoryRepository, userRepository, roleController)
+	userController := controllers.NewUserController(userRepository)
+	return &CalendarFeedHandler{CalendarFeedController: calendarFeedController, UserController: userController}
+}
+
+func (h *CalendarFeedHandler) calendarFeeds(router chi.Router) {
+	router.Get("/", h.getCalendarFeeds)
+	router.Post("/", h.addCalendarFeed)
+	router.Delete("/{feedID}", h.revokeCalendarFeed)
+}
+
+func (h *CalendarFeedHandler) validateFeedIDFromURLParam(r *http.Request) (int, error) {
+	feedID := chi.URLParam(r, "feedID")
+	if feedID == "" {
+		return 0, errors.New("feed ID is required")
+	}
+	feedID = strings.TrimLeft(feedID, "0")
+	feedID = strings.Trim(feedID, " ")
+	id, err := strconv.Atoi(feedID)
+	if err != nil {
+		return 0, errors.New("cannot convert feed ID from string to int, invalid feed ID")
+	}
+	// Check if the feed ID only contains digits
+	if !regexp.MustCompile("^[0-9]+$").MatchString(feedID) {
+		return 0, errors.New("invalid feed ID")
+	}
+	return id, nil
+}
+
+func (h *CalendarFeedHandler) getCalendarFeeds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	feeds, err := h.CalendarFeedController.GetCalendarFeeds(user.ID, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, feeds)
+}
+
+func (h *CalendarFeedHandler) addCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	// The body is optional, a feed without a task category serves the tasks assigned to the caller
+	feedData := struct {
+		TaskCategoryID null.Int `json:"task_category_id"`
+	}{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&feedData); err != nil {
+			render.Render(w, r, ErrorRenderer(err))
+			return
+		}
+	}
+	isManager := controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll)
+	feed, err := h.CalendarFeedController.CreateCalendarFeed(user.ID, feedData.TaskCategoryID, isManager, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	feed.URL = fmt.Sprintf("%s://%s/calendar/%s.ics", scheme, r.Host, feed.Token)
+	utils.RenderJsonStatus(w, http.StatusCreated, feed)
+}
+
+func (h *CalendarFeedHandler) revokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	feedID, err := h.validateFeedIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if err := h.CalendarFeedController.RevokeCalendarFeed(feedID, user.ID, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
+}
+
+// Serves a feed as an iCalendar file. The token in the URL is the only credential,
+// because calendar clients cannot send the JWT cookie.
+func (h *CalendarFeedHandler) getCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	component := internalModels.CalendarComponentTodo
+	switch strings.ToLower(r.URL.Query().Get("component")) {
+	case "", "vtodo":
+	case "vevent":
+		component = internalModels.CalendarComponentEvent
+	default:
+		render.Render(w, r, ErrorRenderer(errors.New("component must be vtodo or vevent")))
+		return
+	}
+
+	feed, tasks, err := h.CalendarFeedController.GetCalendarFeedTasks(chi.URLParam(r, "token"), ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	name := "Assigned tasks"
+	if feed.TaskCategoryID.Valid {
+		name = fmt.Sprintf("Tasks of category %d", feed.TaskCategoryID.Int)
+	}
+	body := controllers.RenderTaskCalendar(name, tasks, component)
+
+	sum := sha256.Sum256([]byte(body))
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	// HTTP dates have a precision of one second
+	lastModified := controllers.CalendarLastModified(feed, tasks).UTC().Truncate(time.Second)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	// The ETag also changes when a task is unassigned, so it is preferred over the date
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		if match == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	} else if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !lastModified.After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Write([]byte(body))
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+func (h *TaskHandler) checklist(router chi.Router) {
+	router.Get("/", h.getChecklistItems)
+	router.Post("/", h.addChecklistItem)
+	router.Put("/reorder", h.reorderChecklistItems)
+	router.Route("/{itemID}", func(router chi.Router) {
+		router.Patch("/toggle", h.toggleChecklistItem)
+		router.Delete("/", h.deleteChecklistItem)
+	})
+}
+
+func (h *TaskHandler) validateChecklistItemIDFromURLParam(r *http.Request) (int, error) {
+	itemID := chi.URLParam(r, "itemID")
+	if itemID == "" {
+		return 0, errors.New("checklist item ID is required")
+	}
+	itemID = strings.TrimLeft(itemID, "0")
+	itemID = strings.Trim(itemID, " ")
+	id, err := strconv.Atoi(itemID)
+	if err != nil {
+		return 0, errors.New("cannot convert checklist item ID from string to int, invalid checklist item ID")
+	}
+	// Check if the checklist item ID only contains digits
+	if !regexp.MustCompile("^[0-9]+$").MatchString(itemID) {
+		return 0, errors.New("invalid checklist item ID")
+	}
+	return id, nil
+}
+
+func (h *TaskHandler) getChecklistItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID, err := h.validateTaskIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	items, err := h.ChecklistController.GetChecklistItems(taskID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, items)
+}
+
+func (h *TaskHandler) addChecklistItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID, err := h.validateTaskIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	item := models.ChecklistItem{}
+	if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	item.TaskID = taskID
+	if err := h.ChecklistController.AddChecklistItem(&item, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJsonStatus(w, http.StatusCreated, item)
+}
+
+func (h *TaskHandler) toggleChecklistItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID, err := h.validateTaskIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	itemID, err := h.validateChecklistItemIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	item, err := h.ChecklistController.ToggleChecklistItem(taskID, itemID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, item)
+}
+
+func (h *TaskHandler) reorderChecklistItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID, err := h.validateTaskIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	reorder := models.ChecklistReorder{}
+	if err := json.NewDecoder(r.Body).Decode(&reorder); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	items, err := h.ChecklistController.ReorderChecklistItems(taskID, reorder.ItemIDs, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, items)
+}
+
+func (h *TaskHandler) deleteChecklistItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID, err := h.validateTaskIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	itemID, err := h.validateChecklistItemIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if err := h.ChecklistController.DeleteChecklistItem(taskID, itemID, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+// Verifies the email of a user with the token of a verification link
+func (h *UserHandler) verifyEmail(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		return
+	}
+	token := r.PostForm.Get("token")
+	if token == "" {
+		render.Render(w, r, ErrorRenderer(controllers.ErrInvalidVerificationToken))
+		return
+	}
+	if _, err := h.EmailVerificationController.VerifyEmail(token, time.Now(), r.Context()); err != nil {
+		if err == controllers.ErrInvalidVerificationToken {
+			render.Render(w, r, ErrorRenderer(err))
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, success{Status: "success"})
+}
+
+// Emails a new verification link. The response is the same whether the email has an unverified account or
+// not, so failures, including too many links asked for the address, are only logged.
+func (h *UserHandler) resendVerification(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		return
+	}
+	email := r.PostForm.Get("email")
+	if !h.isValidEmail(email) {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("your email is not valid, please provide a valid email")))
+		return
+	}
+	if err := h.EmailVerificationController.ResendVerification(email, time.Now(), r.Context()); err != nil {
+		log.Printf("Could not send an email verification link: %v\n", err)
+	}
+	utils.RenderJsonStatus(w, http.StatusAccepted, success{Status: "if the email has an unverified account, a verification link was sent to it"})
+}
+
+// Lists the users who have not verified their email
+func (h *UserHandler) getPendingUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.EmailVerificationController.GetPendingUsers(r.Context())
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, users)
+}
+
+// Verifies the email of a user without a link
+func (h *UserHandler) verifyUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := h.validateUserIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrBadRequest)
+		return
+	}
+	if err := h.EmailVerificationController.VerifyUser(userID, r.Context()); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, success{Status: "success"})
+}
@@ -31,6 +31,38 @@ func ErrorRenderer(err error) *ErrorResponse {
 		Message:    err.Error(),
 	}
 }
+func UnauthorizedRenderer(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:        err,
+		StatusCode: 401,
+		StatusText: "Unauthorized",
+		Message:    err.Error(),
+	}
+}
+func ForbiddenRenderer(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:        err,
+		StatusCode: 403,
+		StatusText: "Forbidden",
+		Message:    err.Error(),
+	}
+}
+func ConflictRenderer(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:        err,
+		StatusCode: 409,
+		StatusText: "Conflict",
+		Message:    err.Error(),
+	}
+}
+func TooLargeRenderer(err error) *ErrorResponse {
+	return &ErrorResponse{
+		Err:        err,
+		StatusCode: 413,
+		StatusText: "Request entity too large",
+		Message:    err.Error(),
+	}
+}
 func ServerErrorRenderer(err error) *ErrorResponse {
 	return &ErrorResponse{
 		Err:        err,
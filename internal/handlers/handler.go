@@ -1,54 +1,63 @@
 package handlers
 
 import (
-	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
-	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/jwtauth/v5"
 )
 
-var tokenAuth *jwtauth.JWTAuth
-
-const Secret = "<my-secret-key-1010>"
-
-func init() {
-	tokenAuth = jwtauth.New("HS256", []byte(Secret), nil)
-}
-
-var ctx = context.Background()
-
-func NewHandler(db *repositories.Database) http.Handler {
+// Builds the router of the API. The token issuer, the session controller and the controllers of the password
+// resets and the email verification come from the configuration and are shared by the handlers using them,
+// the background runner sends the emails that must not delay the responses.
+func NewHandler(db *repositories.Database, tokenIssuer *controllers.TokenIssuer, userSessionController *controllers.UserSessionController, passwordResetController *controllers.PasswordResetController, emailVerificationController *controllers.EmailVerificationController) http.Handler {
+	// The authentication and the role endpoints share the cache of permissions of the role controller
+	roleController := controllers.NewRoleController(repositories.NewRoleRepository(db))
+	authController := controllers.NewAuthController(tokenIssuer, userSessionController, repositories.NewAPIKeyRepository(db), repositories.NewUserRepository(db), roleController)
 	r := chi.NewRouter()
 
 	r.Use(middleware.Logger)
+	r.Use(authenticate(authController))
 	r.MethodNotAllowed(methodNotAllowedHandler)
 	r.NotFound(notFoundHandler)
-	userHandler := NewUserHandler(db)
-	taskHandler := NewTaskHandler(db)
-	taskCategoryHandler := NewTaskCategoryHandler(db)
+	userHandler := NewUserHandler(db, tokenIssuer, userSessionController, authController, roleController, passwordResetController, emailVerificationController)
+	taskHandler := NewTaskHandler(db, emailVerificationController)
+	taskCategoryHandler := NewTaskCategoryHandler(db, tokenIssuer)
+	trashHandler := NewTrashHandler(db)
+	taskTemplateHandler := NewTaskTemplateHandler(db)
+	calendarFeedHandler := NewCalendarFeedHandler(db, roleController)
+	calDAVHandler := NewCalDAVHandler(db, authController, emailVerificationController)
+	jobHandler := NewJobHandler(db)
+	reportHandler := NewReportHandler(db)
+	roleHandler := NewRoleHandler(roleController)
 	// protected routes
 	r.Group(func(r chi.Router) {
-		/*
-
-			jwtauth.Verifier automatically searches for a JWT token in an incoming request:
-			- The jwt URI query parameter
-				- The Authorization: Bearer <token> request header
-				- The jwt cookie
-
-		*/
-
-		r.Use(jwtauth.Verifier(tokenAuth))
-		// send 401 Unauthorized response for any unverified
-		r.Use(jwtauth.Authenticator)
+		// send 401 Unauthorized response for any request without a principal
+		r.Use(requireUser)
 		r.Route("/users", userHandler.users)
 		r.Route("/task-categories", taskCategoryHandler.taskCategories)
 		r.Route("/tasks", taskHandler.tasks)
+		r.Route("/trash", trashHandler.trash)
+		r.Route("/templates", taskTemplateHandler.taskTemplates)
+		r.Route("/calendar/feeds", calendarFeedHandler.calendarFeeds)
+		r.Route("/jobs", jobHandler.jobs)
+		r.Route("/reports", reportHandler.reports)
+		r.Route("/roles", roleHandler.roles)
+		r.Get("/dashboard", taskHandler.getDashboard)
 	})
 
 	// public routes
@@ -56,7 +65,18 @@ func NewHandler(db *repositories.Database) http.Handler {
 		r.Get("/", welcome)
 		r.Post("/signup", userHandler.signup)
 		r.Post("/login", userHandler.login)
+		r.Post("/refresh", userHandler.refresh)
 		r.Post("/logout", userHandler.logout)
+		r.Post("/password/forgot", userHandler.forgotPassword)
+		r.Post("/password/reset", userHandler.resetPassword)
+		r.Post("/verify-email", userHandler.verifyEmail)
+		r.Post("/verify-email/resend", userHandler.resendVerification)
+		// calendar clients cannot send the JWT, the secret token in the URL authenticates the feed
+		r.Get("/calendar/{token}.ics", calendarFeedHandler.getCalendarFeed)
+		// CalDAV clients log in with basic auth instead of the JWT
+		r.Route(calDAVRoot, calDAVHandler.calDAV)
+		r.Handle("/.well-known/caldav", http.HandlerFunc(calDAVWellKnown))
+		r.Get("/.well-known/jwks.json", jwks(tokenIssuer))
 	})
 	return r
 }
@@ -71,20 +91,153 @@ func notFoundHandler(w http.ResponseWriter, r *http.Request) {
 	render.Render(w, r, ErrNotFound)
 }
 
-func MakeToken(email, password string) string {
-	_, tokenString, _ := tokenAuth.Encode(map[string]interface{}{
-		"email":    email,
-		"password": password,
+// Resolves the principal of a request once, from its X-API-Key header, its Authorization: Bearer header or
+// its jwt cookie, and stores it in the context with the error of invalid credentials. Requests without
+// credentials carry no principal, requireUser rejects them on the protected routes.
+func authenticate(authController *controllers.AuthController) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authController.Authenticate(r, time.Now(), r.Context())
+			if err == jwtauth.ErrNoTokenFound {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(controllers.WithAuthentication(r.Context(), principal, err)))
+		})
+	}
+}
+
+// Rejects the requests without a principal
+func requireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := controllers.CurrentPrincipal(r.Context()); err != nil {
+			render.Render(w, r, UnauthorizedRenderer(err))
+			return
+		}
+		next.ServeHTTP(w, r)
 	})
-	return tokenString
 }
 
-func GetToken(r *http.Request, tokenAuth *jwtauth.JWTAuth) jwt.Token {
-	token, err := tokenAuth.Decode(jwtauth.TokenFromCookie(r))
+// Rejects the requests of users whose role does not hold a permission
+func RequirePermission(permission internalModels.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := controllers.RequirePermission(r.Context(), permission); err != nil {
+				if err == controllers.ErrForbidden {
+					render.Render(w, r, ForbiddenRenderer(err))
+				} else {
+					render.Render(w, r, UnauthorizedRenderer(err))
+				}
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Checks that the caller has a permission on a task category, with their role in the category or with a
+// permission of their role over every category, and returns the caller
+func authorizeTaskCategory(r *http.Request, taskCategoryController *controllers.TaskCategoryController, taskCategoryID int, permission internalModels.TaskCategoryPermission, override internalModels.Permission) (*models.User, error) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if err := taskCategoryController.CheckTaskCategoryPermission(taskCategoryID, user.ID, controllers.HasPermission(ctx, override), permission, ctx); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func renderAccessError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == repositories.ErrNoMatch {
+		render.Render(w, r, ErrNotFound)
+	} else {
+		render.Render(w, r, ErrorRenderer(err))
+	}
+}
+
+// Maximum size of an uploaded CSV file kept in memory, larger files are stored in temporary files
+const maxImportMemory = 10 << 20
+
+// Maximum size of an import request, the file of an async import is stored in the payload of its job
+const maxImportSize = 20 << 20
+
+var errImportTooLarge = fmt.Errorf("the import request must not be larger than %d MB", maxImportSize>>20)
+
+// Reads the file and the options of a CSV import from a multipart form. The mapping is a JSON object
+// from field names to headers, the other options can also be given in the query. The file must be closed.
+// Requests larger than maxImportSize return errImportTooLarge.
+func parseImportRequest(w http.ResponseWriter, r *http.Request) (multipart.File, internalModels.ImportOptions, error) {
+	options := internalModels.ImportOptions{}
+	if r.ContentLength > maxImportSize {
+		return nil, options, errImportTooLarge
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, options, errImportTooLarge
+		}
+		return nil, options, errors.New("the request must be a multipart form with a file")
+	}
+	if mapping := r.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &options.Mapping); err != nil {
+			return nil, options, errors.New("the mapping must be a JSON object from field names to headers")
+		}
+	}
+	for name, option := range map[string]*bool{"dry_run": &options.DryRun, "archive_missing": &options.ArchiveMissing} {
+		parsed, err := formBool(r, name)
+		if err != nil {
+			return nil, options, err
+		}
+		*option = parsed
+	}
+	options.Mode = r.FormValue("mode")
+	options.ExternalSource = r.FormValue("external_source")
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return nil, options, errors.New("the CSV file is required in the file field")
+	}
+	return file, options, nil
+}
+
+// Reads a boolean from the form or the query, false when it is not given
+func formBool(r *http.Request, name string) (bool, error) {
+	value := r.FormValue(name)
+	if value == "" {
+		return false, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return parsed, nil
+}
+
+// Responds with the error of an import request, with 413 when the request is too large
+func renderImportRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == errImportTooLarge {
+		render.Render(w, r, TooLargeRenderer(err))
+	} else {
+		render.Render(w, r, ErrorRenderer(err))
+	}
+}
+
+// Responds with the report of an import, a report with errors is sent with 422 since nothing was written
+func renderImportReport(w http.ResponseWriter, report internalModels.ImportReport) {
+	if len(report.Errors) > 0 {
+		utils.RenderJsonStatus(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+	utils.RenderJson(w, report)
+}
+
+// Publishes the public keys verifying the tokens, so other services can verify them
+func jwks(tokenIssuer *controllers.TokenIssuer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		utils.RenderJson(w, tokenIssuer.PublicKeys())
 	}
-	return token
 }
 
 func welcome(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
+)
+
+type JobHandler struct {
+	JobController  *controllers.JobController
+	UserController *controllers.UserController
+}
+
+func NewJobHandler(database *repositories.Database) *JobHandler {
+	jobController := controllers.NewJobController(repositories.NewJobRepository(database), NewJobRegistry(database))
+	userController := controllers.NewUserController(repositories.NewUserRepository(database))
+	return &JobHandler{JobController: jobController, UserController: userController}
+}
+
+// Builds the registry of the job types of the application. The HTTP server uses it to add jobs
+// and the workers use it to run them.
+func NewJobRegistry(database *repositories.Database) *controllers.JobRegistry {
+	registry := controllers.NewJobRegistry()
+	csvImportController := controllers.NewCSVImportController(
+		repositories.NewTaskRepository(database),
+		repositories.NewTaskCategoryRepository(database),
+		repositories.NewUserRepository(database),
+	)
+	csvImportController.RegisterJobs(registry)
+	taskController := controllers.NewTaskController(repositories.NewTaskRepository(database), repositories.NewChecklistRepository(database))
+	jobController := controllers.NewJobController(repositories.NewJobRepository(database), registry)
+	taskController.RegisterJobs(registry, jobController)
+	trashController := controllers.NewTrashController(
+		repositories.NewTaskRepository(database),
+		repositories.NewTaskCategoryRepository(database),
+		repositories.NewUserRepository(database),
+	)
+	trashController.RegisterJobs(registry, jobController)
+	controllers.NewSLAController(repositories.NewTaskRepository(database)).RegisterJobs(registry, jobController)
+	return registry
+}
+
+func (h *JobHandler) jobs(router chi.Router) {
+	router.With(RequirePermission(internalModels.PermissionJobManage)).Get("/", h.getJobs)
+	router.Route("/{jobID}", func(router chi.Router) {
+		router.Get("/", h.getJob)
+		router.With(RequirePermission(internalModels.PermissionJobManage)).Post("/retry", h.retryJob)
+	})
+}
+
+func (h *JobHandler) validateJobIDFromURLParam(r *http.Request) (int, error) {
+	jobID := chi.URLParam(r, "jobID")
+	if jobID == "" {
+		return 0, errors.New("job ID is required")
+	}
+	jobID = strings.TrimLeft(jobID, "0")
+	jobID = strings.Trim(jobID, " ")
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return 0, errors.New("cannot convert job ID from string to int, invalid job ID")
+	}
+	// Check if the job ID only contains digits
+	if !regexp.MustCompile("^[0-9]+$").MatchString(jobID) {
+		return 0, errors.New("invalid job ID")
+	}
+	return id, nil
+}
+
+// Lists the latest jobs, dead jobs are listed with ?status=dead. Only managers can list jobs.
+func (h *JobHandler) getJobs(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobs, err := h.JobController.GetJobs(r.URL.Query().Get("status"), ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, jobs)
+}
+
+// Gets the status, progress and result of a job
+func (h *JobHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobID, err := h.validateJobIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	isManager := controllers.HasPermission(ctx, internalModels.PermissionJobManage)
+	job, err := h.JobController.GetJob(jobID, user.ID, isManager, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, job)
+}
+
+// Queues a dead job again, only managers can retry jobs
+func (h *JobHandler) retryJob(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	jobID, err := h.validateJobIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	job, err := h.JobController.RetryDeadJob(jobID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, job)
+}
+
+// Adds a job running an import of the uploaded file and responds with the job, its status
+// and result are then read from /jobs/{jobID}
+func enqueueImport(w http.ResponseWriter, r *http.Request, jobController *controllers.JobController, jobType string, file io.Reader, options internalModels.ImportOptions, importerID int) {
+	ctx := r.Context()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	job, err := jobController.EnqueueJob(internalModels.NewJob{
+		Type:      jobType,
+		Payload:   internalModels.ImportJob{Data: string(data), Options: options, ImporterID: importerID},
+		CreatedBy: null.IntFrom(importerID),
+	}, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	w.Header().Set("Location", "/jobs/"+strconv.Itoa(job.ID))
+	utils.RenderJsonStatus(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+func (h *UserHandler) notifications(router chi.Router) {
+	router.Get("/", h.getMyNotifications)
+	router.Post("/read", h.markAllMyNotificationsRead)
+	router.Post("/{notificationID}/read", h.markMyNotificationRead)
+}
+
+// Lists the notifications of the logged in user, newest first, only the unread ones with unread=true
+func (h *UserHandler) getMyNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	unreadOnly, err := formBool(r, "unread")
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	notifications, err := h.NotificationController.GetNotificationsOfUser(user.ID, unreadOnly, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, notifications)
+}
+
+func (h *UserHandler) markMyNotificationRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	notificationID, err := strconv.Atoi(chi.URLParam(r, "notificationID"))
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(errors.New("invalid notification ID")))
+		return
+	}
+	// The notifications of other users are not found
+	notification, err := h.NotificationController.MarkNotificationRead(user.ID, notificationID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, notification)
+}
+
+func (h *UserHandler) markAllMyNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := h.NotificationController.MarkAllNotificationsRead(user.ID, ctx); err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
+}
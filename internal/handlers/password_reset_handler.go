@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+// Emails a password reset link. The response is the same whether the email has an account or not, so
+// failures, including too many links asked for the address, are only logged.
+func (h *UserHandler) forgotPassword(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		return
+	}
+	email := r.PostForm.Get("email")
+	if !h.isValidEmail(email) {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("your email is not valid, please provide a valid email")))
+		return
+	}
+	if err := h.PasswordResetController.RequestPasswordReset(email, time.Now(), r.Context()); err != nil {
+		log.Printf("Could not send a password reset link: %v\n", err)
+	}
+	utils.RenderJsonStatus(w, http.StatusAccepted, success{Status: "if the email has an account, a password reset link was sent to it"})
+}
+
+// Sets a new password with the token of a reset link, every session of the user has to log in again
+func (h *UserHandler) resetPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		return
+	}
+	token := r.PostForm.Get("token")
+	password := r.PostForm.Get("password")
+	if token == "" {
+		render.Render(w, r, ErrorRenderer(controllers.ErrInvalidPasswordResetToken))
+		return
+	}
+	if !h.isValidPassword(password) {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("your password is not valid, please provide a password that contains at least 6 characters")))
+		return
+	}
+	if _, err := h.PasswordResetController.ResetPassword(token, password, ctx); err != nil {
+		if err == controllers.ErrInvalidPasswordResetToken {
+			render.Render(w, r, ErrorRenderer(err))
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, success{Status: "success"})
+}
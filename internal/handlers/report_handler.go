@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+// Days covered by a report without from, a burndown and a cumulative flow cover a month
+// and a throughput covers twelve weeks
+const (
+	defaultDailyReportDays  = 30
+	defaultWeeklyReportDays = 12 * 7
+)
+
+type ReportHandler struct {
+	ReportController *controllers.ReportController
+	UserController   *controllers.UserController
+}
+
+func NewReportHandler(database *repositories.Database) *ReportHandler {
+	reportController := controllers.NewReportController(repositories.NewReportRepository(database), repositories.NewTaskCategoryRepository(database))
+	userController := controllers.NewUserController(repositories.NewUserRepository(database))
+	return &ReportHandler{ReportController: reportController, UserController: userController}
+}
+
+func (h *ReportHandler) reports(router chi.Router) {
+	router.Use(RequirePermission(internalModels.PermissionReportView))
+	router.Get("/burndown", h.getBurndown)
+	router.Get("/throughput", h.getThroughput)
+	router.Get("/cumulative-flow", h.getCumulativeFlow)
+}
+
+// Reads the range of a report from the from, to and task_category_id query parameters
+func (h *ReportHandler) parseReportRange(r *http.Request, defaultDays int) (internalModels.ReportRange, error) {
+	query := r.URL.Query()
+	taskCategoryID := 0
+	if value := query.Get("task_category_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			return internalModels.ReportRange{}, errors.New("invalid task category ID")
+		}
+		taskCategoryID = id
+	}
+	return controllers.ParseReportRange(query.Get("from"), query.Get("to"), taskCategoryID, defaultDays, time.Now())
+}
+
+// Renders the error of a report, an unknown task category is not found
+func renderReportError(w http.ResponseWriter, r *http.Request, err error) {
+	if err == repositories.ErrNoMatch {
+		render.Render(w, r, ErrNotFound)
+	} else {
+		render.Render(w, r, ServerErrorRenderer(err))
+	}
+}
+
+// Gets the open tasks at the end of each day
+func (h *ReportHandler) getBurndown(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportRange, err := h.parseReportRange(r, defaultDailyReportDays)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	points, err := h.ReportController.GetBurndown(reportRange, ctx)
+	if err != nil {
+		renderReportError(w, r, err)
+		return
+	}
+	utils.RenderJson(w, points)
+}
+
+// Gets the tasks completed in each week
+func (h *ReportHandler) getThroughput(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportRange, err := h.parseReportRange(r, defaultWeeklyReportDays)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	points, err := h.ReportController.GetThroughput(reportRange, ctx)
+	if err != nil {
+		renderReportError(w, r, err)
+		return
+	}
+	utils.RenderJson(w, points)
+}
+
+// Gets the tasks in every status at the end of each day
+func (h *ReportHandler) getCumulativeFlow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	reportRange, err := h.parseReportRange(r, defaultDailyReportDays)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	points, err := h.ReportController.GetCumulativeFlow(reportRange, ctx)
+	if err != nil {
+		renderReportError(w, r, err)
+		return
+	}
+	utils.RenderJson(w, points)
+}
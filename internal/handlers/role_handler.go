@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+type RoleHandler struct {
+	RoleController *controllers.RoleController
+}
+
+func NewRoleHandler(roleController *controllers.RoleController) *RoleHandler {
+	return &RoleHandler{RoleController: roleController}
+}
+
+func (h *RoleHandler) roles(router chi.Router) {
+	router.Get("/", h.getRoles)
+	router.With(RequirePermission(internalModels.PermissionRoleManage)).Post("/", h.addRole)
+	router.Route("/{roleName}", func(router chi.Router) {
+		router.Get("/", h.getRole)
+		router.With(RequirePermission(internalModels.PermissionRoleManage)).Put("/", h.updateRole)
+		router.With(RequirePermission(internalModels.PermissionRoleManage)).Delete("/", h.deleteRole)
+	})
+}
+
+func (h *RoleHandler) getRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.RoleController.GetRoles(r.Context())
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, roles)
+}
+
+func (h *RoleHandler) getRole(w http.ResponseWriter, r *http.Request) {
+	role, err := h.RoleController.GetRole(chi.URLParam(r, "roleName"), r.Context())
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, role)
+}
+
+func (h *RoleHandler) addRole(w http.ResponseWriter, r *http.Request) {
+	role := internalModels.Role{}
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	role, err := h.RoleController.AddRole(role, r.Context())
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	utils.RenderJsonStatus(w, http.StatusCreated, role)
+}
+
+// Changes the description and the permissions of a custom role, the users holding it are affected at once
+func (h *RoleHandler) updateRole(w http.ResponseWriter, r *http.Request) {
+	role := internalModels.Role{}
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	role, err := h.RoleController.UpdateRole(chi.URLParam(r, "roleName"), role, r.Context())
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, role)
+}
+
+func (h *RoleHandler) deleteRole(w http.ResponseWriter, r *http.Request) {
+	if err := h.RoleController.DeleteRole(chi.URLParam(r, "roleName"), r.Context()); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, success{Status: "success"})
+}
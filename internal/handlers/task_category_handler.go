@@ -3,43 +3,60 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io/ioutil"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
 	"github.com/qthuy2k1/task-management-app/internal/utils"
+	"github.com/volatiletech/null/v8"
 )
 
 type TaskCategoryHandler struct {
 	TaskCategoryController *controllers.TaskCategoryController
 	UserController         *controllers.UserController
+	CSVImportController    *controllers.CSVImportController
+	JobController          *controllers.JobController
+	// Signs the confirmations of the cascade deletions
+	TokenIssuer *controllers.TokenIssuer
 }
 
-func NewTaskCategoryHandler(database *repositories.Database) *TaskCategoryHandler {
+func NewTaskCategoryHandler(database *repositories.Database, tokenIssuer *controllers.TokenIssuer) *TaskCategoryHandler {
 	taskCategoryRepository := repositories.NewTaskCategoryRepository(database)
 	taskCategoryController := controllers.NewTaskCategoryController(taskCategoryRepository)
 	userRepository := repositories.NewUserRepository(database)
 	userController := controllers.NewUserController(userRepository)
-	return &TaskCategoryHandler{TaskCategoryController: taskCategoryController, UserController: userController}
+	csvImportController := controllers.NewCSVImportController(repositories.NewTaskRepository(database), taskCategoryRepository, userRepository)
+	jobController := controllers.NewJobController(repositories.NewJobRepository(database), NewJobRegistry(database))
+	return &TaskCategoryHandler{TaskCategoryController: taskCategoryController, UserController: userController, CSVImportController: csvImportController, JobController: jobController, TokenIssuer: tokenIssuer}
 }
 
 func (h *TaskCategoryHandler) taskCategories(router chi.Router) {
 	router.Get("/", h.getAllTaskCategories)
 	router.Post("/", h.addTaskCategory)
-	router.Post("/csv", h.importTaskCategoryCSV)
+	router.With(RequirePermission(internalModels.PermissionCategoryManage)).Post("/csv", h.importTaskCategoryCSV)
 	router.Route("/{taskCategoryID}", func(router chi.Router) {
 		router.Get("/", h.getTaskCategory)
 		router.Put("/", h.updateTaskCategory)
 		router.Delete("/", h.deleteTaskCategory)
 		router.Get("/get-tasks", h.getTasksByCategory)
+		router.Get("/settings", h.getTaskCategorySettings)
+		router.Put("/settings", h.updateTaskCategorySettings)
+		router.Post("/move", h.moveTaskCategory)
+		router.Post("/merge", h.mergeTaskCategory)
+		router.Route("/members", func(router chi.Router) {
+			router.Get("/", h.getTaskCategoryMembers)
+			router.Put("/{userID}", h.setTaskCategoryMember)
+			router.Delete("/{userID}", h.removeTaskCategoryMember)
+		})
 	})
 }
 func (h *TaskCategoryHandler) validateTaskCategoryIDFromURLParam(r *http.Request) (int, error) {
@@ -71,6 +88,7 @@ func (h *TaskCategoryHandler) validateTaskCategoryIDFromURLParam(r *http.Request
 }
 
 func (h *TaskCategoryHandler) addTaskCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskCategory := &models.TaskCategory{}
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -81,21 +99,32 @@ func (h *TaskCategoryHandler) addTaskCategory(w http.ResponseWriter, r *http.Req
 	err = json.Unmarshal(body, &taskCategory)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	// The trash columns are only written by the server, the settings and the visibility have their own endpoints
+	taskCategory = &models.TaskCategory{
+		Name:           taskCategory.Name,
+		ParentID:       taskCategory.ParentID,
+		ExternalSource: taskCategory.ExternalSource,
+		ExternalID:     taskCategory.ExternalID,
 	}
 	if taskCategory.Name == "" {
 		render.Render(w, r, ErrBadRequest)
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
-		return
-	}
 
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
-		render.Render(w, r, ErrorRenderer(err))
-		return
+	// The owners of a category can add subcategories to it
+	if taskCategory.ParentID.Valid {
+		if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategory.ParentID.Int, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+			renderAccessError(w, r, err)
+			return
+		}
+	} else {
+		err = controllers.RequirePermission(ctx, internalModels.PermissionCategoryManage)
+		if err != nil {
+			render.Render(w, r, ErrorRenderer(err))
+			return
+		}
 	}
 
 	if err := h.TaskCategoryController.AddTaskCategory(taskCategory, ctx); err != nil {
@@ -105,14 +134,29 @@ func (h *TaskCategoryHandler) addTaskCategory(w http.ResponseWriter, r *http.Req
 	utils.RenderJson(w, taskCategory)
 }
 
+// Lists the task categories the caller can see
 func (h *TaskCategoryHandler) getAllTaskCategories(w http.ResponseWriter, r *http.Request) {
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
+	isManager := controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll)
 
-	taskCategories, err := h.TaskCategoryController.GetAllTaskCategories(ctx)
+	// ?tree=true lists the categories as a tree
+	tree, err := strconv.ParseBool(r.URL.Query().Get("tree"))
+	if err == nil && tree {
+		taskCategoryTree, err := h.TaskCategoryController.GetTaskCategoryTree(user.ID, isManager, ctx)
+		if err != nil {
+			render.Render(w, r, ServerErrorRenderer(err))
+			return
+		}
+		utils.RenderJson(w, taskCategoryTree)
+		return
+	}
+
+	taskCategories, err := h.TaskCategoryController.GetVisibleTaskCategories(user.ID, isManager, ctx)
 	if err != nil {
 		render.Render(w, r, ServerErrorRenderer(err))
 		return
@@ -121,13 +165,14 @@ func (h *TaskCategoryHandler) getAllTaskCategories(w http.ResponseWriter, r *htt
 }
 
 func (h *TaskCategoryHandler) getTaskCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
 	taskCategory, err := h.TaskCategoryController.GetTaskCategoryByID(taskCategoryID, ctx)
@@ -142,48 +187,142 @@ func (h *TaskCategoryHandler) getTaskCategory(w http.ResponseWriter, r *http.Req
 	utils.RenderJson(w, taskCategory)
 }
 
+// Moves a task category to the trash, only managers and owners can delete task categories. The tasks of the category
+// are moved to the category ?reassign_to, or moved to the trash with ?cascade=true. A cascade deletion
+// is refused with a confirmation token, and the request is sent again with ?confirm=<token>. The token
+// only confirms the tasks the category had when it was issued.
+// A category with tasks and without policy is refused with the number of its tasks.
 func (h *TaskCategoryHandler) deleteTaskCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	user, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage)
+	if err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
+	policy, err := h.parseTaskCategoryDeletePolicy(r, taskCategoryID, user.ID)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	err = h.TaskCategoryController.DeleteTaskCategory(taskCategoryID, ctx)
+	// The tasks can only be moved to a category the caller manages
+	if policy.ReassignTo.Valid && policy.ReassignTo.Int != taskCategoryID {
+		if _, err := authorizeTaskCategory(r, h.TaskCategoryController, policy.ReassignTo.Int, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+			if err == repositories.ErrNoMatch {
+				err = repositories.ErrReassignTargetNotFound
+			}
+			render.Render(w, r, ErrorRenderer(err))
+			return
+		}
+	}
+	result, err := h.TaskCategoryController.DeleteTaskCategory(taskCategoryID, user.ID, policy, ctx)
 	if err != nil {
-		if err == repositories.ErrNoMatch {
+		var hasTasks *repositories.TaskCategoryHasTasksError
+		if errors.As(err, &hasTasks) {
+			conflict := internalModels.TaskCategoryDeleteConflict{
+				StatusText:    "Conflict",
+				Message:       err.Error(),
+				AffectedTasks: hasTasks.Tasks,
+			}
+			if policy.Cascade {
+				conflict.ConfirmationToken, err = h.makeDeleteConfirmationToken(taskCategoryID, user.ID, hasTasks.Digest)
+				if err != nil {
+					render.Render(w, r, ServerErrorRenderer(err))
+					return
+				}
+			}
+			utils.RenderJsonStatus(w, http.StatusConflict, conflict)
+		} else if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
+		} else if err == repositories.ErrReassignTargetNotFound {
+			render.Render(w, r, ErrorRenderer(err))
 		} else {
 			render.Render(w, r, ServerErrorRenderer(err))
 		}
 		return
 	}
-	s := success{
-		Status: "success",
+	result.Status = "success"
+	utils.RenderJson(w, result)
+}
+
+// How long a confirmation of a cascade deletion can be used
+const deleteConfirmationTTL = 10 * time.Minute
+
+// Audience of the confirmation tokens, so that no other token signed by the server is accepted
+const deleteConfirmationAudience = "delete_task_category"
+
+// Reads the deletion policy of a task category from the query
+func (h *TaskCategoryHandler) parseTaskCategoryDeletePolicy(r *http.Request, taskCategoryID, userID int) (internalModels.TaskCategoryDeletePolicy, error) {
+	policy := internalModels.TaskCategoryDeletePolicy{}
+	query := r.URL.Query()
+	if reassignTo := query.Get("reassign_to"); reassignTo != "" {
+		id, err := strconv.Atoi(reassignTo)
+		if err != nil {
+			return policy, errors.New("reassign_to must be a task category ID")
+		}
+		policy.ReassignTo = null.IntFrom(id)
 	}
-	utils.RenderJson(w, s)
+	if cascade := query.Get("cascade"); cascade != "" {
+		value, err := strconv.ParseBool(cascade)
+		if err != nil {
+			return policy, errors.New("cascade must be true or false")
+		}
+		policy.Cascade = value
+	}
+	if confirm := query.Get("confirm"); confirm != "" {
+		if !policy.Cascade {
+			return policy, errors.New("confirm is only used with cascade=true")
+		}
+		digest, err := h.parseDeleteConfirmationToken(confirm, taskCategoryID, userID)
+		if err != nil {
+			return policy, err
+		}
+		policy.ConfirmedTasksDigest = digest
+	}
+	return policy, nil
 }
+
+// Makes a token confirming the deletion of the tasks with the given digest with their category, by the given user
+func (h *TaskCategoryHandler) makeDeleteConfirmationToken(taskCategoryID, userID int, tasksDigest string) (string, error) {
+	return h.TokenIssuer.Sign(map[string]interface{}{
+		"task_category_id": taskCategoryID,
+		"user_id":          userID,
+		"tasks_digest":     tasksDigest,
+	}, deleteConfirmationAudience, deleteConfirmationTTL, time.Now())
+}
+
+// Reads the digest of the confirmed tasks from a confirmation token of the deletion of a task category
+func (h *TaskCategoryHandler) parseDeleteConfirmationToken(tokenString string, taskCategoryID, userID int) (string, error) {
+	errInvalid := errors.New("invalid or expired confirmation token")
+	token, err := h.TokenIssuer.Parse(tokenString, deleteConfirmationAudience, time.Now())
+	if err != nil {
+		return "", errInvalid
+	}
+	claims := token.PrivateClaims()
+	if claims["task_category_id"] != float64(taskCategoryID) ||
+		claims["user_id"] != float64(userID) {
+		return "", errInvalid
+	}
+	digest, ok := claims["tasks_digest"].(string)
+	if !ok || digest == "" {
+		return "", errInvalid
+	}
+	return digest, nil
+}
+
 func (h *TaskCategoryHandler) updateTaskCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
-	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
-		render.Render(w, r, ErrorRenderer(err))
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
 	taskCategoryData := models.TaskCategory{}
@@ -196,6 +335,7 @@ func (h *TaskCategoryHandler) updateTaskCategory(w http.ResponseWriter, r *http.
 	err = json.Unmarshal(body, &taskCategoryData)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
 	taskCategory, err := h.TaskCategoryController.UpdateTaskCategory(taskCategoryID, taskCategoryData, ctx)
 	if err != nil {
@@ -210,45 +350,153 @@ func (h *TaskCategoryHandler) updateTaskCategory(w http.ResponseWriter, r *http.
 	utils.RenderJson(w, taskCategory)
 }
 
+// Imports task categories from an uploaded CSV file, only managers can import task categories.
+// An async import runs in a background job and responds with the job.
 func (h *TaskCategoryHandler) importTaskCategoryCSV(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseForm()
+	ctx := r.Context()
+	file, options, err := parseImportRequest(w, r)
 	if err != nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		renderImportRequestError(w, r, err)
+		return
 	}
-	path := r.PostForm.Get("path")
-	taskCategoryList, err := h.TaskCategoryController.ImportTaskCategoryDataFromCSV(path)
+	defer file.Close()
+	async, err := formBool(r, "async")
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	if async {
+		user, err := controllers.CurrentUser(ctx)
+		if err != nil {
+			render.Render(w, r, ErrorRenderer(err))
+			return
+		}
+		enqueueImport(w, r, h.JobController, controllers.JobTypeImportTaskCategories, file, options, user.ID)
+		return
+	}
+	report, err := h.CSVImportController.ImportTaskCategories(file, options, ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
+	renderImportReport(w, report)
+}
+
+func (h *TaskCategoryHandler) getTasksByCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+		renderAccessError(w, r, err)
+		return
 	}
-	for _, taskCategory := range taskCategoryList {
-		if err := h.TaskCategoryController.AddTaskCategory(&taskCategory, ctx); err != nil {
+	tasks, err := h.TaskCategoryController.GetTasksByCategory(taskCategoryID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, tasks)
+}
+
+func (h *TaskCategoryHandler) getTaskCategorySettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	settings, err := h.TaskCategoryController.GetTaskCategorySettings(taskCategoryID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, settings)
+}
+
+func (h *TaskCategoryHandler) updateTaskCategorySettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	settingsData := internalModels.TaskCategorySettings{}
+	if err := json.NewDecoder(r.Body).Decode(&settingsData); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if err := controllers.ValidateSLAPolicy(settingsData.SLA); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if settingsData.Visibility != "" {
+		if err := controllers.ValidateTaskCategoryVisibility(settingsData.Visibility); err != nil {
 			render.Render(w, r, ErrorRenderer(err))
 			return
 		}
 	}
-	utils.RenderJson(w, taskCategoryList)
+	settings, err := h.TaskCategoryController.UpdateTaskCategorySettings(taskCategoryID, settingsData, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else if err == repositories.ErrEscalationUserNotFound {
+			render.Render(w, r, ErrorRenderer(err))
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, settings)
 }
 
-func (h *TaskCategoryHandler) getTasksByCategory(w http.ResponseWriter, r *http.Request) {
+// Moves a task category under another one, a null parent_id moves it to the root.
+// Only the managers and the owners of both categories can move task categories.
+func (h *TaskCategoryHandler) moveTaskCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
-	tasks, err := h.TaskCategoryController.GetTasksByCategory(taskCategoryID, ctx)
+	move := internalModels.TaskCategoryMove{}
+	if err := json.NewDecoder(r.Body).Decode(&move); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	// Only managers can move a category to the root
+	if move.ParentID.Valid {
+		_, err = authorizeTaskCategory(r, h.TaskCategoryController, move.ParentID.Int, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage)
+	} else {
+		err = controllers.RequirePermission(ctx, internalModels.PermissionCategoryManage)
+	}
+	if err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	taskCategory, err := h.TaskCategoryController.MoveTaskCategory(taskCategoryID, move.ParentID, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
@@ -257,5 +505,141 @@ func (h *TaskCategoryHandler) getTasksByCategory(w http.ResponseWriter, r *http.
 		}
 		return
 	}
-	utils.RenderJson(w, tasks)
+	utils.RenderJson(w, taskCategory)
+}
+
+// Merges a task category into a target category: its tasks and children are moved to the target
+// and it is moved to the trash. Only the managers and the owners of both categories can merge task categories.
+func (h *TaskCategoryHandler) mergeTaskCategory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	user, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage)
+	if err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	merge := internalModels.TaskCategoryMerge{}
+	if err := json.NewDecoder(r.Body).Decode(&merge); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if merge.TargetID <= 0 {
+		render.Render(w, r, ErrorRenderer(errors.New("target_id is required")))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, merge.TargetID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	result, err := h.TaskCategoryController.MergeTaskCategory(taskCategoryID, merge.TargetID, user.ID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, result)
+}
+
+func (h *TaskCategoryHandler) validateUserIDFromURLParam(r *http.Request) (int, error) {
+	userID := chi.URLParam(r, "userID")
+	if !regexp.MustCompile("^[0-9]+$").MatchString(userID) {
+		return 0, errors.New("invalid user ID")
+	}
+	return strconv.Atoi(userID)
+}
+
+// Lists the members of a task category and their roles
+func (h *TaskCategoryHandler) getTaskCategoryMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	members, err := h.TaskCategoryController.GetTaskCategoryMembers(taskCategoryID, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, members)
+}
+
+// Adds a user to a task category with the role of the body, or changes the role of a member.
+// Only the managers and the owners of the category can change its members.
+func (h *TaskCategoryHandler) setTaskCategoryMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	userID, err := h.validateUserIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	member := internalModels.TaskCategoryMember{}
+	if err := json.NewDecoder(r.Body).Decode(&member); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	member.TaskCategoryID = taskCategoryID
+	member.UserID = userID
+	member, err = h.TaskCategoryController.SetTaskCategoryMember(member, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, member)
+}
+
+// Removes a user from the members of a task category.
+// Only the managers and the owners of the category can change its members.
+func (h *TaskCategoryHandler) removeTaskCategoryMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskCategoryID, err := h.validateTaskCategoryIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	userID, err := h.validateUserIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionCategoryManage); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	if err := h.TaskCategoryController.RemoveTaskCategoryMember(taskCategoryID, userID, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
 }
@@ -1,18 +1,23 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
 	"github.com/qthuy2k1/task-management-app/internal/utils"
@@ -22,35 +27,56 @@ type TaskHandler struct {
 	TaskController           *controllers.TaskController
 	UserController           *controllers.UserController
 	UserTaskDetailController *controllers.UserTaskDetailController
+	ChecklistController      *controllers.ChecklistController
+	MentionController        *controllers.MentionController
+	CSVImportController      *controllers.CSVImportController
+	JobController            *controllers.JobController
+	TaskCategoryController   *controllers.TaskCategoryController
+	// Checks that assigned users have verified their email
+	EmailVerificationController *controllers.EmailVerificationController
 }
 
-func NewTaskHandler(database *repositories.Database) *TaskHandler {
+func NewTaskHandler(database *repositories.Database, emailVerificationController *controllers.EmailVerificationController) *TaskHandler {
 	taskRepository := repositories.NewTaskRepository(database)
-	taskController := controllers.NewTaskController(taskRepository)
+	checklistRepository := repositories.NewChecklistRepository(database)
+	taskController := controllers.NewTaskController(taskRepository, checklistRepository)
+	checklistController := controllers.NewChecklistController(checklistRepository, taskRepository)
 	userRepository := repositories.NewUserRepository(database)
 	userController := controllers.NewUserController(userRepository)
 	userTaskDetailRepository := repositories.NewUserTaskDetailRepository(database)
 	userTaskDetailController := controllers.NewUserTaskDetailController(userTaskDetailRepository)
-	return &TaskHandler{TaskController: taskController, UserController: userController, UserTaskDetailController: userTaskDetailController}
+	mentionRepository := repositories.NewMentionRepository(database)
+	notificationRepository := repositories.NewNotificationRepository(database)
+	mentionController := controllers.NewMentionController(mentionRepository, notificationRepository, userRepository)
+	taskCategoryRepository := repositories.NewTaskCategoryRepository(database)
+	taskCategoryController := controllers.NewTaskCategoryController(taskCategoryRepository)
+	csvImportController := controllers.NewCSVImportController(taskRepository, taskCategoryRepository, userRepository)
+	jobController := controllers.NewJobController(repositories.NewJobRepository(database), NewJobRegistry(database))
+	return &TaskHandler{TaskController: taskController, UserController: userController, UserTaskDetailController: userTaskDetailController, ChecklistController: checklistController, MentionController: mentionController, CSVImportController: csvImportController, JobController: jobController, TaskCategoryController: taskCategoryController, EmailVerificationController: emailVerificationController}
 }
 
 func (h *TaskHandler) tasks(router chi.Router) {
 	router.Get("/", h.getAllTasks)
 	router.Post("/", h.addTask)
-	router.Post("/csv", h.importTaskCSV)
+	router.With(RequirePermission(internalModels.PermissionTaskManage)).Post("/csv", h.importTaskCSV)
+	router.Post("/bulk", h.bulkUpdateTasks)
 	router.Get("/filter-name", h.getTasksByName)
+	router.Get("/export", h.exportTasks)
 	// router.Get("/filter", h.filterTasks)
-	// router.Get("/count-filtered-status", h.countFilteredStatusTask)
 	router.Route("/{taskID}", func(router chi.Router) {
+		router.Use(h.taskAccess)
 		router.Get("/", h.getTask)
 		router.Put("/", h.updateTask)
 		router.Delete("/", h.deleteTask)
 		router.Patch("/lock", h.lockTask)
 		router.Patch("/unlock", h.unLockTask)
+		router.Patch("/archive", h.archiveTask)
+		router.Patch("/unarchive", h.unarchiveTask)
 		router.Post("/add-user", h.addUserTaskDetail)
 		router.Post("/delete-user", h.deleteUserFromTask)
 		router.Get("/get-users", h.getAllUserAsignnedToTask)
 		router.Get("/get-task-category", h.getTaskCategoryOfTask)
+		router.Route("/checklist", h.checklist)
 	})
 }
 
@@ -82,7 +108,59 @@ func (h *TaskHandler) validateTaskIDFromURLParam(r *http.Request) (int, error) {
 
 }
 
+// Hides the tasks of the task categories the caller cannot see from every task route
+func (h *TaskHandler) taskAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		taskID, err := h.validateTaskIDFromURLParam(r)
+		if err != nil {
+			render.Render(w, r, ErrorRenderer(err))
+			return
+		}
+		if err := h.authorizeTask(r, taskID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+			renderAccessError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Checks that the caller has a permission on the task category of a task, or the permission of their role
+// overriding it
+func (h *TaskHandler) authorizeTask(r *http.Request, taskID int, permission internalModels.TaskCategoryPermission, override internalModels.Permission) error {
+	ctx := r.Context()
+	task, err := h.TaskController.GetTaskByID(taskID, ctx)
+	if err != nil {
+		return err
+	}
+	_, err = authorizeTaskCategory(r, h.TaskCategoryController, task.TaskCategoryID, permission, override)
+	return err
+}
+
+// Gets the relation of the current user to a task they can see, for the task policy. The owners of the
+// category of the task act as managers.
+func (h *TaskHandler) taskActor(r *http.Request, taskID int) (internalModels.TaskActor, error) {
+	ctx := r.Context()
+	task, err := h.TaskController.GetTaskByID(taskID, ctx)
+	if err != nil {
+		return internalModels.TaskActor{}, err
+	}
+	user, err := authorizeTaskCategory(r, h.TaskCategoryController, task.TaskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll)
+	if err != nil {
+		return internalModels.TaskActor{}, err
+	}
+	manages := func(override internalModels.Permission) bool {
+		_, err := authorizeTaskCategory(r, h.TaskCategoryController, task.TaskCategoryID, internalModels.TaskCategoryManage, override)
+		return err == nil
+	}
+	return internalModels.TaskActor{
+		UserID:  user.ID,
+		Manager: manages(internalModels.PermissionTaskManage),
+		Locker:  manages(internalModels.PermissionTaskLock),
+	}, nil
+}
+
 func (h *TaskHandler) addTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	task := models.Task{}
 	// Read request body into a []byte variable
 	body, err := ioutil.ReadAll(r.Body)
@@ -94,15 +172,11 @@ func (h *TaskHandler) addTask(w http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &task)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
-	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
-		render.Render(w, r, ErrorRenderer(err))
+	task = newTaskFromRequest(task)
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, task.TaskCategoryID, internalModels.TaskCategoryManage, internalModels.PermissionTaskManage); err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
 	if err := h.TaskController.AddTask(&task, ctx); err != nil {
@@ -114,11 +188,60 @@ func (h *TaskHandler) addTask(w http.ResponseWriter, r *http.Request) {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	utils.RenderJson(w, task)
+	taskDetail, err := h.getTaskDetailWithMentions(&task, r)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, taskDetail)
 }
 
-func (h *TaskHandler) getAllTasks(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
+// Keeps the fields of a new task that clients can set, the trash and archive columns are only written by
+// the server
+func newTaskFromRequest(taskData models.Task) models.Task {
+	return models.Task{
+		Name:           taskData.Name,
+		Description:    taskData.Description,
+		StartDate:      taskData.StartDate,
+		EndDate:        taskData.EndDate,
+		Status:         taskData.Status,
+		AuthorID:       taskData.AuthorID,
+		TaskCategoryID: taskData.TaskCategoryID,
+		ExternalSource: taskData.ExternalSource,
+		ExternalID:     taskData.ExternalID,
+	}
+}
+
+// Records the mentions in the description of a task written by the caller and adds the outcome to the task details
+func (h *TaskHandler) getTaskDetailWithMentions(task *models.Task, r *http.Request) (internalModels.TaskDetail, error) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		return internalModels.TaskDetail{}, err
+	}
+	// The task is already saved, so mentions that cannot be recorded are logged and left out of the response
+	report, mentionErr := h.MentionController.ProcessTaskMentions(task, user.ID, ctx)
+	if mentionErr != nil {
+		log.Printf("Could not process the mentions of task %d: %v\n", task.ID, mentionErr)
+	}
+	taskDetails, err := h.TaskController.GetTaskDetails(models.TaskSlice{task}, ctx)
+	if err != nil {
+		return internalModels.TaskDetail{}, err
+	}
+	if mentionErr == nil {
+		taskDetails[0].Mentions = &report
+	}
+	return taskDetails[0], nil
+}
+
+// Columns the tasks can be sorted by
+var taskSortFields = map[string]bool{
+	"id": true, "name": true, "description": true, "start_date": true, "end_date": true, "status": true,
+	"author_id": true, "created_at": true, "updated_at": true, "task_category_id": true,
+}
+
+// Reads the filters, the sort and the page of a task list from the query string
+func parseTaskListQuery(query url.Values) (map[string]any, error) {
 	queryParams := make(map[string]any)
 	// set default for query
 	queryParams["page"] = 1
@@ -128,43 +251,156 @@ func (h *TaskHandler) getAllTasks(w http.ResponseWriter, r *http.Request) {
 	for key, values := range query {
 		if len(values) > 0 {
 			switch key {
-			case "page":
-				pageNumber, err := strconv.Atoi(values[0])
+			case "archived", "include_archived", "include_descendants":
+				value, err := strconv.ParseBool(values[0])
 				if err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-					return
+					return nil, fmt.Errorf("%s must be true or false", key)
 				}
-				queryParams[key] = pageNumber
-			case "size":
-				pageSize, err := strconv.Atoi(values[0])
+				queryParams[key] = value
+			case "page", "size", "id", "author_id", "task_category_id":
+				value, err := strconv.Atoi(values[0])
 				if err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-					return
+					return nil, err
 				}
-				queryParams[key] = pageSize
-			case "author_id":
-				authorID, err := strconv.Atoi(values[0])
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-					return
+				queryParams[key] = value
+			case "field":
+				// The sort field and order are written into the query, so only known values are accepted
+				if !taskSortFields[values[0]] {
+					return nil, fmt.Errorf("cannot sort tasks by %q", values[0])
+				}
+				queryParams[key] = values[0]
+			case "order":
+				order := strings.ToLower(values[0])
+				if order != "asc" && order != "desc" {
+					return nil, errors.New("order must be asc or desc")
 				}
-				queryParams[key] = authorID
+				queryParams[key] = order
 			default:
 				queryParams[key] = values[0]
 			}
 		}
 	}
+	return queryParams, nil
+}
+
+func (h *TaskHandler) getAllTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	queryParams, err := parseTaskListQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Archived tasks are only listed when they are asked for
+	if _, ok := queryParams["archived"]; !ok && queryParams["include_archived"] != true {
+		queryParams["archived"] = false
+	}
+	if err := h.filterVisibleTasks(r, queryParams); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
 
 	tasks, err := h.TaskController.GetAllTasks(ctx, queryParams)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	taskDetails, err := h.TaskController.GetTaskDetails(tasks, ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	utils.RenderJson(w, tasks)
+	utils.RenderJson(w, taskDetails)
+}
+
+// Keeps the tasks of the categories the caller can see in a task list, managers see every task
+func (h *TaskHandler) filterVisibleTasks(r *http.Request, queryParams map[string]any) error {
+	ctx := r.Context()
+	delete(queryParams, "visible_to_user_id")
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if !controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll) {
+		queryParams["visible_to_user_id"] = user.ID
+	}
+	return nil
+}
+
+// Number of exported tasks written between two flushes of the response
+const exportFlushInterval = 100
+
+// Streams the tasks matching the filters of the list in the format of the format parameter, without pages
+func (h *TaskHandler) exportTasks(w http.ResponseWriter, r *http.Request) {
+	queryParams, err := parseTaskListQuery(r.URL.Query())
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	delete(queryParams, "page")
+	delete(queryParams, "size")
+	if err := h.filterVisibleTasks(r, queryParams); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = internalModels.ExportFormatCSV
+	}
+	exportWriter, err := controllers.NewTaskExportWriter(format, w)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+
+	// The response starts with the first task, so a failing query can still be answered with an error
+	started := false
+	begin := func() error {
+		started = true
+		w.Header().Set("Content-Type", exportWriter.ContentType())
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks.%s"`, format))
+		return exportWriter.Begin()
+	}
+	flusher, _ := w.(http.Flusher)
+	count := 0
+	err = h.TaskController.ExportTasks(queryParams, func(task internalModels.TaskExport) error {
+		if !started {
+			if err := begin(); err != nil {
+				return err
+			}
+		}
+		if err := exportWriter.Write(task); err != nil {
+			return err
+		}
+		count++
+		if count%exportFlushInterval == 0 && flusher != nil {
+			if err := exportWriter.Flush(); err != nil {
+				return err
+			}
+			flusher.Flush()
+		}
+		return nil
+	}, r.Context())
+	if err == nil && !started {
+		err = begin()
+	}
+	if err == nil {
+		err = exportWriter.End()
+	}
+	if err != nil {
+		if !started {
+			render.Render(w, r, ServerErrorRenderer(err))
+			return
+		}
+		// Part of the export was sent, aborting makes the client see an incomplete response
+		log.Printf("export of tasks failed after %d tasks: %v", count, err)
+		panic(http.ErrAbortHandler)
+	}
 }
 
 func (h *TaskHandler) getTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskID, err := h.validateTaskIDFromURLParam(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -179,28 +415,33 @@ func (h *TaskHandler) getTask(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	taskDetails, err := h.TaskController.GetTaskDetails(models.TaskSlice{task}, ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	utils.RenderJson(w, task)
+	utils.RenderJson(w, taskDetails[0])
 
 }
 
 func (h *TaskHandler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskID, err := h.validateTaskIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
-		return
-	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
+	user, err := controllers.CurrentUser(ctx)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	err = h.TaskController.DeleteTask(taskID, ctx)
+	if err := h.authorizeTask(r, taskID, internalModels.TaskCategoryManage, internalModels.PermissionTaskDelete); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	err = h.TaskController.DeleteTask(taskID, user.ID, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
@@ -216,16 +457,12 @@ func (h *TaskHandler) deleteTask(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TaskHandler) updateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskID, err := h.validateTaskIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
-		return
-	}
 
 	taskData := models.Task{}
 	// Read request body into a []byte variable
@@ -239,41 +476,49 @@ func (h *TaskHandler) updateTask(w http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &taskData)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	var isManager bool
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
+	actor, err := h.taskActor(r, taskID)
 	if err != nil {
-		isManager = false
-	} else {
-		isManager = true
+		renderAccessError(w, r, err)
+		return
 	}
-	task, err := h.TaskController.UpdateTask(taskID, taskData, ctx, isManager)
+	// A task can only be moved to a category the caller can see
+	if _, err := authorizeTaskCategory(r, h.TaskCategoryController, taskData.TaskCategoryID, internalModels.TaskCategoryView, internalModels.PermissionTaskViewAll); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	task, err := h.TaskController.UpdateTask(taskID, taskData, actor, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrorRenderer(fmt.Errorf("no rows afftected")))
+		} else if errors.Is(err, controllers.ErrTaskForbidden) {
+			render.Render(w, r, ForbiddenRenderer(err))
+		} else if err == repositories.ErrChecklistIncomplete {
+			render.Render(w, r, ErrorRenderer(err))
 		} else {
 			render.Render(w, r, ServerErrorRenderer(err))
 		}
 		return
 	}
+	taskDetail, err := h.getTaskDetailWithMentions(task, r)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
 
-	utils.RenderJson(w, task)
+	utils.RenderJson(w, taskDetail)
 }
 
 func (h *TaskHandler) lockTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskID, err := h.validateTaskIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
-		return
-	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
-		render.Render(w, r, ErrorRenderer(err))
+	if err := h.authorizeTask(r, taskID, internalModels.TaskCategoryManage, internalModels.PermissionTaskLock); err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
 	err = h.TaskController.LockTask(taskID, ctx)
@@ -292,20 +537,16 @@ func (h *TaskHandler) lockTask(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *TaskHandler) unLockTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskID, err := h.validateTaskIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	if err := h.authorizeTask(r, taskID, internalModels.TaskCategoryManage, internalModels.PermissionTaskLock); err != nil {
+		renderAccessError(w, r, err)
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
-		render.Render(w, r, ErrorRenderer(err))
-	}
 	err = h.TaskController.UnLockTask(taskID, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
@@ -321,41 +562,126 @@ func (h *TaskHandler) unLockTask(w http.ResponseWriter, r *http.Request) {
 	utils.RenderJson(w, s)
 }
 
+// Archives a task, only managers and the owners of its category can archive tasks
+func (h *TaskHandler) archiveTask(w http.ResponseWriter, r *http.Request) {
+	h.setTaskArchived(w, r, h.TaskController.ArchiveTask)
+}
+
+// Unarchives a task, only managers and the owners of its category can unarchive tasks
+func (h *TaskHandler) unarchiveTask(w http.ResponseWriter, r *http.Request) {
+	h.setTaskArchived(w, r, h.TaskController.UnarchiveTask)
+}
+
+func (h *TaskHandler) setTaskArchived(w http.ResponseWriter, r *http.Request, update func(taskID int, ctx context.Context) error) {
+	ctx := r.Context()
+	taskID, err := h.validateTaskIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if err := h.authorizeTask(r, taskID, internalModels.TaskCategoryManage, internalModels.PermissionTaskManage); err != nil {
+		renderAccessError(w, r, err)
+		return
+	}
+	if err := update(taskID, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
+}
+
+// Imports tasks from an uploaded CSV file, only managers can import tasks. An async import runs
+// in a background job and responds with the job.
 func (h *TaskHandler) importTaskCSV(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseForm()
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	file, options, err := parseImportRequest(w, r)
+	if err != nil {
+		renderImportRequestError(w, r, err)
+		return
+	}
+	defer file.Close()
+	async, err := formBool(r, "async")
 	if err != nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	path := r.PostForm.Get("path")
-	taskList, err := h.TaskController.ImportTaskDataFromCSV(path)
+	if async {
+		enqueueImport(w, r, h.JobController, controllers.JobTypeImportTasks, file, options, user.ID)
+		return
+	}
+	report, err := h.CSVImportController.ImportTasks(file, options, user.ID, ctx)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	renderImportReport(w, report)
+}
+
+// Gets the task counts of the caller, and of all tasks for managers
+func (h *TaskHandler) getDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
+	isManager := controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll)
+	dashboard, err := h.TaskController.GetDashboard(user.ID, isManager, time.Now(), ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, dashboard)
+}
+
+func (h *TaskHandler) bulkUpdateTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	request := internalModels.BulkTaskRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
-	for _, task := range taskList {
-		if err := h.TaskController.AddTask(&task, ctx); err != nil {
-			render.Render(w, r, ErrorRenderer(err))
-			return
-		}
-		if err = h.UserTaskDetailController.AddUserToTask(task.AuthorID, task.ID, ctx); err != nil {
-			render.Render(w, r, ErrorRenderer(err))
-			return
-		}
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	// The role of the caller may run the operation on the tasks of every category
+	isManager := controllers.HasPermission(ctx, controllers.BulkOperationPermission(request.Operation))
+	accesses, err := h.TaskCategoryController.GetTaskCategoryAccesses(user.ID, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
 	}
 
-	utils.RenderJson(w, taskList)
+	report, err := h.TaskController.BulkUpdateTasks(request, user.ID, isManager, accesses, ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	// Nothing was written when an all or nothing request fails
+	if !report.Committed {
+		utils.RenderJsonStatus(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+	utils.RenderJson(w, report)
 }
 
 func (h *TaskHandler) getTaskCategoryOfTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	taskID, err := h.validateTaskIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
@@ -374,30 +700,25 @@ func (h *TaskHandler) getTaskCategoryOfTask(w http.ResponseWriter, r *http.Reque
 }
 
 func (h *TaskHandler) getTasksByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	name := r.URL.Query().Get("name")
 	if name == "" {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("invalid name")))
 		return
 	}
-	tasks, err := h.TaskController.GetTasksByName(name, ctx)
+	user, err := controllers.CurrentUser(ctx)
 	if err != nil {
-		if err == repositories.ErrNoMatch {
-			render.Render(w, r, ErrNotFound)
-		} else {
-			render.Render(w, r, ErrorRenderer(err))
-		}
+		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	utils.RenderJson(w, tasks)
-}
-
-func (h *TaskHandler) countFilteredStatusTask(w http.ResponseWriter, r *http.Request) {
-	status := r.URL.Query().Get("status")
-	if status == "" {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("invalid status")))
-		return
+	isManager := controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll)
+	tasks, err := h.TaskController.GetTasksByName(name, ctx)
+	if err == nil {
+		tasks, err = h.TaskCategoryController.FilterVisibleTasks(tasks, user.ID, isManager, ctx)
+		if err == nil && len(tasks) == 0 {
+			err = repositories.ErrNoMatch
+		}
 	}
-	count, err := h.TaskController.CountFilteredStatusTask(status, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
@@ -406,15 +727,12 @@ func (h *TaskHandler) countFilteredStatusTask(w http.ResponseWriter, r *http.Req
 		}
 		return
 	}
-	type CountResponse struct {
-		Status string `json:"status"`
-		Count  int64  `json:"count"`
-	}
-	response := CountResponse{
-		Status: status,
-		Count:  count,
+	taskDetails, err := h.TaskController.GetTaskDetails(tasks, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
 	}
-	utils.RenderJson(w, response)
+	utils.RenderJson(w, taskDetails)
 }
 
 // func (h *TaskHandler) filterTasks(w http.ResponseWriter, r *http.Request) {
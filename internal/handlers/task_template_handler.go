@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+type TaskTemplateHandler struct {
+	TaskTemplateController *controllers.TaskTemplateController
+	UserController         *controllers.UserController
+}
+
+func NewTaskTemplateHandler(database *repositories.Database) *TaskTemplateHandler {
+	taskTemplateRepository := repositories.NewTaskTemplateRepository(database)
+	taskRepository := repositories.NewTaskRepository(database)
+	userRepository := repositories.NewUserRepository(database)
+	taskTemplateController := controllers.NewTaskTemplateController(taskTemplateRepository, taskRepository, userRepository)
+	userController := controllers.NewUserController(userRepository)
+	return &TaskTemplateHandler{TaskTemplateController: taskTemplateController, UserController: userController}
+}
+
+func (h *TaskTemplateHandler) taskTemplates(router chi.Router) {
+	router.Get("/", h.getAllTaskTemplates)
+	router.With(RequirePermission(internalModels.PermissionTemplateManage)).Post("/", h.addTaskTemplate)
+	router.Route("/{templateID}", func(router chi.Router) {
+		router.Get("/", h.getTaskTemplate)
+		router.With(RequirePermission(internalModels.PermissionTemplateManage)).Put("/", h.updateTaskTemplate)
+		router.With(RequirePermission(internalModels.PermissionTemplateManage)).Delete("/", h.deleteTaskTemplate)
+		router.With(RequirePermission(internalModels.PermissionTemplateManage)).Post("/instantiate", h.instantiateTaskTemplate)
+	})
+}
+
+func (h *TaskTemplateHandler) validateTemplateIDFromURLParam(r *http.Request) (int, error) {
+	templateID := chi.URLParam(r, "templateID")
+	if templateID == "" {
+		return 0, errors.New("template ID is required")
+	}
+	templateID = strings.TrimLeft(templateID, "0")
+	templateID = strings.Trim(templateID, " ")
+	id, err := strconv.Atoi(templateID)
+	if err != nil {
+		return 0, errors.New("cannot convert template ID from string to int, invalid template ID")
+	}
+	// Check if the template ID only contains digits
+	if !regexp.MustCompile("^[0-9]+$").MatchString(templateID) {
+		return 0, errors.New("invalid template ID")
+	}
+	return id, nil
+}
+
+func (h *TaskTemplateHandler) getAllTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templates, err := h.TaskTemplateController.GetAllTaskTemplates(ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, templates)
+}
+
+func (h *TaskTemplateHandler) getTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID, err := h.validateTemplateIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	template, err := h.TaskTemplateController.GetTaskTemplateByID(templateID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, template)
+}
+
+func (h *TaskTemplateHandler) addTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	template := internalModels.TaskTemplate{}
+	err := json.NewDecoder(r.Body).Decode(&template)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if err := h.TaskTemplateController.AddTaskTemplate(&template, user.ID, ctx); err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, template)
+}
+
+func (h *TaskTemplateHandler) updateTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID, err := h.validateTemplateIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	templateData := internalModels.TaskTemplate{}
+	err = json.NewDecoder(r.Body).Decode(&templateData)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	template, err := h.TaskTemplateController.UpdateTaskTemplate(templateID, templateData, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, template)
+}
+
+func (h *TaskTemplateHandler) deleteTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID, err := h.validateTemplateIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	err = h.TaskTemplateController.DeleteTaskTemplate(templateID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
+}
+
+func (h *TaskTemplateHandler) instantiateTaskTemplate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	templateID, err := h.validateTemplateIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	instantiation := internalModels.TaskTemplateInstantiation{}
+	err = json.NewDecoder(r.Body).Decode(&instantiation)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	instance, err := h.TaskTemplateController.InstantiateTaskTemplate(templateID, instantiation, user.ID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	utils.RenderJson(w, instance)
+}
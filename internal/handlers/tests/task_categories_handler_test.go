@@ -109,7 +109,7 @@ func TestGetTaskCategoryByID(t *testing.T) {
 			mockTaskCat:    &models.TaskCategory{ID: 1, Name: "Category 1"},
 			mockErr:        nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"name":"Category 1"}`,
+			expectedBody:   `{"id":1,"name":"Category 1","deleted_at":null,"deleted_by":null,"require_checklist_completion":false,"external_source":null,"external_id":null,"sla_start_within_hours":null,"sla_complete_within_business_hours":null,"sla_escalate_to_user_id":null,"parent_id":null,"visibility":""}`,
 		},
 		{
 			name:           "Task Category Not Found",
@@ -210,7 +210,7 @@ func TestAddTaskCategory(t *testing.T) {
 				Name: "Test Task Category",
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"name":"Test Task Category"}`,
+			expectedBody:   `{"id":1,"name":"Test Task Category","deleted_at":null,"deleted_by":null,"require_checklist_completion":false,"external_source":null,"external_id":null,"sla_start_within_hours":null,"sla_complete_within_business_hours":null,"sla_escalate_to_user_id":null,"parent_id":null,"visibility":""}`,
 		},
 		{
 			name: "Invalid request body",
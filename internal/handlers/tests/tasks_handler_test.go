@@ -42,7 +42,7 @@ func TestGetAllTasks(t *testing.T) {
 			sortField:      "id",
 			sortOrder:      "asc",
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"id":1,"name":"Task 1","description":"Description of Task 1","start_date":"2023-04-20T13:00:00Z","end_date":"2023-04-21T13:00:00Z","status":"In Progress","author_id":1,"created_at":"2023-04-20T13:00:00Z","updated_at":"2023-04-20T13:00:00Z","task_category_id":1}]`,
+			expectedBody:   `[{"id":1,"name":"Task 1","description":"Description of Task 1","start_date":"2023-04-20T13:00:00Z","end_date":"2023-04-21T13:00:00Z","status":"In Progress","author_id":1,"created_at":"2023-04-20T13:00:00Z","updated_at":"2023-04-20T13:00:00Z","task_category_id":1,"deleted_at":null,"deleted_by":null,"external_source":null,"external_id":null,"archived_at":null,"parent_task_id":null}]`,
 			mockResults: models.TaskSlice{
 				{
 					ID:             1,
@@ -153,6 +153,7 @@ func TestGetAllTasks(t *testing.T) {
 func TestGetTaskByID(t *testing.T) {
 	// Define the mock task service
 	taskServiceMock := &mockControllers.MockTaskService{}
+	now := time.Now().UTC()
 
 	// Define the test cases
 	testCases := []struct {
@@ -166,10 +167,10 @@ func TestGetTaskByID(t *testing.T) {
 		{
 			name:           "Success",
 			taskID:         1,
-			mockTask:       &models.Task{ID: 1, Name: "Task 1", Description: "Description of Task 1", StartDate: time.Now(), EndDate: time.Now().Add(time.Hour), Status: null.NewString("In Progress", true), AuthorID: 1, CreatedAt: time.Now(), UpdatedAt: time.Now(), TaskCategoryID: 1},
+			mockTask:       &models.Task{ID: 1, Name: "Task 1", Description: "Description of Task 1", StartDate: now, EndDate: now.Add(time.Hour), Status: null.NewString("In Progress", true), AuthorID: 1, CreatedAt: now, UpdatedAt: now, TaskCategoryID: 1},
 			mockErr:        nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"name":"Task 1","description":"Description of Task 1","start_date":"` + time.Now().Format(time.RFC3339Nano) + `","end_date":"` + time.Now().Add(time.Hour).Format(time.RFC3339Nano) + `","status":"In Progress","author_id":1,"created_at":"` + time.Now().Format(time.RFC3339Nano) + `","updated_at":"` + time.Now().Format(time.RFC3339Nano) + `","task_category_id":1}`,
+			expectedBody:   `{"id":1,"name":"Task 1","description":"Description of Task 1","start_date":"` + now.Format(time.RFC3339Nano) + `","end_date":"` + now.Add(time.Hour).Format(time.RFC3339Nano) + `","status":"In Progress","author_id":1,"created_at":"` + now.Format(time.RFC3339Nano) + `","updated_at":"` + now.Format(time.RFC3339Nano) + `","task_category_id":1,"deleted_at":null,"deleted_by":null,"external_source":null,"external_id":null,"archived_at":null,"parent_task_id":null}`,
 		},
 		{
 			name:           "Task Not Found",
@@ -306,8 +307,8 @@ func TestAddTask(t *testing.T) {
 	mockTask := &models.Task{
 		Name:           "Test Task",
 		Description:    "This is a test task",
-		StartDate:      time.Now().Local(),
-		EndDate:        time.Now().Add(time.Hour).Local(),
+		StartDate:      time.Now().UTC(),
+		EndDate:        time.Now().Add(time.Hour).UTC(),
 		Status:         null.NewString("Working", true),
 		AuthorID:       1,
 		TaskCategoryID: 1,
@@ -487,8 +488,8 @@ func TestUpdateTaskHandler(t *testing.T) {
 	taskData := models.Task{
 		Name:           "Test Task",
 		Description:    "This is a test task",
-		StartDate:      time.Now().Local(),
-		EndDate:        time.Now().Add(time.Hour * 24).Local(),
+		StartDate:      time.Now().UTC(),
+		EndDate:        time.Now().Add(time.Hour * 24).UTC(),
 		Status:         null.NewString("Open", true),
 		AuthorID:       1,
 		TaskCategoryID: 1,
@@ -763,7 +764,7 @@ func TestGetTaskCategoryOfTask(t *testing.T) {
 			mockTaskCat:    &models.TaskCategory{ID: 1, Name: "Category 1"},
 			mockErr:        nil,
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"id":1,"name":"Category 1"}`,
+			expectedBody:   `{"id":1,"name":"Category 1","deleted_at":null,"deleted_by":null,"require_checklist_completion":false,"external_source":null,"external_id":null,"sla_start_within_hours":null,"sla_complete_within_business_hours":null,"sla_escalate_to_user_id":null,"parent_id":null,"visibility":""}`,
 		},
 		{
 			name:           "Task Category Not Found",
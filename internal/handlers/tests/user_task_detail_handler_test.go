@@ -24,8 +24,11 @@ import (
 
 var tokenAuth *jwtauth.JWTAuth
 
+// The tokens of the tests are signed with their own secret
+const testSecret = "test-secret-of-at-least-32-characters"
+
 func init() {
-	tokenAuth = jwtauth.New("HS256", []byte(handlers.Secret), nil)
+	tokenAuth = jwtauth.New("HS256", []byte(testSecret), nil)
 }
 
 func TestAddUserTaskDetailHandler(t *testing.T) {
@@ -317,7 +320,7 @@ func TestGetAllUserAssignedToTaskHandler(t *testing.T) {
 			name:           "Success - Users assigned to task",
 			taskID:         1,
 			expectedStatus: http.StatusOK,
-			expectedJSON:   `[{"id":1,"name":"Alice","email":"alice@example.com","password":"password","role":"user"},{"id":2,"name":"Bob","email":"bob@example.com","password":"password","role":"admin"}]`,
+			expectedJSON:   `[{"id":1,"name":"Alice","email":"alice@example.com","password":"password","role":"user","deleted_at":null,"deleted_by":null,"email_verified_at":null,"pending_email":null},{"id":2,"name":"Bob","email":"bob@example.com","password":"password","role":"admin","deleted_at":null,"deleted_by":null,"email_verified_at":null,"pending_email":null}]`,
 			expectedError:  nil,
 		},
 		{
@@ -411,7 +414,7 @@ func TestGetAllTaskAssignedToUser(t *testing.T) {
 			name:           "Success - Tasks assigned to user",
 			userID:         1,
 			expectedStatus: http.StatusOK,
-			expectedJSON:   `[{"id":1,"name":"Task 1","description":"Description of task 1","start_date":"2022-12-01T12:00:00Z","end_date":"2022-12-02T12:00:00Z","status":"in progress","author_id":1,"created_at":"2022-12-01T12:00:00Z","updated_at":"2022-12-02T12:00:00Z","task_category_id":1},{"id":2,"name":"Task 2","description":"Description of task 2","start_date":"2022-12-03T12:00:00Z","end_date":"2022-12-04T12:00:00Z","status":"completed","author_id":1,"created_at":"2022-12-03T12:00:00Z","updated_at":"2022-12-04T12:00:00Z","task_category_id":1}]`,
+			expectedJSON:   `[{"id":1,"name":"Task 1","description":"Description of task 1","start_date":"2022-12-01T12:00:00Z","end_date":"2022-12-02T12:00:00Z","status":"in progress","author_id":1,"created_at":"2022-12-01T12:00:00Z","updated_at":"2022-12-02T12:00:00Z","task_category_id":1,"deleted_at":null,"deleted_by":null,"external_source":null,"external_id":null,"archived_at":null,"parent_task_id":null},{"id":2,"name":"Task 2","description":"Description of task 2","start_date":"2022-12-03T12:00:00Z","end_date":"2022-12-04T12:00:00Z","status":"completed","author_id":1,"created_at":"2022-12-03T12:00:00Z","updated_at":"2022-12-04T12:00:00Z","task_category_id":1,"deleted_at":null,"deleted_by":null,"external_source":null,"external_id":null,"archived_at":null,"parent_task_id":null}]`,
 			expectedError:  nil,
 		},
 		{
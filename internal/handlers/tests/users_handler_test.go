@@ -523,7 +523,7 @@ func TestSignUpHandler(t *testing.T) {
 				}
 
 				// Generate JWT token for user
-				token := handlers.MakeToken(user.Email, user.Password)
+				_, token, _ := tokenAuth.Encode(map[string]interface{}{"sub": strconv.Itoa(user.ID), "sid": 1})
 
 				// Set JWT token as cookie
 				http.SetCookie(w, &http.Cookie{
@@ -594,7 +594,7 @@ func TestLoginHandler(t *testing.T) {
 				return
 			}
 
-			token := handlers.MakeToken(email, password)
+			_, token, _ := tokenAuth.Encode(map[string]interface{}{"sub": "1", "sid": 1})
 
 			// Set the JWT token as a cookie in the response
 			http.SetCookie(w, &http.Cookie{
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+type TrashHandler struct {
+	TrashController *controllers.TrashController
+	UserController  *controllers.UserController
+}
+
+func NewTrashHandler(database *repositories.Database) *TrashHandler {
+	taskRepository := repositories.NewTaskRepository(database)
+	taskCategoryRepository := repositories.NewTaskCategoryRepository(database)
+	userRepository := repositories.NewUserRepository(database)
+	trashController := controllers.NewTrashController(taskRepository, taskCategoryRepository, userRepository)
+	userController := controllers.NewUserController(userRepository)
+	return &TrashHandler{TrashController: trashController, UserController: userController}
+}
+
+func (h *TrashHandler) trash(router chi.Router) {
+	router.Use(RequirePermission(internalModels.PermissionTrashManage))
+	router.Get("/", h.getTrash)
+	router.Route("/tasks/{itemID}", func(router chi.Router) {
+		router.Post("/restore", h.trashItemAction(h.TrashController.RestoreTask))
+		router.Delete("/", h.trashItemAction(h.TrashController.PurgeTask))
+	})
+	router.Route("/task-categories/{itemID}", func(router chi.Router) {
+		router.Post("/restore", h.trashItemAction(h.TrashController.RestoreTaskCategory))
+		router.Delete("/", h.trashItemAction(h.TrashController.PurgeTaskCategory))
+	})
+	router.Route("/users/{itemID}", func(router chi.Router) {
+		router.Post("/restore", h.trashItemAction(h.TrashController.RestoreUser))
+		router.Delete("/", h.trashItemAction(h.TrashController.PurgeUser))
+	})
+}
+
+func (h *TrashHandler) validateItemIDFromURLParam(r *http.Request) (int, error) {
+	itemID := chi.URLParam(r, "itemID")
+	if itemID == "" {
+		return 0, errors.New("item ID is required")
+	}
+	itemID = strings.TrimLeft(itemID, "0")
+	itemID = strings.Trim(itemID, " ")
+	id, err := strconv.Atoi(itemID)
+	if err != nil {
+		return 0, errors.New("cannot convert item ID from string to int, invalid item ID")
+	}
+	// Check if the item ID only contains digits
+	if !regexp.MustCompile("^[0-9]+$").MatchString(itemID) {
+		return 0, errors.New("invalid item ID")
+	}
+	return id, nil
+}
+
+func (h *TrashHandler) getTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	trash, err := h.TrashController.GetTrash(ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, trash)
+}
+
+// Returns a handler that runs the given restore or purge function on the item in the URL
+func (h *TrashHandler) trashItemAction(action func(int, context.Context) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.handleTrashItem(w, r, action)
+	}
+}
+
+func (h *TrashHandler) handleTrashItem(w http.ResponseWriter, r *http.Request, action func(int, context.Context) error) {
+	ctx := r.Context()
+	itemID, err := h.validateItemIDFromURLParam(r)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	err = action(itemID, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else if err == repositories.ErrPurgeBlocked {
+			render.Render(w, r, ConflictRenderer(err))
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	s := success{
+		Status: "success",
+	}
+	utils.RenderJson(w, s)
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/qthuy2k1/task-management-app/internal/repositories"
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+// Name of the cookie holding the refresh token
+const refreshTokenCookie = "refresh_token"
+
+var errInvalidRefreshToken = errors.New("the refresh token is invalid, expired or revoked, please log in again")
+
+func (h *UserHandler) sessions(router chi.Router) {
+	router.Get("/", h.getMySessions)
+	router.Delete("/{sessionID}", h.revokeMySession)
+}
+
+// Records the device and the address a session is used from
+func sessionDevice(r *http.Request) internalModels.UserSession {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return internalModels.UserSession{UserAgent: r.UserAgent(), IPAddress: ip}
+}
+
+// Starts a session of a user and sets its tokens as cookies
+func (h *UserHandler) startSession(w http.ResponseWriter, r *http.Request, user *models.User) (internalModels.SessionTokens, error) {
+	tokens, err := h.UserSessionController.StartSession(user, sessionDevice(r), h.TokenIssuer, time.Now(), r.Context())
+	if err != nil {
+		return tokens, err
+	}
+	h.setSessionCookies(w, tokens)
+	return tokens, nil
+}
+
+// Sets the access token and the refresh token as cookies expiring with the tokens
+func (h *UserHandler) setSessionCookies(w http.ResponseWriter, tokens internalModels.SessionTokens) {
+	http.SetCookie(w, &http.Cookie{
+		HttpOnly: true,
+		Expires:  time.Now().Add(h.TokenIssuer.TTL()),
+		SameSite: http.SameSiteLaxMode,
+		// Uncomment below for HTTPS:
+		// Secure: true,
+		Name:  "jwt", // Must be named "jwt" or else the token cannot be found by TokenFromCookie.
+		Value: tokens.AccessToken,
+	})
+	http.SetCookie(w, &http.Cookie{
+		HttpOnly: true,
+		Path:     "/",
+		Expires:  time.Now().Add(h.UserSessionController.RefreshTTL),
+		SameSite: http.SameSiteLaxMode,
+		// Uncomment below for HTTPS:
+		// Secure: true,
+		Name:  refreshTokenCookie,
+		Value: tokens.RefreshToken,
+	})
+}
+
+func clearSessionCookies(w http.ResponseWriter) {
+	for _, name := range []string{"jwt", refreshTokenCookie} {
+		http.SetCookie(w, &http.Cookie{
+			HttpOnly: true,
+			Path:     "/",
+			MaxAge:   -1, // Delete the cookie.
+			SameSite: http.SameSiteLaxMode,
+			// Uncomment below for HTTPS:
+			// Secure: true,
+			Name:  name,
+			Value: "",
+		})
+	}
+}
+
+// Gets the refresh token of the refresh_token cookie, or of the refresh_token form value
+func refreshTokenFromRequest(r *http.Request) string {
+	if cookie, err := r.Cookie(refreshTokenCookie); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	return r.PostFormValue(refreshTokenCookie)
+}
+
+// Exchanges a refresh token for a new access token and a new refresh token
+func (h *UserHandler) refresh(w http.ResponseWriter, r *http.Request) {
+	refreshToken := refreshTokenFromRequest(r)
+	if refreshToken == "" {
+		render.Render(w, r, UnauthorizedRenderer(errInvalidRefreshToken))
+		return
+	}
+	tokens, err := h.UserSessionController.Refresh(refreshToken, sessionDevice(r), h.UserController, h.TokenIssuer, time.Now(), r.Context())
+	if err != nil {
+		if err == repositories.ErrNoMatch || err == repositories.ErrRefreshTokenReused {
+			clearSessionCookies(w)
+			render.Render(w, r, UnauthorizedRenderer(errInvalidRefreshToken))
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	h.setSessionCookies(w, tokens)
+	utils.RenderJson(w, tokens)
+}
+
+func (h *UserHandler) getMySessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	principal, err := controllers.CurrentPrincipal(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	sessions, err := h.UserSessionController.GetSessions(principal.User.ID, principal.SessionID, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, sessions)
+}
+
+// Revokes a session of the logged in user, such as one of a lost device
+func (h *UserHandler) revokeMySession(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	principal, err := controllers.CurrentPrincipal(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(errors.New("invalid session ID")))
+		return
+	}
+	if err := h.UserSessionController.RevokeSession(sessionID, principal.User.ID, internalModels.SessionRevokedByUser, ctx); err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	if principal.SessionID == sessionID {
+		clearSessionCookies(w)
+	}
+	utils.RenderJson(w, success{Status: "success"})
+}
+
+// Revokes every session of a user, the current user is also logged out of this client
+func (h *UserHandler) revokeAllSessions(w http.ResponseWriter, r *http.Request, userID int, reason string, currentUser bool) error {
+	if _, err := h.UserSessionController.RevokeAllSessions(userID, reason, r.Context()); err != nil {
+		return err
+	}
+	if currentUser {
+		clearSessionCookies(w)
+	}
+	return nil
+}
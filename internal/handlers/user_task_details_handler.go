@@ -8,6 +8,9 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
 	"github.com/qthuy2k1/task-management-app/internal/utils"
 )
@@ -24,13 +27,29 @@ func UserTaskDetailContext(next http.Handler) http.Handler {
 		id, err := strconv.Atoi(userTaskDetailID)
 		if err != nil {
 			render.Render(w, r, ErrorRenderer(fmt.Errorf("invalid task category ID")))
+			return
 		}
 		ctx := context.WithValue(r.Context(), userTaskDetailIDKey, id)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// Checks that the current user may change the assignees of a task, and renders the error otherwise
+func (h *TaskHandler) authorizeAssignment(w http.ResponseWriter, r *http.Request, taskID int) bool {
+	actor, err := h.taskActor(r, taskID)
+	if err != nil {
+		renderAccessError(w, r, err)
+		return false
+	}
+	if err := controllers.AuthorizeTaskAssignment(actor); err != nil {
+		render.Render(w, r, ForbiddenRenderer(err))
+		return false
+	}
+	return true
+}
+
 func (h *TaskHandler) addUserTaskDetail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	err := r.ParseForm()
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
@@ -47,13 +66,10 @@ func (h *TaskHandler) addUserTaskDetail(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	if !h.authorizeAssignment(w, r, taskID) {
 		return
 	}
-	err = h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
+	if err := h.EmailVerificationController.CheckAssignable(userID, ctx); err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
@@ -68,6 +84,7 @@ func (h *TaskHandler) addUserTaskDetail(w http.ResponseWriter, r *http.Request)
 	utils.RenderJson(w, s)
 }
 func (h *TaskHandler) deleteUserFromTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	err := r.ParseForm()
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
@@ -83,9 +100,7 @@ func (h *TaskHandler) deleteUserFromTask(w http.ResponseWriter, r *http.Request)
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("invalid task id")))
 		return
 	}
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
+	if !h.authorizeAssignment(w, r, taskID) {
 		return
 	}
 
@@ -119,16 +134,32 @@ func (h *TaskHandler) getAllUserAsignnedToTask(w http.ResponseWriter, r *http.Re
 	utils.RenderJson(w, users)
 }
 
+// Gets the tasks assigned to a user, the tasks of the task categories the caller cannot see are left out
 func (h *UserHandler) getAllTaskAssignedToUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	userID, err := strconv.Atoi(chi.URLParam(r, "userID"))
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("invalid user id")))
 		return
 	}
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
 	tasks, err := h.UserTaskDetailController.GetAllTaskAssignedToUser(userID)
 	if err != nil {
 		render.Render(w, r, ServerErrorRenderer(err))
 		return
 	}
-	utils.RenderJson(w, tasks)
+	assigned := make(models.TaskSlice, 0, len(tasks))
+	for i := range tasks {
+		assigned = append(assigned, &tasks[i])
+	}
+	visible, err := h.TaskCategoryController.FilterVisibleTasks(assigned, user.ID, controllers.HasPermission(ctx, internalModels.PermissionTaskViewAll), ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, visible)
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -14,22 +15,49 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/qthuy2k1/task-management-app/internal/controllers"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/qthuy2k1/task-management-app/internal/repositories"
 	"github.com/qthuy2k1/task-management-app/internal/utils"
 )
 
 type UserHandler struct {
-	UserController           *controllers.UserController
-	UserTaskDetailController *controllers.UserTaskDetailController
+	UserController              *controllers.UserController
+	UserTaskDetailController    *controllers.UserTaskDetailController
+	TaskCategoryController      *controllers.TaskCategoryController
+	MentionController           *controllers.MentionController
+	NotificationController      *controllers.NotificationController
+	TokenIssuer                 *controllers.TokenIssuer
+	UserSessionController       *controllers.UserSessionController
+	AuthController              *controllers.AuthController
+	RoleController              *controllers.RoleController
+	PasswordResetController     *controllers.PasswordResetController
+	EmailVerificationController *controllers.EmailVerificationController
 }
 
-func NewUserHandler(database *repositories.Database) *UserHandler {
+func NewUserHandler(database *repositories.Database, tokenIssuer *controllers.TokenIssuer, userSessionController *controllers.UserSessionController, authController *controllers.AuthController, roleController *controllers.RoleController, passwordResetController *controllers.PasswordResetController, emailVerificationController *controllers.EmailVerificationController) *UserHandler {
 	userRepository := repositories.NewUserRepository(database)
 	userController := controllers.NewUserController(userRepository)
 	userTaskDetailRepository := repositories.NewUserTaskDetailRepository(database)
 	userTaskDetailController := controllers.NewUserTaskDetailController(userTaskDetailRepository)
-	return &UserHandler{UserController: userController, UserTaskDetailController: userTaskDetailController}
+	taskCategoryController := controllers.NewTaskCategoryController(repositories.NewTaskCategoryRepository(database))
+	mentionRepository := repositories.NewMentionRepository(database)
+	notificationRepository := repositories.NewNotificationRepository(database)
+	mentionController := controllers.NewMentionController(mentionRepository, notificationRepository, userRepository)
+	notificationController := controllers.NewNotificationController(notificationRepository)
+	return &UserHandler{
+		UserController:              userController,
+		UserTaskDetailController:    userTaskDetailController,
+		TaskCategoryController:      taskCategoryController,
+		MentionController:           mentionController,
+		NotificationController:      notificationController,
+		TokenIssuer:                 tokenIssuer,
+		UserSessionController:       userSessionController,
+		AuthController:              authController,
+		RoleController:              roleController,
+		PasswordResetController:     passwordResetController,
+		EmailVerificationController: emailVerificationController,
+	}
 }
 
 type success struct {
@@ -40,12 +68,19 @@ func (h *UserHandler) users(router chi.Router) {
 	router.Get("/", h.getAllUsers)
 	router.Post("/change-password", h.changeUserPassword)
 	router.Get("/profile", h.profileUser)
-	router.Get("/managers", h.getUsersManager)
+	router.With(RequirePermission(internalModels.PermissionUserManage)).Get("/managers", h.getUsersManager)
+	router.With(RequirePermission(internalModels.PermissionUserManage)).Get("/pending", h.getPendingUsers)
+	router.Get("/me/mentions", h.getMyMentions)
+	router.Route("/me/notifications", h.notifications)
+	router.Get("/me/permissions", h.getMyPermissions)
+	router.Route("/me/sessions", h.sessions)
+	router.Route("/me/api-keys", h.apiKeys)
 	router.Route("/{userID}", func(router chi.Router) {
 		router.Get("/", h.getUser)
 		router.Put("/", h.updateUser)
-		router.Patch("/update-role", h.updateRole)
-		router.Delete("/", h.deleteUser)
+		router.With(RequirePermission(internalModels.PermissionUserRoleAssign)).Patch("/update-role", h.updateRole)
+		router.With(RequirePermission(internalModels.PermissionUserManage)).Delete("/", h.deleteUser)
+		router.With(RequirePermission(internalModels.PermissionUserManage)).Post("/verify", h.verifyUser)
 		router.Post("/get-tasks", h.getAllTaskAssignedToUser)
 	})
 }
@@ -78,6 +113,7 @@ func (h *UserHandler) validateUserIDFromURLParam(r *http.Request) (int, error) {
 
 }
 func (h *UserHandler) getAllUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	users, err := h.UserController.GetAllUsers(ctx)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -87,6 +123,7 @@ func (h *UserHandler) getAllUsers(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) getUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	userID, err := h.validateUserIDFromURLParam(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -107,21 +144,18 @@ func (h *UserHandler) getUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) deleteUser(w http.ResponseWriter, r *http.Request) {
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
-		return
-	}
-	err := h.UserController.IsManager(ctx, r, tokenAuth)
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
 	userID, err := h.validateUserIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrBadRequest)
 		return
 	}
-	err = h.UserController.DeleteUser(userID, ctx, r, tokenAuth, token)
+	err = h.UserController.DeleteUser(userID, user.ID, ctx)
 
 	if err != nil {
 		render.Render(w, r, ErrNotFound)
@@ -134,11 +168,22 @@ func (h *UserHandler) deleteUser(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) updateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	currentUser, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
 	userID, err := h.validateUserIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrBadRequest)
 		return
 	}
+	// Users can only change their own account, unless they manage the users
+	if userID != currentUser.ID && !controllers.HasPermission(ctx, internalModels.PermissionUserManage) {
+		render.Render(w, r, ForbiddenRenderer(fmt.Errorf("you can only update your own account")))
+		return
+	}
 	userData := models.User{}
 
 	// Read request body into a []byte variable
@@ -152,40 +197,50 @@ func (h *UserHandler) updateUser(w http.ResponseWriter, r *http.Request) {
 	err = json.Unmarshal(body, &userData)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	if !h.isValidEmail(userData.Email) {
+		render.Render(w, r, ErrorRenderer(fmt.Errorf("your email is not valid, please provide a valid email")))
+		return
 	}
-	user, err := h.UserController.UpdateUser(userID, userData, ctx)
+	user, emailChanged, err := h.UserController.UpdateUser(userID, userData, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
+		} else if err == controllers.ErrEmailInUse {
+			render.Render(w, r, ErrorRenderer(err))
 		} else {
 			render.Render(w, r, ServerErrorRenderer(err))
 		}
 		return
 	}
+	// The new email is pending until the link queued to it is opened, like at signup
+	if emailChanged {
+		if err := h.EmailVerificationController.SendVerification(user, time.Now(), ctx); err != nil {
+			log.Printf("Could not send an email verification link: %v\n", err)
+		}
+	}
 	utils.RenderJson(w, user)
 }
 
 func (h *UserHandler) updateRole(w http.ResponseWriter, r *http.Request) {
-	err := h.UserController.IsManager(ctx, r, tokenAuth)
+	ctx := r.Context()
+	principal, err := controllers.CurrentPrincipal(ctx)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
+		return
 	}
 	role := r.URL.Query().Get("role")
 	if role == "" {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("invalid role")))
 		return
 	}
-
-	if role != "manager" && role != "user" {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("the role must be either 'manager' or 'user'")))
-		return
-	}
 	userID, err := h.validateUserIDFromURLParam(r)
 	if err != nil {
 		render.Render(w, r, ErrBadRequest)
 		return
 	}
-	user, err := h.UserController.UpdateRole(userID, role, ctx, r, tokenAuth)
+	user, err := h.UserController.GetUserByID(userID, ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
@@ -194,33 +249,55 @@ func (h *UserHandler) updateRole(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	if err := h.RoleController.CheckRoleAssignment(role, user, principal, ctx); err != nil {
+		if err == controllers.ErrRoleAssignmentForbidden {
+			render.Render(w, r, ForbiddenRenderer(err))
+		} else {
+			render.Render(w, r, ErrorRenderer(err))
+		}
+		return
+	}
+	user, err = h.UserController.UpdateRole(userID, role, ctx)
+	if err != nil {
+		if err == repositories.ErrNoMatch {
+			render.Render(w, r, ErrNotFound)
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	// The tokens of the user carry their old role
+	if err := h.revokeAllSessions(w, r, userID, internalModels.SessionRevokedRoleChange, false); err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
 	utils.RenderJson(w, user)
 }
 
 func (h *UserHandler) changeUserPassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	err := r.ParseForm()
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		return
 	}
 	oldPassword := r.PostForm.Get("oldPassword")
 	newPassword := r.PostForm.Get("newPassword")
-	token := GetToken(r, tokenAuth)
-
-	email, ok := token.Get("email")
-	if !ok {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("cannot get the email from token")))
-	}
-	// Convert email from interface{} to string
-	emailStr, ok := email.(string)
-	if !ok {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("cannot convert email from interface to string")))
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-	err = h.UserController.ChangeUserPassword(oldPassword, newPassword, emailStr, ctx, r, tokenAuth)
+	err = h.UserController.ChangeUserPassword(oldPassword, newPassword, user.Email, ctx)
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
+	// Whoever knew the old password may hold a session, so every session has to log in again
+	if err := h.revokeAllSessions(w, r, user.ID, internalModels.SessionRevokedPasswordChange, true); err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
 	s := success{
 		Status: "success",
 	}
@@ -228,15 +305,17 @@ func (h *UserHandler) changeUserPassword(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *UserHandler) signup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	err := r.ParseForm()
 	if err != nil {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("failed to parse form data")))
+		return
 	}
 	user := models.User{}
 	user.Name = r.PostForm.Get("name")
 	user.Email = r.PostForm.Get("email")
 	user.Password = r.PostForm.Get("password")
-	user.Role = "user"
+	user.Role = internalModels.RoleUser
 
 	if user.Name == "" {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("missing name")))
@@ -255,22 +334,28 @@ func (h *UserHandler) signup(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token := MakeToken(user.Email, user.Password)
-
-	http.SetCookie(w, &http.Cookie{
-		HttpOnly: true,
-		Expires:  time.Now().Add(7 * 24 * time.Hour),
-		SameSite: http.SameSiteLaxMode,
-		// Uncomment below for HTTPS:
-		// Secure: true,
-		Name:  "jwt", // Must be named "jwt" or else the token cannot be searched for by jwtauth.Verifier.
-		Value: token,
-	})
 	r = r.WithContext(ctx)
 	if err := h.UserController.AddUser(&user, ctx); err != nil {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
+	// The account is created even when the link cannot be sent, a new one can be asked for
+	if err := h.EmailVerificationController.SendVerification(&user, time.Now(), ctx); err != nil {
+		log.Printf("Could not send an email verification link: %v\n", err)
+	}
+	if err := h.EmailVerificationController.CheckLogin(user.ID, ctx); err != nil {
+		if !errors.Is(err, repositories.ErrEmailNotVerified) {
+			render.Render(w, r, ServerErrorRenderer(err))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("Sign up successful, please verify your email before logging in"))
+		return
+	}
+	if _, err := h.startSession(w, r, &user); err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
 	_, err = json.Marshal(user)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -282,6 +367,7 @@ func (h *UserHandler) signup(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *UserHandler) login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 	err := r.ParseForm()
 	if err != nil {
 		http.Error(w, "Failed to parse form data.", http.StatusInternalServerError)
@@ -302,52 +388,55 @@ func (h *UserHandler) login(w http.ResponseWriter, r *http.Request) {
 		render.Render(w, r, ErrorRenderer(fmt.Errorf("your password is not valid, please provide a valid password that contains at least 6 characters")))
 		return
 	}
-	// Generate a JWT token using the email and password
-	token := MakeToken(email, password)
-
 	// Check if the email and password are valid
 	ok, err := h.UserController.CompareEmailAndPassword(email, password, ctx)
 	if !ok {
 		render.Render(w, r, ErrorRenderer(err))
 		return
 	}
-
-	// Set the JWT token as a cookie in the response
-	http.SetCookie(w, &http.Cookie{
-		HttpOnly: true,
-		Expires:  time.Now().Add(7 * 24 * time.Hour),
-		SameSite: http.SameSiteLaxMode,
-		// Uncomment below for HTTPS:
-		// Secure: true,
-		Name:  "jwt", // Must be named "jwt" or else the token cannot be searched for by jwtauth.Verifier.
-		Value: token,
-	})
+	user, err := h.UserController.GetUserByEmail(email, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	if err := h.EmailVerificationController.CheckLogin(user.ID, ctx); err != nil {
+		if errors.Is(err, repositories.ErrEmailNotVerified) {
+			render.Render(w, r, ForbiddenRenderer(err))
+		} else {
+			render.Render(w, r, ServerErrorRenderer(err))
+		}
+		return
+	}
+	// Start a session and set its access and refresh tokens as cookies in the response
+	if _, err := h.startSession(w, r, user); err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
 	// Write the response with a success message
 	w.Write([]byte(fmt.Sprintf(`Login successful, your email is: %s`, email)))
 }
 
+// Revokes the session of the access token, or of the refresh token when the access token expired, so
+// copies of the tokens stop working too
 func (h *UserHandler) logout(w http.ResponseWriter, r *http.Request) {
-	http.SetCookie(w, &http.Cookie{
-		HttpOnly: true,
-		MaxAge:   -1, // Delete the cookie.
-		SameSite: http.SameSiteLaxMode,
-		// Uncomment below for HTTPS:
-		// Secure: true,
-		Name:  "jwt",
-		Value: "",
-	})
+	ctx := r.Context()
+	var err error
+	if principal, authErr := controllers.CurrentPrincipal(ctx); authErr == nil && principal.SessionID != 0 {
+		err = h.UserSessionController.RevokeSession(principal.SessionID, principal.User.ID, internalModels.SessionRevokedLogout, ctx)
+	} else if refreshToken := refreshTokenFromRequest(r); refreshToken != "" {
+		err = h.UserSessionController.RevokeRefreshToken(refreshToken, internalModels.SessionRevokedLogout, ctx)
+	}
+	if err != nil && err != repositories.ErrNoMatch {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	clearSessionCookies(w)
 	w.Write([]byte(`Logout successful`))
 }
 
 func (h *UserHandler) profileUser(w http.ResponseWriter, r *http.Request) {
-	token := GetToken(r, tokenAuth)
-	if token == nil {
-		render.Render(w, r, ErrorRenderer(fmt.Errorf("no token found")))
-		return
-	}
-	userEmail, _ := token.Get("email")
-
-	user, err := h.UserController.GetUserByEmail(userEmail.(string), ctx)
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
 	if err != nil {
 		if err == repositories.ErrNoMatch {
 			render.Render(w, r, ErrNotFound)
@@ -359,6 +448,35 @@ func (h *UserHandler) profileUser(w http.ResponseWriter, r *http.Request) {
 	utils.RenderJson(w, user)
 }
 
+func (h *UserHandler) getMyMentions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, err := controllers.CurrentUser(ctx)
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	mentions, err := h.MentionController.GetMentionsOfUser(user.ID, ctx)
+	if err != nil {
+		render.Render(w, r, ServerErrorRenderer(err))
+		return
+	}
+	utils.RenderJson(w, mentions)
+}
+
+// Reports the permissions the role of the logged in user holds
+func (h *UserHandler) getMyPermissions(w http.ResponseWriter, r *http.Request) {
+	principal, err := controllers.CurrentPrincipal(r.Context())
+	if err != nil {
+		render.Render(w, r, ErrorRenderer(err))
+		return
+	}
+	permissions := internalModels.UserPermissions{Role: principal.User.Role, Permissions: principal.Permissions}
+	if permissions.Permissions == nil {
+		permissions.Permissions = []internalModels.Permission{}
+	}
+	utils.RenderJson(w, permissions)
+}
+
 // Validates that an email address is in a valid format
 func (h *UserHandler) isValidEmail(email string) bool {
 	if email == "" {
@@ -381,10 +499,7 @@ func (h *UserHandler) isValidPassword(password string) bool {
 }
 
 func (h *UserHandler) getUsersManager(w http.ResponseWriter, r *http.Request) {
-	err := h.UserController.IsManager(ctx, r, tokenAuth)
-	if err != nil {
-		render.Render(w, r, ErrorRenderer(err))
-	}
+	ctx := r.Context()
 	users, err := h.UserController.GetUsersManager(ctx)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
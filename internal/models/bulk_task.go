@@ -0,0 +1,55 @@
+package models
+
+// Operations that can be run on many tasks at once
+const (
+	BulkSetStatus      = "set_status"
+	BulkSetCategory    = "set_category"
+	BulkAddAssignee    = "add_assignee"
+	BulkRemoveAssignee = "remove_assignee"
+	BulkLock           = "lock"
+	BulkUnlock         = "unlock"
+	BulkDelete         = "delete"
+)
+
+// Modes of a bulk request: either every task is updated or none is, or the failing tasks are skipped
+const (
+	BulkModeAllOrNothing = "all_or_nothing"
+	BulkModeBestEffort   = "best_effort"
+)
+
+// Result status of a single task in a bulk request
+const (
+	BulkResultSuccess    = "success"
+	BulkResultFailed     = "failed"
+	BulkResultRolledBack = "rolled_back"
+)
+
+type BulkTaskOperation struct {
+	Type           string `json:"type"`
+	Status         string `json:"status,omitempty"`
+	TaskCategoryID int    `json:"task_category_id,omitempty"`
+	UserID         int    `json:"user_id,omitempty"`
+}
+
+// A bulk request selects tasks either by their IDs or by a filter using the same fields as GET /tasks
+type BulkTaskRequest struct {
+	TaskIDs   []int             `json:"task_ids"`
+	Filter    map[string]string `json:"filter"`
+	Operation BulkTaskOperation `json:"operation"`
+	Mode      string            `json:"mode"`
+}
+
+type BulkTaskResult struct {
+	TaskID int    `json:"task_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkTaskReport struct {
+	Operation string           `json:"operation"`
+	Mode      string           `json:"mode"`
+	Committed bool             `json:"committed"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkTaskResult `json:"results"`
+}
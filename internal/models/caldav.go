@@ -0,0 +1,23 @@
+package models
+
+import (
+	gen "github.com/qthuy2k1/task-management-app/internal/models/gen"
+)
+
+// The name and UID a CalDAV client chose for a task it created in a calendar, the task has the default UID
+// when the client did not give one
+type CalDAVResource struct {
+	TaskID         int
+	TaskCategoryID int
+	Name           string
+	UID            string
+}
+
+// A task served as a VTODO resource of a CalDAV calendar
+type CalDAVObject struct {
+	Name string
+	UID  string
+	Task *gen.Task
+	Data string
+	ETag string
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// Components a task can be rendered as in a calendar feed
+const (
+	CalendarComponentTodo  = "VTODO"
+	CalendarComponentEvent = "VEVENT"
+)
+
+// A secret URL that serves the tasks of a user, or of a task category for managers, as an iCalendar file.
+// Only a hash of the token is stored, so the token and URL are only known when the feed is created.
+type CalendarFeed struct {
+	ID             int       `json:"id"`
+	UserID         int       `json:"user_id"`
+	TaskCategoryID null.Int  `json:"task_category_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	RevokedAt      null.Time `json:"revoked_at"`
+	Token          string    `json:"token,omitempty"`
+	URL            string    `json:"url,omitempty"`
+}
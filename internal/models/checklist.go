@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+type ChecklistItem struct {
+	ID         int       `json:"id"`
+	TaskID     int       `json:"task_id"`
+	Position   int       `json:"position"`
+	Text       string    `json:"text"`
+	Done       bool      `json:"done"`
+	AssigneeID null.Int  `json:"assignee_id"`
+	DueDate    null.Time `json:"due_date"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ChecklistProgress struct {
+	Total int
+	Done  int
+}
+
+// Percentage of done items rounded down, 0 when the task has no checklist
+func (p ChecklistProgress) Percentage() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+type ChecklistReorder struct {
+	ItemIDs []int `json:"item_ids"`
+}
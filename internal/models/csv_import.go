@@ -0,0 +1,49 @@
+package models
+
+// Modes of a CSV import
+const (
+	// Every row is a new record
+	ImportModeCreate = "create"
+	// Rows are matched to the records of the same external source by their external ID
+	ImportModeUpsert = "upsert"
+)
+
+// A problem found in a row of an imported CSV file, rows are numbered from 1 without the header
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// The outcome of a CSV import. Nothing is written when a row has an error or when it is a dry run,
+// the counts of a dry run are what the import would write.
+type ImportReport struct {
+	DryRun    bool             `json:"dry_run"`
+	Committed bool             `json:"committed"`
+	TotalRows int              `json:"total_rows"`
+	ValidRows int              `json:"valid_rows"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Unchanged int              `json:"unchanged"`
+	Archived  int              `json:"archived"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// Options of a CSV import. The mapping gives the header of the column to read for a field,
+// fields that are not in the mapping are read from the column with the same name.
+// An upsert needs the external source of the file, and can archive the tasks of that
+// source that are not in the file.
+type ImportOptions struct {
+	Mapping        map[string]string `json:"mapping"`
+	DryRun         bool              `json:"dry_run"`
+	Mode           string            `json:"mode"`
+	ExternalSource string            `json:"external_source"`
+	ArchiveMissing bool              `json:"archive_missing"`
+}
+
+// Payload of a job running an import in the background
+type ImportJob struct {
+	Data       string        `json:"data"`
+	Options    ImportOptions `json:"options"`
+	ImporterID int           `json:"importer_id"`
+}
@@ -0,0 +1,34 @@
+package models
+
+// Number of tasks of a task category
+type CategoryTaskCount struct {
+	TaskCategoryID int    `json:"task_category_id"`
+	Name           string `json:"name"`
+	Count          int    `json:"count"`
+}
+
+// A user and the number of open tasks assigned to them
+type AssigneeWorkload struct {
+	UserID    int    `json:"user_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	OpenTasks int    `json:"open_tasks"`
+}
+
+// Counts of the tasks of a dashboard scope. Open tasks are the tasks that are not complete, overdue
+// and due this week only count open tasks.
+type TaskSummary struct {
+	Total        int                 `json:"total"`
+	ByStatus     map[string]int      `json:"by_status"`
+	ByCategory   []CategoryTaskCount `json:"by_category"`
+	Overdue      int                 `json:"overdue"`
+	DueThisWeek  int                 `json:"due_this_week"`
+	Unassigned   int                 `json:"unassigned"`
+	TopAssignees []AssigneeWorkload  `json:"top_assignees"`
+}
+
+// The summary of the tasks of the caller, and of all tasks for managers
+type Dashboard struct {
+	Me     TaskSummary  `json:"me"`
+	Global *TaskSummary `json:"global,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// What the users who have not verified their email are kept from doing
+type EmailVerificationPolicy string
+
+const (
+	// Unverified users can log in, but cannot be assigned tasks
+	EmailVerificationRestrictAssignment EmailVerificationPolicy = "restrict_assignment"
+	// Unverified users cannot log in either
+	EmailVerificationBlockLogin EmailVerificationPolicy = "block_login"
+)
+
+// A user who has not verified their email, as listed to the managers
+type PendingUser struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
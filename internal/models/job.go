@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// Statuses of a job
+const (
+	// Waiting for a worker, also after a failed attempt that will be retried
+	JobQueued = "queued"
+	// Claimed by a worker
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	// Failed every attempt, the job stays in the table until a manager retries it
+	JobDead = "dead"
+)
+
+type Job struct {
+	ID             int             `json:"id"`
+	Type           string          `json:"type"`
+	Payload        json.RawMessage `json:"-"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	TimeoutSeconds int             `json:"timeout_seconds"`
+	RunAt          time.Time       `json:"run_at"`
+	StartedAt      null.Time       `json:"started_at"`
+	Progress       int             `json:"progress"`
+	Result         json.RawMessage `json:"result,omitempty"`
+	LastError      null.String     `json:"last_error"`
+	CreatedBy      null.Int        `json:"created_by"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	FinishedAt     null.Time       `json:"finished_at"`
+}
+
+// A job to add to the queue, the limits of the job type are used when they are not set
+type NewJob struct {
+	Type        string
+	Payload     interface{}
+	MaxAttempts int
+	Timeout     time.Duration
+	RunAt       time.Time
+	CreatedBy   null.Int
+}
+
+// Payload of the recurring job archiving the tasks completed more than AfterDays days ago
+type ArchiveCompletedTasksJob struct {
+	AfterDays int `json:"after_days"`
+}
+
+// Payload of the recurring job purging the items that have been in the trash for more than RetentionDays days
+type PurgeTrashJob struct {
+	RetentionDays int `json:"retention_days"`
+}
+
+// Payload of the job sending a plain text email
+type SendMailJob struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// Places where a user can be mentioned
+const (
+	MentionSourceDescription = "description"
+)
+
+// A record of a user being mentioned in a task
+type Mention struct {
+	ID          int       `json:"id"`
+	TaskID      int       `json:"task_id"`
+	TaskName    string    `json:"task_name"`
+	UserID      int       `json:"user_id"`
+	MentionedBy null.Int  `json:"mentioned_by"`
+	Source      string    `json:"source"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// The outcome of resolving the mentions of a text, handles are written without the leading @
+type MentionReport struct {
+	Mentioned []string `json:"mentioned"`
+	Unknown   []string `json:"unknown"`
+}
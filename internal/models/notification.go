@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// Types of notifications
+const (
+	NotificationMention   = "mention"
+	NotificationSLABreach = "sla_breach"
+)
+
+type Notification struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Type      string    `json:"type"`
+	TaskID    null.Int  `json:"task_id"`
+	Message   string    `json:"message"`
+	ReadAt    null.Time `json:"read_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	gen "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+// How the user of a request was authenticated
+const (
+	AuthMethodToken  = "token"
+	AuthMethodAPIKey = "api_key"
+	AuthMethodBasic  = "basic"
+)
+
+// The user a request is made by, resolved once by the authentication middleware
+type Principal struct {
+	User   *gen.User
+	Method string
+	// Set when the request carries an access token
+	SessionID int
+	// Set when the request carries an API key
+	APIKeyID int
+	// The permissions of the role of the user
+	Permissions []Permission
+}
+
+// A key that scripts and integrations send in the X-API-Key header to act as a user. Only a hash of the
+// key is stored, so the key is only known when it is created.
+type APIKey struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt null.Time `json:"last_used_at"`
+	Key        string    `json:"key,omitempty"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// Number of tasks in a status at the end of a day, read from the status transitions
+type DailyStatusCount struct {
+	Date   time.Time
+	Status string
+	Count  int
+}
+
+// Tasks of the report scope and the ones not completed yet at the end of a day
+type BurndownPoint struct {
+	Date  string `json:"date"`
+	Total int    `json:"total"`
+	Open  int    `json:"open"`
+}
+
+// Tasks completed in the week starting on a Monday
+type ThroughputPoint struct {
+	WeekStart string `json:"week_start"`
+	Completed int    `json:"completed"`
+}
+
+// Number of tasks in every status at the end of a day
+type CumulativeFlowPoint struct {
+	Date     string         `json:"date"`
+	Statuses map[string]int `json:"statuses"`
+}
+
+// Scope of a report, days are inclusive and a zero task category covers every category
+type ReportRange struct {
+	From           time.Time
+	To             time.Time
+	TaskCategoryID int
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// Something a role allows on the whole app, whatever the task categories the user belongs to
+type Permission string
+
+const (
+	// See every task category and task, including the private ones
+	PermissionTaskViewAll Permission = "task.view_all"
+	// Create, change, archive and assign the tasks of every category, and import or bulk update tasks
+	PermissionTaskManage Permission = "task.manage"
+	// Delete the tasks of every category
+	PermissionTaskDelete Permission = "task.delete"
+	// Lock and unlock the tasks of every category
+	PermissionTaskLock Permission = "task.lock"
+	// Create top level task categories, and change, move, merge, import and delete every category
+	PermissionCategoryManage Permission = "category.manage"
+	PermissionTemplateManage Permission = "template.manage"
+	PermissionTrashManage    Permission = "trash.manage"
+	PermissionJobManage      Permission = "job.manage"
+	PermissionReportView     Permission = "report.view"
+	// Delete users and list the managers
+	PermissionUserManage Permission = "user.manage"
+	// Change the role of a user
+	PermissionUserRoleAssign Permission = "user.role.assign"
+	// Create, change and delete the custom roles
+	PermissionRoleManage Permission = "role.manage"
+)
+
+// Every permission, in the order they are listed
+var Permissions = []Permission{
+	PermissionTaskViewAll,
+	PermissionTaskManage,
+	PermissionTaskDelete,
+	PermissionTaskLock,
+	PermissionCategoryManage,
+	PermissionTemplateManage,
+	PermissionTrashManage,
+	PermissionJobManage,
+	PermissionReportView,
+	PermissionUserManage,
+	PermissionUserRoleAssign,
+	PermissionRoleManage,
+}
+
+// The built-in roles, managers hold every permission and users none
+const (
+	RoleManager = "manager"
+	RoleUser    = "user"
+)
+
+// A named set of permissions, the role of a user is stored by name
+type Role struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Permissions []Permission `json:"permissions"`
+	// Built-in roles cannot be changed or deleted
+	BuiltIn   bool      `json:"built_in"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// The permissions the role of a user holds
+type UserPermissions struct {
+	Role        string       `json:"role"`
+	Permissions []Permission `json:"permissions"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// The relation of a user to a task, which decides what they may change in it
+type TaskActor struct {
+	UserID int
+	// Manages the task, as an owner of its category or with a permission of their role
+	Manager bool
+	// May lock and unlock the task, as an owner of its category or with the task.lock permission
+	Locker bool
+	// Authored the task
+	Author bool
+	// Is assigned to the task
+	Assignee bool
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Visibility of a task category
+const (
+	TaskCategoryVisibilityPublic  = "public"
+	TaskCategoryVisibilityPrivate = "private"
+)
+
+// Roles of the members of a task category. Owners act as managers of the category.
+const (
+	TaskCategoryRoleOwner  = "owner"
+	TaskCategoryRoleMember = "member"
+)
+
+// What a user wants to do with a task category and its tasks
+type TaskCategoryPermission string
+
+const (
+	// See the category and work on its tasks
+	TaskCategoryView TaskCategoryPermission = "view"
+	// Change the category and run the manager operations on its tasks
+	TaskCategoryManage TaskCategoryPermission = "manage"
+)
+
+// The visibility of a task category and the role of a user in it, empty when the user is not a member
+type TaskCategoryAccess struct {
+	TaskCategoryID int
+	Visibility     string
+	Role           string
+}
+
+// A member of a task category
+type TaskCategoryMember struct {
+	TaskCategoryID int       `json:"task_category_id"`
+	UserID         int       `json:"user_id"`
+	Role           string    `json:"role"`
+	CreatedAt      time.Time `json:"created_at"`
+}
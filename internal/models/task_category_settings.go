@@ -0,0 +1,25 @@
+package models
+
+import "github.com/volatiletech/null/v8"
+
+// Options of a task category that change how its tasks behave
+type TaskCategorySettings struct {
+	TaskCategoryID             int       `json:"task_category_id"`
+	RequireChecklistCompletion bool      `json:"require_checklist_completion"`
+	SLA                        SLAPolicy `json:"sla"`
+	Visibility                 string    `json:"visibility"`
+}
+
+// Response times of the tasks of a category. A task must leave the not started status within the
+// start hours of its creation and be complete within the business hours of its creation. A breach
+// is escalated to the managers and the task is assigned to the escalation user when there is one.
+type SLAPolicy struct {
+	StartWithinHours            null.Int `json:"start_within_hours"`
+	CompleteWithinBusinessHours null.Int `json:"complete_within_business_hours"`
+	EscalateToUserID            null.Int `json:"escalate_to_user_id"`
+}
+
+// Checks if the policy sets a response time
+func (p SLAPolicy) IsSet() bool {
+	return p.StartWithinHours.Valid || p.CompleteWithinBusinessHours.Valid
+}
@@ -0,0 +1,58 @@
+package models
+
+import "github.com/volatiletech/null/v8"
+
+// A task category in the category tree. The path joins the names from the root category,
+// and root categories have a depth of 1.
+type TaskCategoryNode struct {
+	ID       int                 `json:"id"`
+	Name     string              `json:"name"`
+	ParentID null.Int            `json:"parent_id"`
+	Path     string              `json:"path"`
+	Depth    int                 `json:"depth"`
+	Children []*TaskCategoryNode `json:"children"`
+}
+
+// Request to move a task category under another one, or to the root when the parent is null
+type TaskCategoryMove struct {
+	ParentID null.Int `json:"parent_id"`
+}
+
+// Request to merge a task category into another one
+type TaskCategoryMerge struct {
+	TargetID int `json:"target_id"`
+}
+
+// The outcome of a merge of task categories
+type TaskCategoryMergeResult struct {
+	SourceID   int   `json:"source_id"`
+	TargetID   int   `json:"target_id"`
+	MovedTasks int64 `json:"moved_tasks"`
+}
+
+// How the tasks of a deleted task category are handled. The tasks are moved to the category
+// ReassignTo, or moved to the trash with Cascade once ConfirmedTasksDigest matches the digest of
+// their IDs, which is signed in the confirmation token of the deletion.
+type TaskCategoryDeletePolicy struct {
+	ReassignTo           null.Int
+	Cascade              bool
+	ConfirmedTasksDigest string
+}
+
+// The outcome of a deletion of a task category
+type TaskCategoryDeleteResult struct {
+	Status          string   `json:"status"`
+	TaskCategoryID  int      `json:"task_category_id"`
+	ReassignedTo    null.Int `json:"reassigned_to"`
+	ReassignedTasks int64    `json:"reassigned_tasks"`
+	DeletedTasks    int64    `json:"deleted_tasks"`
+}
+
+// Response to a deletion refused because the task category still has tasks. The confirmation
+// token is given to a cascade deletion and must be sent back to delete the tasks.
+type TaskCategoryDeleteConflict struct {
+	StatusText        string `json:"status_text"`
+	Message           string `json:"message"`
+	AffectedTasks     int64  `json:"affected_tasks"`
+	ConfirmationToken string `json:"confirmation_token,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	gen "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+// A task together with the values computed from its related records
+type TaskDetail struct {
+	*gen.Task
+	ChecklistTotal      int `json:"checklist_total"`
+	ChecklistDone       int `json:"checklist_done"`
+	ChecklistCompletion int `json:"checklist_completion"`
+	// State of the task against the SLA policy of its category
+	SLAStatus           string     `json:"sla_status"`
+	SLADueAt            null.Time  `json:"sla_due_at,omitzero"`
+	SLARemainingSeconds null.Int64 `json:"sla_remaining_seconds,omitzero"`
+	// Only set in the response of a create or an update of the task
+	Mentions *MentionReport `json:"mentions,omitempty"`
+}
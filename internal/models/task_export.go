@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// Formats of a task export
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatJSON   = "json"
+)
+
+// A task with the name of its category and its assignees, as written by an export
+type TaskExport struct {
+	ID               int         `json:"id"`
+	Name             string      `json:"name"`
+	Description      string      `json:"description"`
+	StartDate        time.Time   `json:"start_date"`
+	EndDate          time.Time   `json:"end_date"`
+	Status           null.String `json:"status"`
+	AuthorID         int         `json:"author_id"`
+	TaskCategoryID   int         `json:"task_category_id"`
+	TaskCategoryName string      `json:"task_category_name"`
+	AssigneeIDs      []int       `json:"assignee_ids"`
+	AssigneeEmails   []string    `json:"assignee_emails"`
+	ExternalSource   null.String `json:"external_source"`
+	ExternalID       null.String `json:"external_id"`
+	ArchivedAt       null.Time   `json:"archived_at"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// States of a task against the SLA policy of its category
+const (
+	// The category has no SLA policy
+	SLAStatusNone    = "none"
+	SLAStatusOnTrack = "on_track"
+	// Less than a quarter of the time to the next deadline is left
+	SLAStatusAtRisk   = "at_risk"
+	SLAStatusBreached = "breached"
+	// Every deadline was met
+	SLAStatusMet = "met"
+)
+
+// Deadlines of an SLA policy
+const (
+	SLABreachStart    = "start"
+	SLABreachComplete = "complete"
+)
+
+// The SLA policy of the category of a task and the times the task was started and completed
+type TaskSLAState struct {
+	TaskID    int
+	CreatedAt time.Time
+	Policy    SLAPolicy
+	// The first time the task left the not started status
+	StartedAt null.Time
+	// The last time the task was completed, only set when it is still complete
+	CompletedAt null.Time
+}
+
+// The state of a task against its SLA policy. The due time is the next deadline of an open task,
+// the remaining time is negative once it has passed.
+type TaskSLA struct {
+	Status           string
+	DueAt            null.Time
+	RemainingSeconds null.Int64
+	// Deadlines that were missed
+	Breaches []string
+}
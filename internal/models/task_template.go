@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	gen "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
+)
+
+// A task template describes a task that is created again and again, such as an onboarding checklist.
+// Names and descriptions may contain {{placeholders}} that are replaced when the template is instantiated,
+// and dates are given as offsets in hours from the anchor date of the instantiation.
+type TaskTemplate struct {
+	ID               int                   `json:"id"`
+	Name             string                `json:"name"`
+	TaskName         string                `json:"task_name"`
+	TaskDescription  string                `json:"task_description"`
+	TaskCategoryID   null.Int              `json:"task_category_id"`
+	StartOffsetHours int                   `json:"start_offset_hours"`
+	EndOffsetHours   int                   `json:"end_offset_hours"`
+	AssigneeIDs      []int                 `json:"assignee_ids"`
+	Subtasks         []TaskTemplateSubtask `json:"subtasks"`
+	CreatedBy        null.Int              `json:"created_by"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+// Blueprint of an extra task created alongside the main task of a template
+type TaskTemplateSubtask struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	StartOffsetHours int    `json:"start_offset_hours"`
+	EndOffsetHours   int    `json:"end_offset_hours"`
+	AssigneeIDs      []int  `json:"assignee_ids"`
+}
+
+type TaskTemplateInstantiation struct {
+	AnchorDate     time.Time         `json:"anchor_date"`
+	Variables      map[string]string `json:"variables"`
+	TaskCategoryID int               `json:"task_category_id"`
+	AssigneeIDs    []int             `json:"assignee_ids"`
+}
+
+type TaskTemplateInstance struct {
+	Task     *gen.Task     `json:"task"`
+	Subtasks gen.TaskSlice `json:"subtasks"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/volatiletech/null/v8"
+)
+
+// Kinds of items that can be found in the trash
+const (
+	TrashTypeTask         = "task"
+	TrashTypeTaskCategory = "task_category"
+	TrashTypeUser         = "user"
+)
+
+type TrashItem struct {
+	ID        int       `json:"id"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	DeletedBy null.Int  `json:"deleted_by"`
+}
+
+type Trash struct {
+	Tasks          []TrashItem `json:"tasks"`
+	TaskCategories []TrashItem `json:"task_categories"`
+	Users          []TrashItem `json:"users"`
+}
+
+// Result of purging the expired items of the trash. Categories and users that tasks still reference are
+// kept in the trash and reported as blocked.
+type PurgeReport struct {
+	Purged  int64       `json:"purged"`
+	Blocked []TrashItem `json:"blocked"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// Reasons a session was revoked for
+const (
+	SessionRevokedLogout         = "logout"
+	SessionRevokedByUser         = "revoked"
+	SessionRevokedPasswordChange = "password_change"
+	SessionRevokedPasswordReset  = "password_reset"
+	SessionRevokedRoleChange     = "role_change"
+	SessionRevokedRefreshReuse   = "refresh_token_reuse"
+)
+
+// A login of a user on a device. Its access tokens are short lived and renewed with a refresh token, which
+// changes at every renewal. Only hashes of the refresh tokens are stored.
+type UserSession struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Whether the session is the one of the request
+	Current bool `json:"current"`
+}
+
+// The tokens given by a login or a refresh, the refresh token cannot be retrieved again
+type SessionTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	// Seconds before the access token expires
+	ExpiresIn int `json:"expires_in"`
+	SessionID int `json:"session_id"`
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type APIKeyRepository struct {
+	Database *Database
+}
+
+func NewAPIKeyRepository(database *Database) *APIKeyRepository {
+	return &APIKeyRepository{Database: database}
+}
+
+const apiKeyColumns = `id, user_id, name, created_at, last_used_at`
+
+func scanAPIKey(row rowScanner) (models.APIKey, error) {
+	var apiKey models.APIKey
+	err := row.Scan(&apiKey.ID, &apiKey.UserID, &apiKey.Name, &apiKey.CreatedAt, &apiKey.LastUsedAt)
+	return apiKey, err
+}
+
+// Adds an API key identified by the hash of the key
+func (re *APIKeyRepository) AddAPIKey(apiKey models.APIKey, keyHash string, ctx context.Context) (models.APIKey, error) {
+	query := `INSERT INTO api_keys(key_hash, user_id, name) VALUES($1, $2, $3) RETURNING ` + apiKeyColumns + `;`
+	return scanAPIKey(re.Database.Conn.QueryRowContext(ctx, query, keyHash, apiKey.UserID, apiKey.Name))
+}
+
+// Records the use of the key of a hash and returns it, if it is not revoked and its owner is not deleted
+func (re *APIKeyRepository) UseAPIKey(keyHash string, ctx context.Context) (models.APIKey, error) {
+	query := `UPDATE api_keys k SET last_used_at=NOW()
+		FROM users u
+		WHERE k.key_hash=$1 AND k.revoked_at IS NULL AND u.id=k.user_id AND u.deleted_at IS NULL
+		RETURNING k.id, k.user_id, k.name, k.created_at, k.last_used_at;`
+	apiKey, err := scanAPIKey(re.Database.Conn.QueryRowContext(ctx, query, keyHash))
+	if err == sql.ErrNoRows {
+		return apiKey, ErrNoMatch
+	}
+	return apiKey, err
+}
+
+// Gets the keys of a user that are not revoked
+func (re *APIKeyRepository) GetAPIKeysOfUser(userID int, ctx context.Context) ([]models.APIKey, error) {
+	list := []models.APIKey{}
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE user_id=$1 AND revoked_at IS NULL ORDER BY id;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, apiKey)
+	}
+	return list, rows.Err()
+}
+
+// Revokes a key of a user, the key stops working immediately
+func (re *APIKeyRepository) RevokeAPIKey(apiKeyID, userID int, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, `UPDATE api_keys SET revoked_at=NOW() WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL;`, apiKeyID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+)
+
+type CalDAVRepository struct {
+	Database *Database
+}
+
+func NewCalDAVRepository(database *Database) *CalDAVRepository {
+	return &CalDAVRepository{Database: database}
+}
+
+// Gets the resources the given tasks have in a calendar by task ID, tasks created outside of CalDAV or in
+// another calendar have none
+func (re *CalDAVRepository) GetCalDAVResources(taskCategoryID int, taskIDs []int, ctx context.Context) (map[int]internalModels.CalDAVResource, error) {
+	resources := make(map[int]internalModels.CalDAVResource)
+	if len(taskIDs) == 0 {
+		return resources, nil
+	}
+	query := `SELECT task_id, task_category_id, name, uid FROM caldav_resources WHERE task_category_id=$1 AND task_id = ANY($2);`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, taskCategoryID, toInt64Array(taskIDs))
+	if err != nil {
+		return resources, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resource internalModels.CalDAVResource
+		if err := rows.Scan(&resource.TaskID, &resource.TaskCategoryID, &resource.Name, &resource.UID); err != nil {
+			return resources, err
+		}
+		resources[resource.TaskID] = resource
+	}
+	return resources, rows.Err()
+}
+
+func (re *CalDAVRepository) GetCalDAVResourceByName(taskCategoryID int, name string, ctx context.Context) (internalModels.CalDAVResource, error) {
+	var resource internalModels.CalDAVResource
+	query := `SELECT task_id, task_category_id, name, uid FROM caldav_resources WHERE task_category_id=$1 AND name=$2;`
+	err := re.Database.Conn.QueryRowContext(ctx, query, taskCategoryID, name).Scan(&resource.TaskID, &resource.TaskCategoryID, &resource.Name, &resource.UID)
+	if err == sql.ErrNoRows {
+		return resource, ErrNoMatch
+	}
+	return resource, err
+}
+
+// Adds a task created by a CalDAV client, assigns its author to it and records its resource in the calendar
+// of the task, all in a single transaction
+func (re *CalDAVRepository) AddCalDAVTask(task *models.Task, resource *internalModels.CalDAVResource, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := addTaskWithAssignees(task, []int{task.AuthorID}, ctx, tx); err != nil {
+			return err
+		}
+		resource.TaskID = task.ID
+		resource.TaskCategoryID = task.TaskCategoryID
+		_, err := tx.ExecContext(ctx, `INSERT INTO caldav_resources(task_id, task_category_id, name, uid) VALUES($1, $2, $3, $4);`,
+			resource.TaskID, resource.TaskCategoryID, resource.Name, resource.UID)
+		return err
+	})
+}
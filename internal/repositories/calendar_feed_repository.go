@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type CalendarFeedRepository struct {
+	Database *Database
+}
+
+func NewCalendarFeedRepository(database *Database) *CalendarFeedRepository {
+	return &CalendarFeedRepository{Database: database}
+}
+
+const calendarFeedColumns = `id, user_id, task_category_id, created_at, revoked_at`
+
+func scanCalendarFeed(row rowScanner) (models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	err := row.Scan(&feed.ID, &feed.UserID, &feed.TaskCategoryID, &feed.CreatedAt, &feed.RevokedAt)
+	return feed, err
+}
+
+// Adds a calendar feed identified by the hash of its token
+func (re *CalendarFeedRepository) AddCalendarFeed(feed *models.CalendarFeed, tokenHash string, ctx context.Context) error {
+	query := `INSERT INTO calendar_feeds(token_hash, user_id, task_category_id) VALUES($1, $2, $3) RETURNING ` + calendarFeedColumns + `;`
+	added, err := scanCalendarFeed(re.Database.Conn.QueryRowContext(ctx, query, tokenHash, feed.UserID, feed.TaskCategoryID))
+	if err != nil {
+		return err
+	}
+	feed.ID = added.ID
+	feed.CreatedAt = added.CreatedAt
+	feed.RevokedAt = added.RevokedAt
+	return nil
+}
+
+// Gets the feed of a token hash if it is not revoked and its owner is not deleted
+func (re *CalendarFeedRepository) GetActiveCalendarFeedByTokenHash(tokenHash string, ctx context.Context) (models.CalendarFeed, error) {
+	query := `SELECT f.id, f.user_id, f.task_category_id, f.created_at, f.revoked_at
+		FROM calendar_feeds f
+		INNER JOIN users u ON u.id = f.user_id
+		WHERE f.token_hash=$1 AND f.revoked_at IS NULL AND u.deleted_at IS NULL;`
+	feed, err := scanCalendarFeed(re.Database.Conn.QueryRowContext(ctx, query, tokenHash))
+	if err == sql.ErrNoRows {
+		return feed, ErrNoMatch
+	}
+	return feed, err
+}
+
+// Gets the feeds of a user that are not revoked
+func (re *CalendarFeedRepository) GetCalendarFeedsOfUser(userID int, ctx context.Context) ([]models.CalendarFeed, error) {
+	list := []models.CalendarFeed{}
+	query := `SELECT ` + calendarFeedColumns + ` FROM calendar_feeds WHERE user_id=$1 AND revoked_at IS NULL ORDER BY id;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		feed, err := scanCalendarFeed(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, feed)
+	}
+	return list, rows.Err()
+}
+
+// Revokes a feed of a user, the URL of the feed stops working immediately
+func (re *CalendarFeedRepository) RevokeCalendarFeed(feedID, userID int, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, `UPDATE calendar_feeds SET revoked_at=NOW() WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL;`, feedID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// ErrChecklistIncomplete is returned when a task is completed while its category requires a finished checklist
+var ErrChecklistIncomplete = errors.New("all checklist items must be done before the task can be completed")
+
+type ChecklistRepository struct {
+	Database *Database
+}
+
+func NewChecklistRepository(database *Database) *ChecklistRepository {
+	return &ChecklistRepository{Database: database}
+}
+
+const checklistItemColumns = `id, task_id, position, text, done, assignee_id, due_date, created_at, updated_at`
+
+func scanChecklistItem(row rowScanner) (models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := row.Scan(&item.ID, &item.TaskID, &item.Position, &item.Text, &item.Done, &item.AssigneeID, &item.DueDate, &item.CreatedAt, &item.UpdatedAt)
+	return item, err
+}
+
+// Gets the checklist items of a task in their order
+func (re *ChecklistRepository) GetChecklistItems(taskID int, ctx context.Context) ([]models.ChecklistItem, error) {
+	list := []models.ChecklistItem{}
+	rows, err := re.Database.Conn.QueryContext(ctx, `SELECT `+checklistItemColumns+` FROM checklist_items WHERE task_id=$1 ORDER BY position, id;`, taskID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanChecklistItem(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, item)
+	}
+	return list, rows.Err()
+}
+
+// Adds an item at the end of the checklist of a task
+func (re *ChecklistRepository) AddChecklistItem(item *models.ChecklistItem, ctx context.Context) error {
+	query := `INSERT INTO checklist_items(task_id, position, text, assignee_id, due_date)
+		SELECT $1, COALESCE(MAX(position), 0) + 1, $2, $3, $4 FROM checklist_items WHERE task_id=$1
+		RETURNING ` + checklistItemColumns + `;`
+	added, err := scanChecklistItem(re.Database.Conn.QueryRowContext(ctx, query, item.TaskID, item.Text, item.AssigneeID, item.DueDate))
+	if err != nil {
+		return err
+	}
+	*item = added
+	return nil
+}
+
+// Checks in the same statement that the task of a checklist item is not in the trash
+const checklistTaskNotDeleted = `EXISTS (SELECT 1 FROM tasks WHERE tasks.id = checklist_items.task_id AND tasks.deleted_at IS NULL)`
+
+// Flips the done flag of a checklist item, the items of tasks in the trash are not found
+func (re *ChecklistRepository) ToggleChecklistItem(taskID, itemID int, ctx context.Context) (models.ChecklistItem, error) {
+	query := `UPDATE checklist_items SET done = NOT done, updated_at = NOW() WHERE id=$1 AND task_id=$2 AND ` + checklistTaskNotDeleted + `
+		RETURNING ` + checklistItemColumns + `;`
+	item, err := scanChecklistItem(re.Database.Conn.QueryRowContext(ctx, query, itemID, taskID))
+	if err == sql.ErrNoRows {
+		return item, ErrNoMatch
+	}
+	return item, err
+}
+
+// Deletes an item from the checklist of a task, the items of tasks in the trash are not found
+func (re *ChecklistRepository) DeleteChecklistItem(taskID, itemID int, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, `DELETE FROM checklist_items WHERE id=$1 AND task_id=$2 AND `+checklistTaskNotDeleted+`;`, itemID, taskID)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// Puts the checklist items of a task in the given order, the IDs must list every item of the checklist exactly once
+func (re *ChecklistRepository) ReorderChecklistItems(taskID int, itemIDs []int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id FROM checklist_items WHERE task_id=$1 FOR UPDATE;`, taskID)
+		if err != nil {
+			return err
+		}
+		existing := make(map[int]bool)
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			existing[id] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(itemIDs) != len(existing) {
+			return errors.New("the new order must list every item of the checklist exactly once")
+		}
+		for position, itemID := range itemIDs {
+			if !existing[itemID] {
+				return errors.New("the new order must list every item of the checklist exactly once")
+			}
+			delete(existing, itemID)
+			_, err := tx.ExecContext(ctx, `UPDATE checklist_items SET position=$1, updated_at=NOW() WHERE id=$2;`, position+1, itemID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Gets the number of items and done items in the checklists of the given tasks
+func (re *ChecklistRepository) GetChecklistProgress(taskIDs []int, ctx context.Context) (map[int]models.ChecklistProgress, error) {
+	progress := make(map[int]models.ChecklistProgress)
+	if len(taskIDs) == 0 {
+		return progress, nil
+	}
+	query := `SELECT task_id, COUNT(*), COUNT(*) FILTER (WHERE done) FROM checklist_items WHERE task_id = ANY($1) GROUP BY task_id;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, toInt64Array(taskIDs))
+	if err != nil {
+		return progress, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			taskID int
+			p      models.ChecklistProgress
+		)
+		if err := rows.Scan(&taskID, &p.Total, &p.Done); err != nil {
+			return progress, err
+		}
+		progress[taskID] = p
+	}
+	return progress, rows.Err()
+}
+
+// Checks if a task cannot be completed yet because its category requires
+// a finished checklist and some of its checklist items are not done
+func (re *ChecklistRepository) BlocksCompletion(taskID, taskCategoryID int, ctx context.Context) (bool, error) {
+	return checklistBlocksCompletion(taskID, taskCategoryID, ctx, re.Database.Conn)
+}
+
+func checklistBlocksCompletion(taskID, taskCategoryID int, ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	query := `SELECT EXISTS(
+		SELECT 1 FROM checklist_items i, task_categories c
+		WHERE i.task_id=$1 AND NOT i.done AND c.id=$2 AND c.require_checklist_completion
+	);`
+	var blocked bool
+	err := exec.QueryRowContext(ctx, query, taskID, taskCategoryID).Scan(&blocked)
+	return blocked, err
+}
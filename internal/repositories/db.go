@@ -1,16 +1,22 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"github.com/qthuy2k1/task-management-app/internal/models"
 )
 
 // ErrNoMatch is returned when we request a row that doesn't exist
 var ErrNoMatch = fmt.Errorf("no matching record")
 
+// ErrPurgeBlocked is returned when an item in the trash cannot be purged because tasks still reference it
+var ErrPurgeBlocked = fmt.Errorf("the item is still referenced by tasks, purge or reassign them first")
+
 type Database struct {
 	Conn *sql.DB
 }
@@ -33,3 +39,134 @@ func Initialize(dbUrl string) (*Database, error) {
 	log.Println("Database connection etablished")
 	return db, nil
 }
+
+// Runs fn inside a database transaction, the transaction is committed if fn
+// returns nil and rolled back otherwise
+func (db *Database) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Gets the soft deleted rows of a table as trash items
+func (db *Database) getTrashItems(ctx context.Context, table, itemType string) ([]models.TrashItem, error) {
+	list := []models.TrashItem{}
+	query := fmt.Sprintf(`SELECT id, name, deleted_at, deleted_by FROM %s WHERE deleted_at IS NOT NULL ORDER BY deleted_at DESC;`, table)
+	rows, err := db.Conn.QueryContext(ctx, query)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := models.TrashItem{Type: itemType}
+		err := rows.Scan(&item.ID, &item.Name, &item.DeletedAt, &item.DeletedBy)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, item)
+	}
+	return list, rows.Err()
+}
+
+// Permanently deletes the rows of a table that were moved to the trash before the given time and that no
+// task references through refColumn. Returns the number of deleted rows and the rows that were kept.
+func purgeUnreferenced(ctx context.Context, tx *sql.Tx, table, itemType, refColumn string, before time.Time) (int64, []models.TrashItem, error) {
+	blocked := []models.TrashItem{}
+	query := fmt.Sprintf(`SELECT id, name, deleted_at, deleted_by FROM %[1]s
+		WHERE deleted_at < $1 AND EXISTS (SELECT 1 FROM tasks WHERE tasks.%[2]s = %[1]s.id)
+		ORDER BY deleted_at;`, table, refColumn)
+	rows, err := tx.QueryContext(ctx, query, before)
+	if err != nil {
+		return 0, blocked, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		item := models.TrashItem{Type: itemType}
+		if err := rows.Scan(&item.ID, &item.Name, &item.DeletedAt, &item.DeletedBy); err != nil {
+			return 0, blocked, err
+		}
+		blocked = append(blocked, item)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, blocked, err
+	}
+
+	query = fmt.Sprintf(`DELETE FROM %[1]s
+		WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.%[2]s = %[1]s.id);`, table, refColumn)
+	result, err := tx.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, blocked, err
+	}
+	rowsAff, err := result.RowsAffected()
+	return rowsAff, blocked, err
+}
+
+// Permanently deletes a row of a table in the trash by ID. Returns ErrPurgeBlocked when a task references
+// it through refColumn, and ErrNoMatch when it is not in the trash.
+func purgeUnreferencedItem(ctx context.Context, tx *sql.Tx, table, refColumn string, id int) error {
+	var inTrash, referenced bool
+	query := fmt.Sprintf(`SELECT TRUE, EXISTS (SELECT 1 FROM tasks WHERE tasks.%[2]s = %[1]s.id)
+		FROM %[1]s WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE;`, table, refColumn)
+	err := tx.QueryRowContext(ctx, query, id).Scan(&inTrash, &referenced)
+	if err == sql.ErrNoRows {
+		return ErrNoMatch
+	}
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return ErrPurgeBlocked
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1;`, table), id)
+	return err
+}
+
+// Gets the external IDs of the soft deleted rows of a table that were imported from an external source
+func (db *Database) getTrashedExternalIDs(ctx context.Context, table, source string) (map[string]bool, error) {
+	trashed := make(map[string]bool)
+	query := fmt.Sprintf(`SELECT external_id FROM %s WHERE external_source=$1 AND deleted_at IS NOT NULL;`, table)
+	rows, err := db.Conn.QueryContext(ctx, query, source)
+	if err != nil {
+		return trashed, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var externalID string
+		if err := rows.Scan(&externalID); err != nil {
+			return trashed, err
+		}
+		trashed[externalID] = true
+	}
+	return trashed, rows.Err()
+}
+
+// Common interface of *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// Converts a postgres integer array to a slice of int
+func toIntSlice(values pq.Int64Array) []int {
+	ints := make([]int, 0, len(values))
+	for _, value := range values {
+		ints = append(ints, int(value))
+	}
+	return ints
+}
+
+// Converts a slice of int to a postgres integer array
+func toInt64Array(values []int) pq.Int64Array {
+	array := make(pq.Int64Array, 0, len(values))
+	for _, value := range values {
+		array = append(array, int64(value))
+	}
+	return array
+}
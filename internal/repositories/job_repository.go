@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type JobRepository struct {
+	Database *Database
+}
+
+func NewJobRepository(database *Database) *JobRepository {
+	return &JobRepository{Database: database}
+}
+
+const jobColumns = `id, type, payload, status, attempts, max_attempts, timeout_seconds, run_at, locked_at, progress, result, last_error, created_by, created_at, updated_at, finished_at`
+
+// Grace period after the timeout of a running job before another worker may claim it
+const staleJobGracePeriod = 60
+
+func scanJob(row rowScanner) (models.Job, error) {
+	var (
+		job     models.Job
+		payload []byte
+		result  []byte
+	)
+	err := row.Scan(&job.ID, &job.Type, &payload, &job.Status, &job.Attempts, &job.MaxAttempts, &job.TimeoutSeconds, &job.RunAt, &job.StartedAt,
+		&job.Progress, &result, &job.LastError, &job.CreatedBy, &job.CreatedAt, &job.UpdatedAt, &job.FinishedAt)
+	job.Payload = payload
+	job.Result = result
+	return job, err
+}
+
+// Adds a queued job
+func (re *JobRepository) AddJob(job *models.Job, ctx context.Context) error {
+	query := `INSERT INTO jobs(type, payload, max_attempts, timeout_seconds, run_at, created_by) VALUES($1, $2, $3, $4, $5, $6) RETURNING ` + jobColumns + `;`
+	added, err := scanJob(re.Database.Conn.QueryRowContext(ctx, query, job.Type, []byte(job.Payload), job.MaxAttempts, job.TimeoutSeconds, job.RunAt, job.CreatedBy))
+	if err != nil {
+		return err
+	}
+	*job = added
+	return nil
+}
+
+// Adds a queued job on behalf of a rate key, unless limit jobs of its type were already added for the key since
+// the given time. The key is locked for the transaction, so concurrent requests cannot both pass the limit.
+// Returns false when the limit is reached.
+func (re *JobRepository) AddRateLimitedJob(job *models.Job, rateKey string, limit int, since time.Time, ctx context.Context) (bool, error) {
+	added := false
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2));`, job.Type, rateKey); err != nil {
+			return err
+		}
+		var count int
+		query := `SELECT COUNT(*) FROM jobs WHERE type=$1 AND rate_key=$2 AND created_at > $3;`
+		if err := tx.QueryRowContext(ctx, query, job.Type, rateKey, since).Scan(&count); err != nil {
+			return err
+		}
+		if count >= limit {
+			return nil
+		}
+		query = `INSERT INTO jobs(type, payload, max_attempts, timeout_seconds, run_at, created_by, rate_key) VALUES($1, $2, $3, $4, $5, $6, $7) RETURNING ` + jobColumns + `;`
+		inserted, err := scanJob(tx.QueryRowContext(ctx, query, job.Type, []byte(job.Payload), job.MaxAttempts, job.TimeoutSeconds, job.RunAt, job.CreatedBy, rateKey))
+		if err != nil {
+			return err
+		}
+		*job = inserted
+		added = true
+		return nil
+	})
+	return added, err
+}
+
+// Adds a queued job of a recurring type, or replaces the payload of the job of that type that is already
+// queued so a type is never queued twice. The type is locked for the transaction, so processes scheduling
+// the same type at once wait for each other.
+func (re *JobRepository) ScheduleJob(job *models.Job, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1));`, job.Type); err != nil {
+			return err
+		}
+		query := `UPDATE jobs SET payload=$2, updated_at=NOW() WHERE id=(SELECT MIN(id) FROM jobs WHERE type=$1 AND status='queued') RETURNING ` + jobColumns + `;`
+		scheduled, err := scanJob(tx.QueryRowContext(ctx, query, job.Type, []byte(job.Payload)))
+		if err == sql.ErrNoRows {
+			query = `INSERT INTO jobs(type, payload, max_attempts, timeout_seconds, run_at, created_by) VALUES($1, $2, $3, $4, $5, $6) RETURNING ` + jobColumns + `;`
+			scheduled, err = scanJob(tx.QueryRowContext(ctx, query, job.Type, []byte(job.Payload), job.MaxAttempts, job.TimeoutSeconds, job.RunAt, job.CreatedBy))
+		}
+		if err != nil {
+			return err
+		}
+		*job = scheduled
+		return nil
+	})
+}
+
+// Deletes the queued jobs of a type, the running ones finish
+func (re *JobRepository) DeleteQueuedJobs(jobType string, ctx context.Context) (int64, error) {
+	result, err := re.Database.Conn.ExecContext(ctx, `DELETE FROM jobs WHERE type=$1 AND status='queued';`, jobType)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// Claims the next due job of the given types for a worker and counts the attempt. Running jobs whose
+// worker stopped without recording the outcome are claimed again once their timeout has passed.
+// Jobs locked by other workers are skipped, so workers never wait for each other.
+func (re *JobRepository) ClaimJob(jobTypes []string, workerID string, ctx context.Context) (models.Job, error) {
+	query := `UPDATE jobs SET status='running', attempts=attempts+1, locked_at=NOW(), locked_by=$2, updated_at=NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE type = ANY($1) AND (
+				(status='queued' AND run_at <= NOW()) OR
+				(status='running' AND locked_at + (timeout_seconds + $3) * INTERVAL '1 second' < NOW())
+			)
+			ORDER BY run_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns + `;`
+	job, err := scanJob(re.Database.Conn.QueryRowContext(ctx, query, pq.StringArray(jobTypes), workerID, staleJobGracePeriod))
+	if err == sql.ErrNoRows {
+		return job, ErrNoMatch
+	}
+	return job, err
+}
+
+// Runs an update of a job that is still held by the given worker, ErrNoMatch means another worker took it
+func (re *JobRepository) updateClaimedJob(query string, ctx context.Context, args ...interface{}) error {
+	result, err := re.Database.Conn.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// Records the result of a job that succeeded
+func (re *JobRepository) CompleteJob(jobID int, workerID string, result []byte, ctx context.Context) error {
+	query := `UPDATE jobs SET status='succeeded', progress=100, result=$3, last_error=NULL, finished_at=NOW(), updated_at=NOW()
+		WHERE id=$1 AND status='running' AND locked_by=$2;`
+	return re.updateClaimedJob(query, ctx, jobID, workerID, result)
+}
+
+// Queues a failed job again to be retried at the given time
+func (re *JobRepository) RetryJobLater(jobID int, workerID string, lastError string, runAt time.Time, ctx context.Context) error {
+	query := `UPDATE jobs SET status='queued', last_error=$3, run_at=$4, updated_at=NOW() WHERE id=$1 AND status='running' AND locked_by=$2;`
+	return re.updateClaimedJob(query, ctx, jobID, workerID, lastError, runAt)
+}
+
+// Moves a job that failed every attempt to the dead letters
+func (re *JobRepository) KillJob(jobID int, workerID string, lastError string, ctx context.Context) error {
+	query := `UPDATE jobs SET status='dead', last_error=$3, finished_at=NOW(), updated_at=NOW() WHERE id=$1 AND status='running' AND locked_by=$2;`
+	return re.updateClaimedJob(query, ctx, jobID, workerID, lastError)
+}
+
+// Queues a job interrupted by a stopping worker again, the interrupted attempt is not counted
+func (re *JobRepository) ReleaseJob(jobID int, workerID string, ctx context.Context) error {
+	query := `UPDATE jobs SET status='queued', attempts=attempts-1, run_at=NOW(), updated_at=NOW() WHERE id=$1 AND status='running' AND locked_by=$2;`
+	return re.updateClaimedJob(query, ctx, jobID, workerID)
+}
+
+// Sets the progress of a running job, in percent
+func (re *JobRepository) UpdateJobProgress(jobID int, workerID string, progress int, ctx context.Context) error {
+	query := `UPDATE jobs SET progress=$3, updated_at=NOW() WHERE id=$1 AND status='running' AND locked_by=$2;`
+	return re.updateClaimedJob(query, ctx, jobID, workerID, progress)
+}
+
+func (re *JobRepository) GetJobByID(jobID int, ctx context.Context) (models.Job, error) {
+	job, err := scanJob(re.Database.Conn.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id=$1;`, jobID))
+	if err == sql.ErrNoRows {
+		return job, ErrNoMatch
+	}
+	return job, err
+}
+
+// Gets the latest jobs, of every status when status is empty
+func (re *JobRepository) GetJobs(status string, limit int, ctx context.Context) ([]models.Job, error) {
+	list := []models.Job{}
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE ($1 = '' OR status = $1) ORDER BY id DESC LIMIT $2;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, status, limit)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, job)
+	}
+	return list, rows.Err()
+}
+
+// Queues a dead job again with all its attempts
+func (re *JobRepository) RequeueDeadJob(jobID int, ctx context.Context) (models.Job, error) {
+	query := `UPDATE jobs SET status='queued', attempts=0, progress=0, run_at=NOW(), finished_at=NULL, updated_at=NOW()
+		WHERE id=$1 AND status='dead' RETURNING ` + jobColumns + `;`
+	job, err := scanJob(re.Database.Conn.QueryRowContext(ctx, query, jobID))
+	if err == sql.ErrNoRows {
+		return job, ErrNoMatch
+	}
+	return job, err
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type MentionRepository struct {
+	Database *Database
+}
+
+func NewMentionRepository(database *Database) *MentionRepository {
+	return &MentionRepository{Database: database}
+}
+
+// Records that the given users were mentioned in a task and returns the users that were not mentioned there before
+func (re *MentionRepository) AddMentions(taskID int, userIDs []int, mentionedBy int, source string, ctx context.Context) ([]int, error) {
+	added := []int{}
+	if len(userIDs) == 0 {
+		return added, nil
+	}
+	query := `INSERT INTO task_mentions(task_id, user_id, mentioned_by, source)
+		SELECT $1, user_id, $3, $4 FROM UNNEST($2::INTEGER[]) AS user_id
+		ON CONFLICT (task_id, user_id, source) DO NOTHING
+		RETURNING user_id;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, taskID, toInt64Array(userIDs), mentionedBy, source)
+	if err != nil {
+		return added, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return added, err
+		}
+		added = append(added, userID)
+	}
+	return added, rows.Err()
+}
+
+// Gets the places where a user was mentioned, newest first
+func (re *MentionRepository) GetMentionsOfUser(userID int, ctx context.Context) ([]models.Mention, error) {
+	list := []models.Mention{}
+	query := `SELECT m.id, m.task_id, t.name, m.user_id, m.mentioned_by, m.source, m.created_at
+		FROM task_mentions m
+		INNER JOIN tasks t ON t.id = m.task_id
+		WHERE m.user_id=$1 AND t.deleted_at IS NULL
+		ORDER BY m.created_at DESC, m.id DESC;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mention models.Mention
+		err := rows.Scan(&mention.ID, &mention.TaskID, &mention.TaskName, &mention.UserID, &mention.MentionedBy, &mention.Source, &mention.CreatedAt)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, mention)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type NotificationRepository struct {
+	Database *Database
+}
+
+func NewNotificationRepository(database *Database) *NotificationRepository {
+	return &NotificationRepository{Database: database}
+}
+
+// Adds the given notifications in a single transaction
+func (re *NotificationRepository) AddNotifications(notifications []models.Notification, ctx context.Context) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		for i := range notifications {
+			n := &notifications[i]
+			query := `INSERT INTO notifications(user_id, type, task_id, message) VALUES($1, $2, $3, $4) RETURNING id, created_at;`
+			err := tx.QueryRowContext(ctx, query, n.UserID, n.Type, n.TaskID, n.Message).Scan(&n.ID, &n.CreatedAt)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+const notificationColumns = `id, user_id, type, task_id, message, read_at, created_at`
+
+func scanNotification(row rowScanner) (models.Notification, error) {
+	var n models.Notification
+	err := row.Scan(&n.ID, &n.UserID, &n.Type, &n.TaskID, &n.Message, &n.ReadAt, &n.CreatedAt)
+	return n, err
+}
+
+// Gets the notifications of a user, newest first, or only the unread ones
+func (re *NotificationRepository) GetNotificationsOfUser(userID int, unreadOnly bool, ctx context.Context) ([]models.Notification, error) {
+	list := []models.Notification{}
+	query := `SELECT ` + notificationColumns + ` FROM notifications WHERE user_id=$1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID, unreadOnly)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		n, err := scanNotification(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, n)
+	}
+	return list, rows.Err()
+}
+
+// Marks a notification of a user as read, a notification read before keeps the time it was read
+func (re *NotificationRepository) MarkNotificationRead(userID, notificationID int, ctx context.Context) (models.Notification, error) {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id=$1 AND user_id=$2 RETURNING ` + notificationColumns + `;`
+	n, err := scanNotification(re.Database.Conn.QueryRowContext(ctx, query, notificationID, userID))
+	if err == sql.ErrNoRows {
+		return n, ErrNoMatch
+	}
+	return n, err
+}
+
+// Marks every unread notification of a user as read and returns how many were marked
+func (re *NotificationRepository) MarkAllNotificationsRead(userID int, ctx context.Context) (int64, error) {
+	result, err := re.Database.Conn.ExecContext(ctx, `UPDATE notifications SET read_at = NOW() WHERE user_id=$1 AND read_at IS NULL;`, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type PasswordResetRepository struct {
+	Database *Database
+}
+
+func NewPasswordResetRepository(database *Database) *PasswordResetRepository {
+	return &PasswordResetRepository{Database: database}
+}
+
+// Adds a reset token of a user identified by the hash of the token
+func (re *PasswordResetRepository) AddPasswordResetToken(userID int, tokenHash string, expiresAt time.Time, ctx context.Context) error {
+	_, err := re.Database.Conn.ExecContext(ctx, `INSERT INTO password_reset_tokens(token_hash, user_id, expires_at) VALUES($1, $2, $3);`, tokenHash, userID, expiresAt)
+	return err
+}
+
+// Uses the reset token of a hash to change the password of its user, and returns the user. Every other
+// unused token of the user is used and every session of the user is revoked at the same time. Unknown,
+// used and expired tokens, and tokens of deleted users, return ErrNoMatch.
+func (re *PasswordResetRepository) ResetPassword(tokenHash, hashedPassword string, ctx context.Context) (int, error) {
+	var userID int
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		query := `UPDATE password_reset_tokens t SET used_at=NOW()
+			FROM users u
+			WHERE t.token_hash=$1 AND t.used_at IS NULL AND t.expires_at > NOW() AND u.id=t.user_id AND u.deleted_at IS NULL
+			RETURNING t.user_id;`
+		err := tx.QueryRowContext(ctx, query, tokenHash).Scan(&userID)
+		if err == sql.ErrNoRows {
+			return ErrNoMatch
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET password=$2 WHERE id=$1;`, userID, hashedPassword); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE password_reset_tokens SET used_at=NOW() WHERE user_id=$1 AND used_at IS NULL;`, userID); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE user_sessions SET revoked_at=NOW(), revoked_reason=$2 WHERE user_id=$1 AND revoked_at IS NULL;`, userID, models.SessionRevokedPasswordReset)
+		return err
+	})
+	return userID, err
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type ReportRepository struct {
+	Database *Database
+}
+
+func NewReportRepository(database *Database) *ReportRepository {
+	return &ReportRepository{Database: database}
+}
+
+// Counts the tasks in every status at the end of each day of the range from the status transitions.
+// Tasks in the trash are left out, tasks without status are counted as not started and days without
+// tasks are not returned.
+func (re *ReportRepository) GetDailyStatusCounts(reportRange models.ReportRange, ctx context.Context) ([]models.DailyStatusCount, error) {
+	query := `SELECT day::date, COALESCE(latest.to_status, $4), COUNT(*)
+		FROM generate_series($1::date, $2::date, interval '1 day') AS day
+		CROSS JOIN LATERAL (
+			SELECT DISTINCT ON (t.task_id) t.to_status
+			FROM task_status_transitions t INNER JOIN tasks ON tasks.id = t.task_id
+			WHERE t.changed_at < day + interval '1 day' AND tasks.deleted_at IS NULL
+				AND ($3 = 0 OR tasks.task_category_id = $3)
+			ORDER BY t.task_id, t.changed_at DESC, t.id DESC
+		) AS latest
+		GROUP BY 1, 2 ORDER BY 1, 2;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, reportRange.From, reportRange.To, reportRange.TaskCategoryID, string(NotStarted))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	counts := []models.DailyStatusCount{}
+	for rows.Next() {
+		var count models.DailyStatusCount
+		if err := rows.Scan(&count.Date, &count.Status, &count.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// Counts the tasks that were completed in each week of the range, weeks start on Monday. A task
+// completed again after being reopened is counted in every week it was completed.
+func (re *ReportRepository) GetWeeklyCompletions(reportRange models.ReportRange, ctx context.Context) (map[time.Time]int, error) {
+	query := `SELECT date_trunc('week', t.changed_at)::date, COUNT(DISTINCT t.task_id)
+		FROM task_status_transitions t INNER JOIN tasks ON tasks.id = t.task_id
+		WHERE t.to_status = $4 AND t.changed_at >= date_trunc('week', $1::date)
+			AND t.changed_at < $2::date + interval '1 day' AND tasks.deleted_at IS NULL
+			AND ($3 = 0 OR tasks.task_category_id = $3)
+		GROUP BY 1;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, reportRange.From, reportRange.To, reportRange.TaskCategoryID, string(Complete))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	completions := make(map[time.Time]int)
+	for rows.Next() {
+		var week time.Time
+		var count int
+		if err := rows.Scan(&week, &count); err != nil {
+			return nil, err
+		}
+		completions[week.UTC()] = count
+	}
+	return completions, rows.Err()
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+var ErrRoleInUse = errors.New("the role is held by users, change their role before deleting it")
+
+type RoleRepository struct {
+	Database *Database
+}
+
+func NewRoleRepository(database *Database) *RoleRepository {
+	return &RoleRepository{Database: database}
+}
+
+// The columns of a role with its permissions, selected from roles r
+const roleColumns = `r.name, r.description, r.built_in, r.created_at,
+	ARRAY(SELECT p.permission FROM role_permissions p WHERE p.role = r.name ORDER BY p.permission)`
+
+func scanRole(row rowScanner) (models.Role, error) {
+	var role models.Role
+	var permissions pq.StringArray
+	err := row.Scan(&role.Name, &role.Description, &role.BuiltIn, &role.CreatedAt, &permissions)
+	role.Permissions = make([]models.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, models.Permission(permission))
+	}
+	return role, err
+}
+
+func (re *RoleRepository) GetRoles(ctx context.Context) ([]models.Role, error) {
+	list := []models.Role{}
+	rows, err := re.Database.Conn.QueryContext(ctx, `SELECT `+roleColumns+` FROM roles r ORDER BY r.built_in DESC, r.name;`)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, role)
+	}
+	return list, rows.Err()
+}
+
+func (re *RoleRepository) GetRole(name string, ctx context.Context) (models.Role, error) {
+	role, err := scanRole(re.Database.Conn.QueryRowContext(ctx, `SELECT `+roleColumns+` FROM roles r WHERE r.name=$1;`, name))
+	if err == sql.ErrNoRows {
+		return role, ErrNoMatch
+	}
+	return role, err
+}
+
+// Adds a custom role with its permissions
+func (re *RoleRepository) AddRole(role models.Role, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO roles(name, description) VALUES($1, $2);`, role.Name, role.Description); err != nil {
+			return err
+		}
+		return insertRolePermissions(tx, role, ctx)
+	})
+}
+
+// Changes the description and replaces the permissions of a custom role
+func (re *RoleRepository) UpdateRole(role models.Role, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `UPDATE roles SET description=$2 WHERE name=$1 AND NOT built_in;`, role.Name, role.Description)
+		if err != nil {
+			return err
+		}
+		rowsAff, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return ErrNoMatch
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role=$1;`, role.Name); err != nil {
+			return err
+		}
+		return insertRolePermissions(tx, role, ctx)
+	})
+}
+
+func insertRolePermissions(tx *sql.Tx, role models.Role, ctx context.Context) error {
+	permissions := make(pq.StringArray, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		permissions = append(permissions, string(permission))
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO role_permissions(role, permission) SELECT $1, UNNEST($2::VARCHAR[]);`, role.Name, permissions)
+	return err
+}
+
+// Deletes a custom role, roles held by users, even deleted ones, return ErrRoleInUse
+func (re *RoleRepository) DeleteRole(name string, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		var inUse bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE role=$1);`, name).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return ErrRoleInUse
+		}
+		result, err := tx.ExecContext(ctx, `DELETE FROM roles WHERE name=$1 AND NOT built_in;`, name)
+		if err != nil {
+			return err
+		}
+		rowsAff, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return ErrNoMatch
+		}
+		return nil
+	})
+}
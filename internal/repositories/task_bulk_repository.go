@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Runs an operation on every given task inside a single transaction.
+// In best effort mode each task runs in its own savepoint, so a failing task is rolled back
+// and reported without affecting the others. Otherwise the first failure rolls back the whole
+// transaction and is returned as the error.
+func (re *TaskRepository) ApplyBulkOperation(operation internalModels.BulkTaskOperation, taskIDs []int, userID int, bestEffort bool, ctx context.Context) (map[int]error, error) {
+	taskErrors := make(map[int]error)
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, taskID := range taskIDs {
+			if !bestEffort {
+				if err := applyBulkOperation(operation, taskID, userID, ctx, tx); err != nil {
+					taskErrors[taskID] = err
+					return err
+				}
+				continue
+			}
+
+			if _, err := tx.ExecContext(ctx, `SAVEPOINT bulk_task;`); err != nil {
+				return err
+			}
+			if err := applyBulkOperation(operation, taskID, userID, ctx, tx); err != nil {
+				taskErrors[taskID] = err
+				if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT bulk_task;`); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := tx.ExecContext(ctx, `RELEASE SAVEPOINT bulk_task;`); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return taskErrors, err
+}
+
+// Runs an operation on a single task using the given executor
+func applyBulkOperation(operation internalModels.BulkTaskOperation, taskID, userID int, ctx context.Context, exec boil.ContextExecutor) error {
+	switch operation.Type {
+	case internalModels.BulkSetStatus:
+		if operation.Status == string(Complete) {
+			task, err := models.Tasks(Where("id = ?", taskID), Where("deleted_at IS NULL")).One(ctx, exec)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return ErrNoMatch
+				}
+				return err
+			}
+			if task.Status.String != string(Complete) {
+				blocked, err := checklistBlocksCompletion(taskID, task.TaskCategoryID, ctx, exec)
+				if err != nil {
+					return err
+				}
+				if blocked {
+					return ErrChecklistIncomplete
+				}
+			}
+		}
+		return setTaskStatus(taskID, TaskStatus(operation.Status), models.M{"updated_at": time.Now()}, ctx, exec)
+	case internalModels.BulkSetCategory:
+		exists, err := models.TaskCategories(Where("id = ?", operation.TaskCategoryID), Where("deleted_at IS NULL")).Exists(ctx, exec)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("task category %d does not exist", operation.TaskCategoryID)
+		}
+		return updateTaskColumns(taskID, models.M{"task_category_id": operation.TaskCategoryID, "updated_at": time.Now()}, ctx, exec)
+	case internalModels.BulkAddAssignee:
+		var verified bool
+		err := exec.QueryRowContext(ctx, `SELECT email_verified_at IS NOT NULL FROM users WHERE id=$1 AND deleted_at IS NULL;`, operation.UserID).Scan(&verified)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("user %d does not exist", operation.UserID)
+		}
+		if err != nil {
+			return err
+		}
+		if !verified {
+			return fmt.Errorf("user %d cannot be assigned tasks: %w", operation.UserID, ErrEmailNotVerified)
+		}
+		var assigned bool
+		err = exec.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM user_task_details WHERE user_id=$1 AND task_id=$2);`, operation.UserID, taskID).Scan(&assigned)
+		if err != nil {
+			return err
+		}
+		if assigned {
+			return errors.New("the user is already assigned to this task")
+		}
+		_, err = exec.ExecContext(ctx, `INSERT INTO user_task_details(user_id, task_id) VALUES($1, $2);`, operation.UserID, taskID)
+		return err
+	case internalModels.BulkRemoveAssignee:
+		result, err := exec.ExecContext(ctx, `DELETE FROM user_task_details WHERE user_id=$1 AND task_id=$2;`, operation.UserID, taskID)
+		if err != nil {
+			return err
+		}
+		rowsAff, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return errors.New("the user is not assigned to this task")
+		}
+		return nil
+	case internalModels.BulkLock:
+		return setTaskStatus(taskID, Lock, models.M{}, ctx, exec)
+	case internalModels.BulkUnlock:
+		return setTaskStatus(taskID, InProgress, models.M{}, ctx, exec)
+	case internalModels.BulkDelete:
+		return updateTaskColumns(taskID, models.M{"deleted_at": time.Now(), "deleted_by": userID}, ctx, exec)
+	}
+	return fmt.Errorf("unknown bulk operation %q", operation.Type)
+}
+
+// Updates the given columns of a task that is not in the trash
+func updateTaskColumns(taskID int, cols models.M, ctx context.Context, exec boil.ContextExecutor) error {
+	rowsAff, err := models.Tasks(Where("id = ?", taskID), Where("deleted_at IS NULL")).UpdateAll(ctx, exec, cols)
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
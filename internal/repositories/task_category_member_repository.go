@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ErrMemberUserNotFound is returned when a user who does not exist is added to a task category
+var ErrMemberUserNotFound = errors.New("the user does not exist")
+
+// Selects the IDs of the task categories a user can see: the public ones and the ones the user is a member of
+const taskCategoriesVisibleToQuery = `SELECT id FROM task_categories WHERE visibility = 'public'
+	UNION SELECT task_category_id FROM task_category_members WHERE user_id = ?`
+
+const taskCategoryMemberColumns = `task_category_id, user_id, role, created_at`
+
+func scanTaskCategoryMember(row rowScanner) (internalModels.TaskCategoryMember, error) {
+	member := internalModels.TaskCategoryMember{}
+	err := row.Scan(&member.TaskCategoryID, &member.UserID, &member.Role, &member.CreatedAt)
+	return member, err
+}
+
+// Gets the visibility of a task category and the role of a user in it
+func (re *TaskCategoryRepository) GetTaskCategoryAccess(taskCategoryID, userID int, ctx context.Context) (internalModels.TaskCategoryAccess, error) {
+	access := internalModels.TaskCategoryAccess{TaskCategoryID: taskCategoryID}
+	query := `SELECT c.visibility, COALESCE(m.role, '') FROM task_categories c
+		LEFT JOIN task_category_members m ON m.task_category_id = c.id AND m.user_id = $2
+		WHERE c.id = $1 AND c.deleted_at IS NULL;`
+	err := re.Database.Conn.QueryRowContext(ctx, query, taskCategoryID, userID).Scan(&access.Visibility, &access.Role)
+	if err == sql.ErrNoRows {
+		return access, ErrNoMatch
+	}
+	return access, err
+}
+
+// Gets the visibility of every task category and the role of a user in them, by task category ID
+func (re *TaskCategoryRepository) GetTaskCategoryAccesses(userID int, ctx context.Context) (map[int]internalModels.TaskCategoryAccess, error) {
+	query := `SELECT c.id, c.visibility, COALESCE(m.role, '') FROM task_categories c
+		LEFT JOIN task_category_members m ON m.task_category_id = c.id AND m.user_id = $1
+		WHERE c.deleted_at IS NULL;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	accesses := make(map[int]internalModels.TaskCategoryAccess)
+	for rows.Next() {
+		access := internalModels.TaskCategoryAccess{}
+		if err := rows.Scan(&access.TaskCategoryID, &access.Visibility, &access.Role); err != nil {
+			return nil, err
+		}
+		accesses[access.TaskCategoryID] = access
+	}
+	return accesses, rows.Err()
+}
+
+// Gets the members of a task category, the owners first
+func (re *TaskCategoryRepository) GetTaskCategoryMembers(taskCategoryID int, ctx context.Context) ([]internalModels.TaskCategoryMember, error) {
+	query := `SELECT ` + taskCategoryMemberColumns + ` FROM task_category_members WHERE task_category_id = $1
+		ORDER BY role = 'owner' DESC, user_id;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, taskCategoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := []internalModels.TaskCategoryMember{}
+	for rows.Next() {
+		member, err := scanTaskCategoryMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// Adds a user to a task category, or changes the role of a member
+func (re *TaskCategoryRepository) SetTaskCategoryMember(member internalModels.TaskCategoryMember, ctx context.Context) (internalModels.TaskCategoryMember, error) {
+	exists, err := models.Users(Where("id = ?", member.UserID), Where("deleted_at IS NULL")).Exists(ctx, re.Database.Conn)
+	if err != nil {
+		return member, err
+	}
+	if !exists {
+		return member, ErrMemberUserNotFound
+	}
+	query := `INSERT INTO task_category_members (task_category_id, user_id, role)
+		SELECT id, $2, $3 FROM task_categories WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (task_category_id, user_id) DO UPDATE SET role = EXCLUDED.role
+		RETURNING ` + taskCategoryMemberColumns + `;`
+	member, err = scanTaskCategoryMember(re.Database.Conn.QueryRowContext(ctx, query, member.TaskCategoryID, member.UserID, member.Role))
+	if err == sql.ErrNoRows {
+		return member, ErrNoMatch
+	}
+	return member, err
+}
+
+// Removes a user from the members of a task category
+func (re *TaskCategoryRepository) RemoveTaskCategoryMember(taskCategoryID, userID int, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, `DELETE FROM task_category_members WHERE task_category_id = $1 AND user_id = $2;`, taskCategoryID, userID)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
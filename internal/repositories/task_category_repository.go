@@ -2,8 +2,14 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
@@ -18,7 +24,7 @@ func NewTaskCategoryRepository(database *Database) *TaskCategoryRepository {
 
 // Gets all task categories from the database
 func (re *TaskCategoryRepository) GetAllTaskCategories(ctx context.Context) (models.TaskCategorySlice, error) {
-	taskCategories, err := models.TaskCategories().All(ctx, re.Database.Conn)
+	taskCategories, err := models.TaskCategories(Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
 	if err != nil {
 		return taskCategories, err
 	}
@@ -34,25 +40,194 @@ func (re *TaskCategoryRepository) AddTaskCategory(taskCategory *models.TaskCateg
 	return nil
 }
 
+// Adds the given task categories in a single transaction
+func (re *TaskCategoryRepository) AddTaskCategories(taskCategories []*models.TaskCategory, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, taskCategory := range taskCategories {
+			if err := taskCategory.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Gets which of the given task category IDs belong to task categories that are not deleted
+func (re *TaskCategoryRepository) GetExistingTaskCategoryIDs(taskCategoryIDs []int, ctx context.Context) (map[int]bool, error) {
+	existing := make(map[int]bool)
+	if len(taskCategoryIDs) == 0 {
+		return existing, nil
+	}
+	taskCategories, err := models.TaskCategories(Select("id"), models.TaskCategoryWhere.ID.IN(taskCategoryIDs), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
+	if err != nil {
+		return existing, err
+	}
+	for _, taskCategory := range taskCategories {
+		existing[taskCategory.ID] = true
+	}
+	return existing, nil
+}
+
+// Inserts the given task categories or updates their name by their external key in a single transaction
+func (re *TaskCategoryRepository) UpsertTaskCategories(taskCategories []*models.TaskCategory, ctx context.Context) error {
+	conflictColumns := []string{models.TaskCategoryColumns.ExternalSource, models.TaskCategoryColumns.ExternalID}
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, taskCategory := range taskCategories {
+			err := taskCategory.Upsert(ctx, tx, true, conflictColumns, boil.Whitelist(models.TaskCategoryColumns.Name), boil.Infer())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Gets the task categories that were imported from an external source and are not deleted
+func (re *TaskCategoryRepository) GetTaskCategoriesByExternalSource(source string, ctx context.Context) (models.TaskCategorySlice, error) {
+	taskCategories, err := models.TaskCategories(models.TaskCategoryWhere.ExternalSource.EQ(null.StringFrom(source)), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
+	if err != nil {
+		return taskCategories, err
+	}
+	return taskCategories, nil
+}
+
+// Gets the external IDs of the task categories of an external source that are in the trash
+func (re *TaskCategoryRepository) GetTrashedTaskCategoryExternalIDs(source string, ctx context.Context) (map[string]bool, error) {
+	return re.Database.getTrashedExternalIDs(ctx, "task_categories", source)
+}
+
 // Gets a task category from the database by ID
 func (re *TaskCategoryRepository) GetTaskCategoryByID(taskCategoryID int, ctx context.Context) (*models.TaskCategory, error) {
-	taskCategory, err := models.TaskCategories(Where("id = ?", taskCategoryID)).One(ctx, re.Database.Conn)
+	taskCategory, err := models.TaskCategories(Where("id = ?", taskCategoryID), Where("deleted_at IS NULL")).One(ctx, re.Database.Conn)
 	if err != nil {
 		return taskCategory, err
 	}
 	return taskCategory, nil
 }
 
-// Deletes a task category from the database by ID
-func (re *TaskCategoryRepository) DeleteTaskCategory(taskCategoryID int, ctx context.Context) error {
-	rowsAff, err := models.TaskCategories(Where("id = ?", taskCategoryID)).DeleteAll(ctx, re.Database.Conn)
+// ErrReassignTargetNotFound is returned when the tasks of a deleted task category are reassigned to
+// a task category that does not exist
+var ErrReassignTargetNotFound = errors.New("the task category to reassign the tasks to does not exist")
+
+// TaskCategoryHasTasksError is returned when a task category cannot be deleted because of its tasks,
+// with the digest of the IDs of the tasks, which confirms a cascade deletion of exactly these tasks.
+type TaskCategoryHasTasksError struct {
+	Tasks  int64
+	Digest string
+}
+
+func (e *TaskCategoryHasTasksError) Error() string {
+	return fmt.Sprintf("the task category has %d tasks, reassign them or delete them with the category", e.Tasks)
+}
+
+// Moves a task category to the trash by ID, the task category is kept in the database until it is purged.
+// The tasks of the category are handled by the policy, and a category with tasks is only deleted when the
+// policy reassigns them or deletes them. The children of the category are moved to its parent.
+// All the changes are made in a single transaction.
+func (re *TaskCategoryRepository) DeleteTaskCategory(taskCategoryID, deletedBy int, policy internalModels.TaskCategoryDeletePolicy, ctx context.Context) (internalModels.TaskCategoryDeleteResult, error) {
+	result := internalModels.TaskCategoryDeleteResult{TaskCategoryID: taskCategoryID, ReassignedTo: policy.ReassignTo}
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		// The locked category cannot get new tasks until the transaction ends
+		var parentID null.Int
+		query := `SELECT parent_id FROM task_categories WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;`
+		if err := tx.QueryRowContext(ctx, query, taskCategoryID).Scan(&parentID); err != nil {
+			if err == sql.ErrNoRows {
+				return ErrNoMatch
+			}
+			return err
+		}
+		if policy.ReassignTo.Valid {
+			var targetID int
+			query := `SELECT id FROM task_categories WHERE id=$1 AND deleted_at IS NULL FOR UPDATE;`
+			if err := tx.QueryRowContext(ctx, query, policy.ReassignTo.Int).Scan(&targetID); err != nil {
+				if err == sql.ErrNoRows {
+					return ErrReassignTargetNotFound
+				}
+				return err
+			}
+		}
+		var (
+			tasks  int64
+			digest string
+		)
+		query = `SELECT COUNT(*), md5(COALESCE(string_agg(id::text, ',' ORDER BY id), '')) FROM tasks WHERE task_category_id=$1 AND deleted_at IS NULL;`
+		if err := tx.QueryRowContext(ctx, query, taskCategoryID).Scan(&tasks, &digest); err != nil {
+			return err
+		}
+
+		switch {
+		case policy.ReassignTo.Valid:
+			// The tasks in the trash are moved too, so they can still be restored
+			moved, err := tx.ExecContext(ctx, `UPDATE tasks SET task_category_id=$2, updated_at=now() WHERE task_category_id=$1;`, taskCategoryID, policy.ReassignTo.Int)
+			if err != nil {
+				return err
+			}
+			if result.ReassignedTasks, err = moved.RowsAffected(); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE calendar_feeds SET task_category_id=$2 WHERE task_category_id=$1;`, taskCategoryID, policy.ReassignTo.Int); err != nil {
+				return err
+			}
+		case policy.Cascade && tasks > 0:
+			// The tasks must not have changed since the deletion was confirmed
+			if policy.ConfirmedTasksDigest != digest {
+				return &TaskCategoryHasTasksError{Tasks: tasks, Digest: digest}
+			}
+			deleted, err := tx.ExecContext(ctx, `UPDATE tasks SET deleted_at=now(), deleted_by=$2 WHERE task_category_id=$1 AND deleted_at IS NULL;`, taskCategoryID, deletedBy)
+			if err != nil {
+				return err
+			}
+			if result.DeletedTasks, err = deleted.RowsAffected(); err != nil {
+				return err
+			}
+		case tasks > 0:
+			return &TaskCategoryHasTasksError{Tasks: tasks, Digest: digest}
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE task_categories SET parent_id=$2 WHERE parent_id=$1;`, taskCategoryID, parentID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE task_categories SET deleted_at=now(), deleted_by=$2 WHERE id=$1;`, taskCategoryID, deletedBy)
+		return err
+	})
+	return result, err
+}
+
+// Gets all task categories in the trash
+func (re *TaskCategoryRepository) GetDeletedTaskCategories(ctx context.Context) ([]internalModels.TrashItem, error) {
+	return re.Database.getTrashItems(ctx, models.TableNames.TaskCategories, internalModels.TrashTypeTaskCategory)
+}
+
+// Restores a task category from the trash by ID
+func (re *TaskCategoryRepository) RestoreTaskCategory(taskCategoryID int, ctx context.Context) error {
+	rowsAff, err := models.TaskCategories(Where("id = ?", taskCategoryID), Where("deleted_at IS NOT NULL")).UpdateAll(ctx, re.Database.Conn, models.M{"deleted_at": nil, "deleted_by": nil})
 	if err != nil {
 		return err
 	}
 	if rowsAff == 0 {
 		return ErrNoMatch
 	}
-	return err
+	return nil
+}
+
+// Permanently deletes a task category in the trash by ID, unless tasks are still in it
+func (re *TaskCategoryRepository) PurgeTaskCategory(taskCategoryID int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		return purgeUnreferencedItem(ctx, tx, models.TableNames.TaskCategories, "task_category_id", taskCategoryID)
+	})
+}
+
+// Permanently deletes all task categories that were moved to the trash before the given time. Categories
+// that still have tasks are kept and returned.
+func (re *TaskCategoryRepository) PurgeTaskCategoriesDeletedBefore(before time.Time, ctx context.Context) (int64, []internalModels.TrashItem, error) {
+	var rowsAff int64
+	var blocked []internalModels.TrashItem
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		rowsAff, blocked, err = purgeUnreferenced(ctx, tx, models.TableNames.TaskCategories, internalModels.TrashTypeTaskCategory, "task_category_id", before)
+		return err
+	})
+	return rowsAff, blocked, err
 }
 
 // Updates a task category in the database by ID
@@ -67,9 +242,112 @@ func (re *TaskCategoryRepository) UpdateTaskCategory(taskCategory *models.TaskCa
 	return taskCategory, nil
 }
 
+// Moves a task category under a parent, or to the root when the parent is null. The tree is locked and
+// checked with the validate function in the same transaction, so concurrent moves cannot create a cycle.
+func (re *TaskCategoryRepository) MoveTaskCategory(taskCategoryID int, parentID null.Int, validate func(models.TaskCategorySlice) error, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		taskCategories, err := lockTaskCategoryTree(tx, ctx)
+		if err != nil {
+			return err
+		}
+		if err := validate(taskCategories); err != nil {
+			return err
+		}
+		rowsAff, err := models.TaskCategories(Where("id = ?", taskCategoryID), Where("deleted_at IS NULL")).UpdateAll(ctx, tx, models.M{"parent_id": parentID})
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return ErrNoMatch
+		}
+		return nil
+	})
+}
+
+// Locks every task category that is not in the trash and returns them. A change of the tree checked against
+// the locked categories waits for the other changes, which could otherwise create a cycle through other nodes.
+func lockTaskCategoryTree(tx *sql.Tx, ctx context.Context) (models.TaskCategorySlice, error) {
+	return models.TaskCategories(Where("deleted_at IS NULL"), OrderBy("id"), For("UPDATE")).All(ctx, tx)
+}
+
+// Moves the tasks, the child categories and the calendar feeds of a task category to a target category
+// and moves the source category to the trash, in a single transaction. The tree is locked and checked with
+// the validate function first, so neither category can be deleted, moved or merged while the tasks move.
+// Returns the number of moved tasks.
+func (re *TaskCategoryRepository) MergeTaskCategory(sourceID, targetID, deletedBy int, validate func(models.TaskCategorySlice) error, ctx context.Context) (int64, error) {
+	var movedTasks int64
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		taskCategories, err := lockTaskCategoryTree(tx, ctx)
+		if err != nil {
+			return err
+		}
+		if err := validate(taskCategories); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, `UPDATE tasks SET task_category_id=$2, updated_at=now() WHERE task_category_id=$1;`, sourceID, targetID)
+		if err != nil {
+			return err
+		}
+		if movedTasks, err = result.RowsAffected(); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE task_categories SET parent_id=$2 WHERE parent_id=$1;`, sourceID, targetID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE calendar_feeds SET task_category_id=$2 WHERE task_category_id=$1;`, sourceID, targetID); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE task_categories SET deleted_at=now(), deleted_by=$2, parent_id=NULL WHERE id=$1;`, sourceID, deletedBy)
+		return err
+	})
+	return movedTasks, err
+}
+
+// Gets the settings of a task category by ID
+func (re *TaskCategoryRepository) GetTaskCategorySettings(taskCategoryID int, ctx context.Context) (internalModels.TaskCategorySettings, error) {
+	settings := internalModels.TaskCategorySettings{TaskCategoryID: taskCategoryID}
+	query := `SELECT require_checklist_completion, sla_start_within_hours, sla_complete_within_business_hours, sla_escalate_to_user_id,
+		visibility FROM task_categories WHERE id=$1 AND deleted_at IS NULL;`
+	err := re.Database.Conn.QueryRowContext(ctx, query, taskCategoryID).Scan(&settings.RequireChecklistCompletion,
+		&settings.SLA.StartWithinHours, &settings.SLA.CompleteWithinBusinessHours, &settings.SLA.EscalateToUserID,
+		&settings.Visibility)
+	if err == sql.ErrNoRows {
+		return settings, ErrNoMatch
+	}
+	return settings, err
+}
+
+// Updates the settings of a task category. The escalation user of the SLA policy must not be deleted.
+func (re *TaskCategoryRepository) UpdateTaskCategorySettings(settings internalModels.TaskCategorySettings, ctx context.Context) error {
+	if settings.SLA.EscalateToUserID.Valid {
+		exists, err := models.Users(Where("id = ?", settings.SLA.EscalateToUserID.Int), Where("deleted_at IS NULL")).Exists(ctx, re.Database.Conn)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrEscalationUserNotFound
+		}
+	}
+	query := `UPDATE task_categories SET require_checklist_completion=$2, sla_start_within_hours=$3,
+		sla_complete_within_business_hours=$4, sla_escalate_to_user_id=$5, visibility=$6 WHERE id=$1 AND deleted_at IS NULL;`
+	result, err := re.Database.Conn.ExecContext(ctx, query, settings.TaskCategoryID, settings.RequireChecklistCompletion,
+		settings.SLA.StartWithinHours, settings.SLA.CompleteWithinBusinessHours, settings.SLA.EscalateToUserID, settings.Visibility)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
 // Get all tasks that have a given category by URL parameter
 func (re *TaskCategoryRepository) GetTasksByCategory(taskCategoryID int, ctx context.Context) (models.TaskSlice, error) {
-	tasks, err := models.Tasks(InnerJoin("task_categories c on c.id = tasks.task_category_id"), Where("tasks.task_category_id = ?", taskCategoryID)).All(ctx, re.Database.Conn)
+	tasks, err := models.Tasks(InnerJoin("task_categories c on c.id = tasks.task_category_id"), Where("tasks.task_category_id = ?", taskCategoryID), Where("tasks.deleted_at IS NULL"), Where("c.deleted_at IS NULL")).All(ctx, re.Database.Conn)
 	if err != nil {
 		return tasks, err
 	}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+// Tasks of a dashboard scope: the tasks a user authored or is assigned to, or every task when the
+// user ID is 0. Tasks in the trash and archived tasks are left out, tasks without status are not started.
+const taskSummaryScope = `WITH scope AS (
+	SELECT t.id, COALESCE(t.status, 'Not Started') AS status, t.end_date, t.task_category_id
+	FROM tasks t
+	WHERE t.deleted_at IS NULL AND t.archived_at IS NULL
+		AND ($1 = 0 OR t.author_id = $1 OR EXISTS (SELECT 1 FROM user_task_details d WHERE d.task_id = t.id AND d.user_id = $1))
+) `
+
+// Counts the tasks of a user, or of every user when the user ID is 0, with one grouped query per
+// breakdown. Overdue tasks are open tasks that ended before now and tasks due this week are open
+// tasks that end between now and the end of the week.
+func (re *TaskRepository) GetTaskSummary(userID int, now, weekEnd time.Time, topAssignees int, ctx context.Context) (internalModels.TaskSummary, error) {
+	summary := internalModels.TaskSummary{
+		ByStatus:     map[string]int{},
+		ByCategory:   []internalModels.CategoryTaskCount{},
+		TopAssignees: []internalModels.AssigneeWorkload{},
+	}
+
+	query := taskSummaryScope + `SELECT status, COUNT(*),
+			COUNT(*) FILTER (WHERE status <> $4 AND end_date < $2),
+			COUNT(*) FILTER (WHERE status <> $4 AND end_date >= $2 AND end_date < $3),
+			COUNT(*) FILTER (WHERE NOT EXISTS (SELECT 1 FROM user_task_details d WHERE d.task_id = scope.id))
+		FROM scope GROUP BY status;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID, now, weekEnd, string(Complete))
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var status string
+		var count, overdue, dueThisWeek, unassigned int
+		if err := rows.Scan(&status, &count, &overdue, &dueThisWeek, &unassigned); err != nil {
+			return summary, err
+		}
+		summary.ByStatus[status] = count
+		summary.Total += count
+		summary.Overdue += overdue
+		summary.DueThisWeek += dueThisWeek
+		summary.Unassigned += unassigned
+	}
+	if err := rows.Err(); err != nil {
+		return summary, err
+	}
+
+	query = taskSummaryScope + `SELECT c.id, c.name, COUNT(*)
+		FROM scope INNER JOIN task_categories c ON c.id = scope.task_category_id
+		GROUP BY c.id, c.name ORDER BY COUNT(*) DESC, c.id;`
+	rows, err = re.Database.Conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var count internalModels.CategoryTaskCount
+		if err := rows.Scan(&count.TaskCategoryID, &count.Name, &count.Count); err != nil {
+			return summary, err
+		}
+		summary.ByCategory = append(summary.ByCategory, count)
+	}
+	if err := rows.Err(); err != nil {
+		return summary, err
+	}
+
+	query = taskSummaryScope + `SELECT u.id, u.name, u.email, COUNT(*)
+		FROM scope
+		INNER JOIN user_task_details d ON d.task_id = scope.id
+		INNER JOIN users u ON u.id = d.user_id AND u.deleted_at IS NULL
+		WHERE scope.status <> $2
+		GROUP BY u.id, u.name, u.email ORDER BY COUNT(*) DESC, u.id LIMIT $3;`
+	rows, err = re.Database.Conn.QueryContext(ctx, query, userID, string(Complete), topAssignees)
+	if err != nil {
+		return summary, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var workload internalModels.AssigneeWorkload
+		if err := rows.Scan(&workload.UserID, &workload.Name, &workload.Email, &workload.OpenTasks); err != nil {
+			return summary, err
+		}
+		summary.TopAssignees = append(summary.TopAssignees, workload)
+	}
+	return summary, rows.Err()
+}
@@ -5,8 +5,12 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/lib/pq"
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
@@ -29,12 +33,28 @@ const (
 	Lock       TaskStatus = "Lock"
 )
 
-func (re *TaskRepository) GetAllTasks(ctx context.Context, filterValues map[string]interface{}) (models.TaskSlice, error) {
-	var (
-		sortField string
-		sortOrder string
-	)
-	query := []QueryMod{Where("1=1")}
+// Checks if a status is one of the task status values
+func IsValidTaskStatus(status string) bool {
+	switch TaskStatus(status) {
+	case NotStarted, InProgress, Complete, Lock:
+		return true
+	}
+	return false
+}
+
+// Selects the IDs of a task category and of all its descendants
+const taskCategoryDescendantsQuery = `WITH RECURSIVE tree AS (
+	SELECT id FROM task_categories WHERE id = ?
+	UNION
+	SELECT c.id FROM task_categories c INNER JOIN tree ON c.parent_id = tree.id
+) SELECT id FROM tree`
+
+// Builds the query mods that filter tasks by the given field values, deleted tasks are always excluded.
+// Archived tasks are only filtered by the archived value, and a category filter includes the
+// descendant categories when include_descendants is true. visible_to_user_id keeps the tasks of the
+// categories the user can see.
+func taskFilterQueryMods(filterValues map[string]interface{}) ([]QueryMod, error) {
+	query := []QueryMod{Where("deleted_at IS NULL")}
 	for field, value := range filterValues {
 		switch field {
 		case "id":
@@ -72,18 +92,55 @@ func (re *TaskRepository) GetAllTasks(ctx context.Context, filterValues map[stri
 			if !ok {
 				return nil, errors.New("cannot convert string task_category_id to int")
 			}
-			query = append(query, Where("task_category_id = ?", valueConv))
+			if filterValues["include_descendants"] == true {
+				query = append(query, Where("task_category_id IN ("+taskCategoryDescendantsQuery+")", valueConv))
+			} else {
+				query = append(query, Where("task_category_id = ?", valueConv))
+			}
+		case "visible_to_user_id":
+			valueConv, ok := value.(int)
+			if !ok {
+				return nil, errors.New("cannot convert interface{} visible_to_user_id to int")
+			}
+			query = append(query, Where("task_category_id IN ("+taskCategoriesVisibleToQuery+")", valueConv))
+		case "archived":
+			valueConv, ok := value.(bool)
+			if !ok {
+				return nil, errors.New("cannot convert interface{} archived to bool")
+			}
+			if valueConv {
+				query = append(query, Where("archived_at IS NOT NULL"))
+			} else {
+				query = append(query, Where("archived_at IS NULL"))
+			}
+		}
+	}
+	return query, nil
+}
+
+func (re *TaskRepository) GetAllTasks(ctx context.Context, filterValues map[string]interface{}) (models.TaskSlice, error) {
+	var (
+		sortField string
+		sortOrder string
+	)
+	query, err := taskFilterQueryMods(filterValues)
+	if err != nil {
+		return nil, err
+	}
+	for field, value := range filterValues {
+		switch field {
 		case "field":
-			sortField, ok := value.(string)
+			var ok bool
+			sortField, ok = value.(string)
 			if !ok {
 				return nil, errors.New("cannot convert interface{} sortfield to string")
 			}
 		case "order":
-			sortOrder, ok := value.(string)
+			var ok bool
+			sortOrder, ok = value.(string)
 			if !ok {
 				return nil, errors.New("cannot convert interface{} sortorder to string")
 			}
-			// query = append(query, OrderBy(fmt.Sprintf(sortField+" "+sortOrder)))
 		case "page":
 			pageNumber, ok := value.(int)
 			if !ok {
@@ -109,28 +166,233 @@ func (re *TaskRepository) GetAllTasks(ctx context.Context, filterValues map[stri
 
 	tasks := make(models.TaskSlice, 0)
 	for _, x := range rows {
-		// var task *models.Task
-		if err != nil {
-			return nil, err
-		}
 		tasks = append(tasks, x)
 	}
 
 	return tasks, nil
 }
 
+// Reads the tasks matching the given filter values one at a time, with the name of their category and
+// their assignees, and calls fn with each of them. Pages are ignored, and fn is not called when the query fails.
+func (re *TaskRepository) ForEachTaskExport(filterValues map[string]interface{}, fn func(internalModels.TaskExport) error, ctx context.Context) error {
+	query, err := taskFilterQueryMods(filterValues)
+	if err != nil {
+		return err
+	}
+	sortField, sortOrder := "id", "asc"
+	if value, ok := filterValues["field"].(string); ok {
+		sortField = value
+	}
+	if value, ok := filterValues["order"].(string); ok {
+		sortOrder = value
+	}
+	query = append(query,
+		Select(
+			"tasks.id", "tasks.name", "tasks.description", "tasks.start_date", "tasks.end_date", "tasks.status", "tasks.author_id", "tasks.task_category_id",
+			"(SELECT c.name FROM task_categories c WHERE c.id = tasks.task_category_id) AS task_category_name",
+			"ARRAY(SELECT d.user_id FROM user_task_details d WHERE d.task_id = tasks.id ORDER BY d.user_id) AS assignee_ids",
+			"ARRAY(SELECT u.email FROM user_task_details d INNER JOIN users u ON u.id = d.user_id WHERE d.task_id = tasks.id ORDER BY d.user_id) AS assignee_emails",
+			"tasks.external_source", "tasks.external_id", "tasks.archived_at", "tasks.created_at", "tasks.updated_at",
+		),
+		// The ID keeps the order stable when the sort field has equal values
+		OrderBy(fmt.Sprintf("tasks.%s %s, tasks.id", sortField, sortOrder)),
+	)
+	rows, err := models.Tasks(query...).QueryContext(ctx, re.Database.Conn)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			task           internalModels.TaskExport
+			taskCategory   sql.NullString
+			assigneeIDs    pq.Int64Array
+			assigneeEmails pq.StringArray
+		)
+		err := rows.Scan(&task.ID, &task.Name, &task.Description, &task.StartDate, &task.EndDate, &task.Status, &task.AuthorID, &task.TaskCategoryID,
+			&taskCategory, &assigneeIDs, &assigneeEmails, &task.ExternalSource, &task.ExternalID, &task.ArchivedAt, &task.CreatedAt, &task.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		task.TaskCategoryName = taskCategory.String
+		task.AssigneeIDs = toIntSlice(assigneeIDs)
+		task.AssigneeEmails = []string(assigneeEmails)
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Gets the IDs of all tasks matching the given filter values
+func (re *TaskRepository) GetTaskIDsByFilter(filterValues map[string]interface{}, ctx context.Context) ([]int, error) {
+	query, err := taskFilterQueryMods(filterValues)
+	if err != nil {
+		return nil, err
+	}
+	query = append(query, Select("id"), OrderBy("id"))
+	tasks, err := models.Tasks(query...).All(ctx, re.Database.Conn)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids, nil
+}
+
+// Gets all tasks with the given IDs, deleted tasks are skipped
+func (re *TaskRepository) GetTasksByIDs(taskIDs []int, ctx context.Context) (models.TaskSlice, error) {
+	return models.Tasks(models.TaskWhere.ID.IN(taskIDs), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
+}
+
+// Gets which of the given tasks a user is assigned to
+func (re *TaskRepository) GetAssignedTaskIDs(userID int, taskIDs []int, ctx context.Context) (map[int]bool, error) {
+	assigned := make(map[int]bool)
+	rows, err := re.Database.Conn.QueryContext(ctx, `SELECT task_id FROM user_task_details WHERE user_id=$1 AND task_id = ANY($2);`, userID, toInt64Array(taskIDs))
+	if err != nil {
+		return assigned, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		if err := rows.Scan(&taskID); err != nil {
+			return assigned, err
+		}
+		assigned[taskID] = true
+	}
+	return assigned, rows.Err()
+}
+
 // Adds a new task to the database
 func (re *TaskRepository) AddTask(task *models.Task, ctx context.Context) error {
-	err := task.Insert(ctx, re.Database.Conn, boil.Infer())
-	if err != nil {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := task.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+		return recordInitialStatus(task.ID, ctx, tx)
+	})
+}
+
+// Adds the given tasks and assigns each author to their task in a single transaction
+func (re *TaskRepository) AddTasks(tasks []*models.Task, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, task := range tasks {
+			if err := task.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+			if err := recordInitialStatus(task.ID, ctx, tx); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO user_task_details(user_id, task_id) VALUES($1, $2);`, task.AuthorID, task.ID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Adds a task and its subtasks with their assignees in a single transaction, the subtasks are linked to
+// the task as their parent
+func (re *TaskRepository) AddTaskWithSubtasks(task *models.Task, assigneeIDs []int, subtasks []*models.Task, subtaskAssigneeIDs [][]int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := addTaskWithAssignees(task, assigneeIDs, ctx, tx); err != nil {
+			return err
+		}
+		for i, subtask := range subtasks {
+			subtask.ParentTaskID = null.IntFrom(task.ID)
+			if err := addTaskWithAssignees(subtask, subtaskAssigneeIDs[i], ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func addTaskWithAssignees(task *models.Task, assigneeIDs []int, ctx context.Context, tx *sql.Tx) error {
+	if err := task.Insert(ctx, tx, boil.Infer()); err != nil {
+		return err
+	}
+	if err := recordInitialStatus(task.ID, ctx, tx); err != nil {
 		return err
 	}
+	for _, userID := range assigneeIDs {
+		_, err := tx.ExecContext(ctx, `INSERT INTO user_task_details(user_id, task_id) VALUES($1, $2);`, userID, task.ID)
+		if err != nil {
+			return fmt.Errorf("cannot assign user %d to task %d: %w", userID, task.ID, err)
+		}
+	}
 	return nil
 }
 
+// Columns of a task that are written when an import updates it
+var taskUpsertColumns = boil.Whitelist(
+	models.TaskColumns.Name,
+	models.TaskColumns.Description,
+	models.TaskColumns.StartDate,
+	models.TaskColumns.EndDate,
+	models.TaskColumns.Status,
+	models.TaskColumns.AuthorID,
+	models.TaskColumns.TaskCategoryID,
+	models.TaskColumns.ArchivedAt,
+	models.TaskColumns.UpdatedAt,
+)
+
+// Inserts or updates the given tasks by their external key and archives the tasks of the given IDs
+// in a single transaction. The author of a task without ID is assigned to it once it is inserted.
+func (re *TaskRepository) UpsertTasks(tasks []*models.Task, archiveTaskIDs []int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, task := range tasks {
+			created := task.ID == 0
+			if !created {
+				if err := recordStatusChange(task.ID, task.Status, ctx, tx); err != nil {
+					return err
+				}
+			}
+			err := task.Upsert(ctx, tx, true, []string{models.TaskColumns.ExternalSource, models.TaskColumns.ExternalID}, taskUpsertColumns, boil.Infer())
+			if err != nil {
+				return err
+			}
+			if created {
+				if err := recordInitialStatus(task.ID, ctx, tx); err != nil {
+					return err
+				}
+				_, err := tx.ExecContext(ctx, `INSERT INTO user_task_details(user_id, task_id) VALUES($1, $2);`, task.AuthorID, task.ID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if len(archiveTaskIDs) == 0 {
+			return nil
+		}
+		query := `UPDATE tasks SET archived_at=now(), updated_at=now() WHERE id = ANY($1) AND archived_at IS NULL AND deleted_at IS NULL;`
+		_, err := tx.ExecContext(ctx, query, toInt64Array(archiveTaskIDs))
+		return err
+	})
+}
+
+// Gets the tasks that were imported from an external source and are not deleted
+func (re *TaskRepository) GetTasksByExternalSource(source string, ctx context.Context) (models.TaskSlice, error) {
+	tasks, err := models.Tasks(models.TaskWhere.ExternalSource.EQ(null.StringFrom(source)), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
+	if err != nil {
+		return tasks, err
+	}
+	return tasks, nil
+}
+
+// Gets the external IDs of the tasks of an external source that are in the trash
+func (re *TaskRepository) GetTrashedTaskExternalIDs(source string, ctx context.Context) (map[string]bool, error) {
+	return re.Database.getTrashedExternalIDs(ctx, "tasks", source)
+}
+
 // Retrieves a task from the database by ID
 func (re *TaskRepository) GetTaskByID(taskID int, ctx context.Context) (*models.Task, error) {
-	task, err := models.Tasks(Where("id = ?", taskID)).One(ctx, re.Database.Conn)
+	task, err := models.Tasks(Where("id = ?", taskID), Where("deleted_at IS NULL")).One(ctx, re.Database.Conn)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -141,9 +403,9 @@ func (re *TaskRepository) GetTaskByID(taskID int, ctx context.Context) (*models.
 	return task, nil
 }
 
-// Deletes a task from the database by ID
-func (re *TaskRepository) DeleteTask(taskID int, ctx context.Context) error {
-	rowsAff, err := models.Tasks(Where("id = ?", taskID)).DeleteAll(ctx, re.Database.Conn)
+// Moves a task to the trash by ID, the task is kept in the database until it is purged
+func (re *TaskRepository) DeleteTask(taskID, deletedBy int, ctx context.Context) error {
+	rowsAff, err := models.Tasks(Where("id = ?", taskID), Where("deleted_at IS NULL")).UpdateAll(ctx, re.Database.Conn, models.M{"deleted_at": time.Now(), "deleted_by": deletedBy})
 	if err != nil {
 		return err
 	}
@@ -153,22 +415,14 @@ func (re *TaskRepository) DeleteTask(taskID int, ctx context.Context) error {
 	return err
 }
 
-// Updates a task in the database by ID
-func (re *TaskRepository) UpdateTask(task *models.Task, ctx context.Context) (*models.Task, error) {
-	rowsAff, err := task.Update(ctx, re.Database.Conn, boil.Infer())
-	if err != nil {
-		return task, err
-	}
-	if rowsAff == 0 {
-		return task, ErrNoMatch
-	}
-	return task, nil
-
+// Gets all tasks in the trash
+func (re *TaskRepository) GetDeletedTasks(ctx context.Context) ([]internalModels.TrashItem, error) {
+	return re.Database.getTrashItems(ctx, models.TableNames.Tasks, internalModels.TrashTypeTask)
 }
 
-// Locks a task in the database by ID
-func (re *TaskRepository) LockTask(taskID int, ctx context.Context) error {
-	rowsAff, err := models.Tasks(Where("id = ?", taskID)).UpdateAll(ctx, re.Database.Conn, models.M{"status": Lock})
+// Restores a task from the trash by ID
+func (re *TaskRepository) RestoreTask(taskID int, ctx context.Context) error {
+	rowsAff, err := models.Tasks(Where("id = ?", taskID), Where("deleted_at IS NOT NULL")).UpdateAll(ctx, re.Database.Conn, models.M{"deleted_at": nil, "deleted_by": nil})
 	if err != nil {
 		return err
 	}
@@ -178,9 +432,116 @@ func (re *TaskRepository) LockTask(taskID int, ctx context.Context) error {
 	return nil
 }
 
+// Permanently deletes a task in the trash and its assignments by ID
+func (re *TaskRepository) PurgeTask(taskID int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM user_task_details WHERE task_id IN (SELECT id FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL);`, taskID)
+		if err != nil {
+			return err
+		}
+		rowsAff, err := models.Tasks(Where("id = ?", taskID), Where("deleted_at IS NOT NULL")).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return ErrNoMatch
+		}
+		return nil
+	})
+}
+
+// Permanently deletes all tasks that were moved to the trash before the given time
+func (re *TaskRepository) PurgeTasksDeletedBefore(before time.Time, ctx context.Context) (int64, error) {
+	var rowsAff int64
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM user_task_details WHERE task_id IN (SELECT id FROM tasks WHERE deleted_at < $1);`, before)
+		if err != nil {
+			return err
+		}
+		rowsAff, err = models.Tasks(Where("deleted_at < ?", before)).DeleteAll(ctx, tx)
+		return err
+	})
+	return rowsAff, err
+}
+
+// Updates a task in the database by ID
+func (re *TaskRepository) UpdateTask(task *models.Task, ctx context.Context) (*models.Task, error) {
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := recordStatusChange(task.ID, task.Status, ctx, tx); err != nil {
+			return err
+		}
+		rowsAff, err := task.Update(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return ErrNoMatch
+		}
+		return nil
+	})
+	return task, err
+}
+
+// Locks a task in the database by ID
+func (re *TaskRepository) LockTask(taskID int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		return setTaskStatus(taskID, Lock, models.M{}, ctx, tx)
+	})
+}
+
 // Unlocks a task in the database by ID
 func (re *TaskRepository) UnLockTask(taskID int, ctx context.Context) error {
-	rowsAff, err := models.Tasks(Where("id = ?", taskID)).UpdateAll(ctx, re.Database.Conn, models.M{"status": InProgress})
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		return setTaskStatus(taskID, InProgress, models.M{}, ctx, tx)
+	})
+}
+
+// Sets the status and the given columns of a task that is not in the trash and records the transition
+func setTaskStatus(taskID int, status TaskStatus, cols models.M, ctx context.Context, exec boil.ContextExecutor) error {
+	if err := recordStatusChange(taskID, null.StringFrom(string(status)), ctx, exec); err != nil {
+		return err
+	}
+	cols["status"] = status
+	return updateTaskColumns(taskID, cols, ctx, exec)
+}
+
+// Records the transition of a task to a new status before the status is written, nothing is recorded
+// when the status does not change. The task is locked until the end of the transaction, so the
+// recorded previous status is still the current one when the new status is written.
+func recordStatusChange(taskID int, status null.String, ctx context.Context, exec boil.ContextExecutor) error {
+	query := `INSERT INTO task_status_transitions(task_id, from_status, to_status)
+		SELECT id, status, $2::VARCHAR FROM tasks WHERE id = $1 AND deleted_at IS NULL AND status IS DISTINCT FROM $2::VARCHAR FOR UPDATE;`
+	_, err := exec.ExecContext(ctx, query, taskID, status)
+	return err
+}
+
+// Records the status of a new task as its first transition, at the creation time of the task
+func recordInitialStatus(taskID int, ctx context.Context, exec boil.ContextExecutor) error {
+	query := `INSERT INTO task_status_transitions(task_id, from_status, to_status, changed_at)
+		SELECT id, NULL, status, created_at FROM tasks WHERE id = $1;`
+	_, err := exec.ExecContext(ctx, query, taskID)
+	return err
+}
+
+// Archives a task by ID, archived tasks are left out of the task list. A task that is already
+// archived keeps its archive time.
+func (re *TaskRepository) ArchiveTask(taskID int, ctx context.Context) error {
+	query := `UPDATE tasks SET archived_at=COALESCE(archived_at, now()), updated_at=now() WHERE id=$1 AND deleted_at IS NULL;`
+	return re.execTaskUpdate(query, taskID, ctx)
+}
+
+// Unarchives a task by ID
+func (re *TaskRepository) UnarchiveTask(taskID int, ctx context.Context) error {
+	query := `UPDATE tasks SET archived_at=NULL, updated_at=now() WHERE id=$1 AND deleted_at IS NULL;`
+	return re.execTaskUpdate(query, taskID, ctx)
+}
+
+func (re *TaskRepository) execTaskUpdate(query string, taskID int, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, query, taskID)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
 	if err != nil {
 		return err
 	}
@@ -190,10 +551,24 @@ func (re *TaskRepository) UnLockTask(taskID int, ctx context.Context) error {
 	return nil
 }
 
+// Archives the complete tasks that were completed before the given time. The completion time is the last
+// transition to complete, or the last update of a task without one.
+func (re *TaskRepository) ArchiveTasksCompletedBefore(before time.Time, ctx context.Context) (int64, error) {
+	query := `UPDATE tasks SET archived_at=now(), updated_at=now()
+		WHERE status = $1 AND archived_at IS NULL AND deleted_at IS NULL
+			AND COALESCE((SELECT MAX(s.changed_at) FROM task_status_transitions s WHERE s.task_id = tasks.id AND s.to_status = $1), updated_at) < $2;`
+	result, err := re.Database.Conn.ExecContext(ctx, query, string(Complete), before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // Get the task category of a task
 func (re *TaskRepository) GetTaskCategoryOfTask(taskID int, ctx context.Context) (*models.TaskCategory, error) {
 	task, err := models.Tasks(
 		Where("id = ?", taskID),
+		Where("deleted_at IS NULL"),
 	).One(ctx, re.Database.Conn)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -202,7 +577,7 @@ func (re *TaskRepository) GetTaskCategoryOfTask(taskID int, ctx context.Context)
 		return nil, err
 	}
 
-	taskCategory, err := task.TaskCategory().One(ctx, re.Database.Conn)
+	taskCategory, err := task.TaskCategory(Where("deleted_at IS NULL")).One(ctx, re.Database.Conn)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return taskCategory, ErrNoMatch
@@ -214,7 +589,7 @@ func (re *TaskRepository) GetTaskCategoryOfTask(taskID int, ctx context.Context)
 
 // Get tasks filter by name in query
 func (re *TaskRepository) GetTasksByName(name string, ctx context.Context) (models.TaskSlice, error) {
-	tasks, err := models.Tasks(Where("name LIKE ?", "%"+name+"%")).All(ctx, re.Database.Conn)
+	tasks, err := models.Tasks(Where("name LIKE ?", "%"+name+"%"), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return tasks, ErrNoMatch
@@ -224,24 +599,6 @@ func (re *TaskRepository) GetTasksByName(name string, ctx context.Context) (mode
 	return tasks, nil
 }
 
-// Total number of tasks
-func (re *TaskRepository) GetTaskCount(ctx context.Context) (int64, error) {
-	count, err := models.Tasks().Count(ctx, re.Database.Conn)
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
-}
-
-// Total number of filtered status tasks
-func (re *TaskRepository) CountFilteredStatusTask(status string, ctx context.Context) (int64, error) {
-	count, err := models.Tasks(Where("status = ?", status)).Count(ctx, re.Database.Conn)
-	if err != nil {
-		return -1, err
-	}
-	return count, nil
-}
-
 // func (re *TaskRepository) FilterTasks(, ctx context.Context) (models.TaskSlice, error) {
 // 	// Build a query using SQLBoiler's query builder.
 
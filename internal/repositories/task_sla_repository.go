@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/volatiletech/null/v8"
+)
+
+var ErrEscalationUserNotFound = errors.New("the escalation user of the SLA policy does not exist")
+
+// Columns of the SLA state of a task, read from its category and its status transitions
+const taskSLAStateColumns = `t.id, t.created_at, c.sla_start_within_hours, c.sla_complete_within_business_hours, c.sla_escalate_to_user_id,
+	(SELECT MIN(s.changed_at) FROM task_status_transitions s WHERE s.task_id = t.id AND s.to_status IS NOT NULL AND s.to_status <> 'Not Started'),
+	CASE WHEN t.status = 'Complete' THEN (SELECT MAX(s.changed_at) FROM task_status_transitions s WHERE s.task_id = t.id AND s.to_status = 'Complete') END`
+
+func scanTaskSLAState(row rowScanner) (internalModels.TaskSLAState, error) {
+	var state internalModels.TaskSLAState
+	err := row.Scan(&state.TaskID, &state.CreatedAt, &state.Policy.StartWithinHours, &state.Policy.CompleteWithinBusinessHours,
+		&state.Policy.EscalateToUserID, &state.StartedAt, &state.CompletedAt)
+	return state, err
+}
+
+func scanTaskSLAStates(rows *sql.Rows) ([]internalModels.TaskSLAState, error) {
+	defer rows.Close()
+	states := []internalModels.TaskSLAState{}
+	for rows.Next() {
+		state, err := scanTaskSLAState(rows)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, rows.Err()
+}
+
+// Gets the SLA state of the tasks of the given IDs by task ID
+func (re *TaskRepository) GetTaskSLAStates(taskIDs []int, ctx context.Context) (map[int]internalModels.TaskSLAState, error) {
+	states := make(map[int]internalModels.TaskSLAState)
+	if len(taskIDs) == 0 {
+		return states, nil
+	}
+	query := `SELECT ` + taskSLAStateColumns + ` FROM tasks t INNER JOIN task_categories c ON c.id = t.task_category_id WHERE t.id = ANY($1);`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, toInt64Array(taskIDs))
+	if err != nil {
+		return states, err
+	}
+	list, err := scanTaskSLAStates(rows)
+	if err != nil {
+		return states, err
+	}
+	for _, state := range list {
+		states[state.TaskID] = state
+	}
+	return states, nil
+}
+
+// Gets the SLA state of the open tasks of the categories with an SLA policy. Tasks in the trash
+// and archived tasks are left out.
+func (re *TaskRepository) GetOpenTaskSLAStates(ctx context.Context) ([]internalModels.TaskSLAState, error) {
+	query := `SELECT ` + taskSLAStateColumns + ` FROM tasks t INNER JOIN task_categories c ON c.id = t.task_category_id
+		WHERE t.deleted_at IS NULL AND t.archived_at IS NULL AND t.status IS DISTINCT FROM 'Complete'
+			AND (c.sla_start_within_hours IS NOT NULL OR c.sla_complete_within_business_hours IS NOT NULL);`
+	rows, err := re.Database.Conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return scanTaskSLAStates(rows)
+}
+
+// Records the breach of a task and, in the same transaction, notifies the owners of its category, the users
+// whose role grants the given permission and the escalation user, and assigns the escalation user to the task. Returns false when the breach was already escalated.
+func (re *TaskRepository) EscalateSLABreach(taskID int, breach, message string, permission internalModels.Permission, escalateToUserID null.Int, ctx context.Context) (bool, error) {
+	escalated := false
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `INSERT INTO task_sla_escalations(task_id, breach) VALUES($1, $2) ON CONFLICT (task_id, breach) DO NOTHING;`, taskID, breach)
+		if err != nil {
+			return err
+		}
+		rowsAff, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAff == 0 {
+			return nil
+		}
+		escalated = true
+		query := `INSERT INTO notifications(user_id, type, task_id, message)
+			SELECT u.id, $1, $2, $3 FROM users u WHERE u.deleted_at IS NULL AND (
+				u.id = $5
+				OR EXISTS (SELECT 1 FROM role_permissions rp WHERE rp.role = u.role AND rp.permission = $4)
+				OR EXISTS (SELECT 1 FROM tasks t INNER JOIN task_category_members m ON m.task_category_id = t.task_category_id
+					WHERE t.id = $2 AND m.user_id = u.id AND m.role = $6));`
+		_, err = tx.ExecContext(ctx, query, internalModels.NotificationSLABreach, taskID, message, permission, escalateToUserID, internalModels.TaskCategoryRoleOwner)
+		if err != nil {
+			return err
+		}
+		if !escalateToUserID.Valid {
+			return nil
+		}
+		// An escalation user who has not verified their email is notified without being assigned
+		query = `INSERT INTO user_task_details(user_id, task_id)
+			SELECT $1, $2 WHERE NOT EXISTS (SELECT 1 FROM user_task_details WHERE user_id = $1 AND task_id = $2)
+			AND EXISTS (SELECT 1 FROM users WHERE id = $1 AND email_verified_at IS NOT NULL);`
+		_, err = tx.ExecContext(ctx, query, escalateToUserID, taskID)
+		return err
+	})
+	return escalated, err
+}
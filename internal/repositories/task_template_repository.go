@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+	"github.com/qthuy2k1/task-management-app/internal/models"
+)
+
+type TaskTemplateRepository struct {
+	Database *Database
+}
+
+func NewTaskTemplateRepository(database *Database) *TaskTemplateRepository {
+	return &TaskTemplateRepository{Database: database}
+}
+
+const taskTemplateColumns = `id, name, task_name, task_description, task_category_id, start_offset_hours, end_offset_hours, assignee_ids, subtasks, created_by, created_at, updated_at`
+
+func scanTaskTemplate(row rowScanner) (models.TaskTemplate, error) {
+	var (
+		template    models.TaskTemplate
+		assigneeIDs pq.Int64Array
+		subtasks    []byte
+	)
+	err := row.Scan(&template.ID, &template.Name, &template.TaskName, &template.TaskDescription, &template.TaskCategoryID, &template.StartOffsetHours, &template.EndOffsetHours, &assigneeIDs, &subtasks, &template.CreatedBy, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return template, err
+	}
+	template.AssigneeIDs = toIntSlice(assigneeIDs)
+	if err := json.Unmarshal(subtasks, &template.Subtasks); err != nil {
+		return template, err
+	}
+	return template, nil
+}
+
+// Gets all task templates from the database
+func (re *TaskTemplateRepository) GetAllTaskTemplates(ctx context.Context) ([]models.TaskTemplate, error) {
+	list := []models.TaskTemplate{}
+	rows, err := re.Database.Conn.QueryContext(ctx, `SELECT `+taskTemplateColumns+` FROM task_templates ORDER BY id;`)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		template, err := scanTaskTemplate(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, template)
+	}
+	return list, rows.Err()
+}
+
+// Gets a task template from the database by ID
+func (re *TaskTemplateRepository) GetTaskTemplateByID(templateID int, ctx context.Context) (models.TaskTemplate, error) {
+	row := re.Database.Conn.QueryRowContext(ctx, `SELECT `+taskTemplateColumns+` FROM task_templates WHERE id=$1;`, templateID)
+	template, err := scanTaskTemplate(row)
+	if err == sql.ErrNoRows {
+		return template, ErrNoMatch
+	}
+	return template, err
+}
+
+// Adds a new task template to the database
+func (re *TaskTemplateRepository) AddTaskTemplate(template *models.TaskTemplate, ctx context.Context) error {
+	subtasks, err := json.Marshal(template.Subtasks)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO task_templates(name, task_name, task_description, task_category_id, start_offset_hours, end_offset_hours, assignee_ids, subtasks, created_by)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, created_at, updated_at;`
+	return re.Database.Conn.QueryRowContext(ctx, query, template.Name, template.TaskName, template.TaskDescription, template.TaskCategoryID, template.StartOffsetHours, template.EndOffsetHours, toInt64Array(template.AssigneeIDs), subtasks, template.CreatedBy).
+		Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt)
+}
+
+// Updates a task template in the database by ID
+func (re *TaskTemplateRepository) UpdateTaskTemplate(template *models.TaskTemplate, ctx context.Context) error {
+	subtasks, err := json.Marshal(template.Subtasks)
+	if err != nil {
+		return err
+	}
+	query := `UPDATE task_templates SET name=$2, task_name=$3, task_description=$4, task_category_id=$5, start_offset_hours=$6, end_offset_hours=$7, assignee_ids=$8, subtasks=$9, updated_at=NOW()
+		WHERE id=$1 RETURNING updated_at;`
+	err = re.Database.Conn.QueryRowContext(ctx, query, template.ID, template.Name, template.TaskName, template.TaskDescription, template.TaskCategoryID, template.StartOffsetHours, template.EndOffsetHours, toInt64Array(template.AssigneeIDs), subtasks).
+		Scan(&template.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return ErrNoMatch
+	}
+	return err
+}
+
+// Deletes a task template from the database by ID, tasks created from the template are kept
+func (re *TaskTemplateRepository) DeleteTaskTemplate(templateID int, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, `DELETE FROM task_templates WHERE id=$1;`, templateID)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
@@ -2,12 +2,21 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
 
+	internalModels "github.com/qthuy2k1/task-management-app/internal/models"
 	models "github.com/qthuy2k1/task-management-app/internal/models/gen"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	. "github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
+// ErrEmailNotVerified is returned when a user who has not verified their email is assigned a task or logs in
+// while the policy forbids it
+var ErrEmailNotVerified = errors.New("the email address has not been verified")
+
 type UserRepository struct {
 	Database *Database
 }
@@ -18,7 +27,7 @@ func NewUserRepository(database *Database) *UserRepository {
 
 // Retrieves all users from the database
 func (re *UserRepository) GetAllUsers(ctx context.Context) (models.UserSlice, error) {
-	users, err := models.Users().All(ctx, re.Database.Conn)
+	users, err := models.Users(Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
 	if err != nil {
 		return users, err
 	}
@@ -36,31 +45,108 @@ func (re *UserRepository) AddUser(user *models.User, ctx context.Context) error
 
 // Retrieves a user by their ID from the database
 func (re *UserRepository) GetUserByID(userID int, ctx context.Context) (*models.User, error) {
-	user, err := models.Users(Where("id = ?", userID)).One(ctx, re.Database.Conn)
+	user, err := models.Users(Where("id = ?", userID), Where("deleted_at IS NULL")).One(ctx, re.Database.Conn)
 	if err != nil {
 		return user, err
 	}
 	return user, nil
 }
 
+// Gets which of the given user IDs belong to users that are not deleted
+func (re *UserRepository) GetExistingUserIDs(userIDs []int, ctx context.Context) (map[int]bool, error) {
+	existing := make(map[int]bool)
+	if len(userIDs) == 0 {
+		return existing, nil
+	}
+	users, err := models.Users(Select("id"), models.UserWhere.ID.IN(userIDs), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
+	if err != nil {
+		return existing, err
+	}
+	for _, user := range users {
+		existing[user.ID] = true
+	}
+	return existing, nil
+}
+
 // Retrieves a user by their email from the database
 func (re *UserRepository) GetUserByEmail(userEmail string, ctx context.Context) (*models.User, error) {
-	user, err := models.Users(Where("email = ?", userEmail)).One(ctx, re.Database.Conn)
+	user, err := models.Users(Where("email = ?", userEmail), Where("deleted_at IS NULL")).One(ctx, re.Database.Conn)
 	if err != nil {
 		return user, err
 	}
 	return user, nil
 }
 
-// Deletes a user from the database, but only if the user is a manager
-func (re *UserRepository) DeleteUser(userID int, ctx context.Context) (int64, error) {
-	rowsAff, err := models.Users(Where("id = ?", userID)).DeleteAll(ctx, re.Database.Conn)
+// Retrieves the user waiting to verify a new email address, the latest one when several users asked for it
+func (re *UserRepository) GetUserByPendingEmail(email string, ctx context.Context) (*models.User, error) {
+	return models.Users(Where("pending_email = ?", email), Where("deleted_at IS NULL"), OrderBy("id DESC")).One(ctx, re.Database.Conn)
+}
+
+// Retrieves the users whose email address starts with the given name followed by @
+func (re *UserRepository) GetUsersByEmailName(name string, ctx context.Context) (models.UserSlice, error) {
+	users, err := models.Users(Where("LOWER(SPLIT_PART(email, '@', 1)) = LOWER(?)", name), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
+	if err != nil {
+		return users, err
+	}
+	return users, nil
+}
+
+// Moves a user to the trash by ID, the user is kept in the database until it is purged
+func (re *UserRepository) DeleteUser(userID, deletedBy int, ctx context.Context) (int64, error) {
+	rowsAff, err := models.Users(Where("id = ?", userID), Where("deleted_at IS NULL")).UpdateAll(ctx, re.Database.Conn, models.M{"deleted_at": time.Now(), "deleted_by": deletedBy})
 	if err != nil {
 		return -1, err
 	}
 	return rowsAff, nil
 }
 
+// Gets all users in the trash
+func (re *UserRepository) GetDeletedUsers(ctx context.Context) ([]internalModels.TrashItem, error) {
+	return re.Database.getTrashItems(ctx, models.TableNames.Users, internalModels.TrashTypeUser)
+}
+
+// Restores a user from the trash by ID
+func (re *UserRepository) RestoreUser(userID int, ctx context.Context) error {
+	rowsAff, err := models.Users(Where("id = ?", userID), Where("deleted_at IS NOT NULL")).UpdateAll(ctx, re.Database.Conn, models.M{"deleted_at": nil, "deleted_by": nil})
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// Permanently deletes a user in the trash and their task assignments by ID, unless they are still the author
+// of a task
+func (re *UserRepository) PurgeUser(userID int, ctx context.Context) error {
+	return re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM user_task_details WHERE user_id IN (SELECT id FROM users u WHERE id = $1 AND deleted_at IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.author_id = u.id));`, userID)
+		if err != nil {
+			return err
+		}
+		return purgeUnreferencedItem(ctx, tx, models.TableNames.Users, "author_id", userID)
+	})
+}
+
+// Permanently deletes all users that were moved to the trash before the given time. Users who are still
+// the author of a task are kept and returned.
+func (re *UserRepository) PurgeUsersDeletedBefore(before time.Time, ctx context.Context) (int64, []internalModels.TrashItem, error) {
+	var rowsAff int64
+	var blocked []internalModels.TrashItem
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM user_task_details WHERE user_id IN (SELECT id FROM users u WHERE deleted_at < $1
+			AND NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.author_id = u.id));`, before)
+		if err != nil {
+			return err
+		}
+		rowsAff, blocked, err = purgeUnreferenced(ctx, tx, models.TableNames.Users, internalModels.TrashTypeUser, "author_id", before)
+		return err
+	})
+	return rowsAff, blocked, err
+}
+
 // Updates a user's name and email in the database, given their ID
 func (re *UserRepository) UpdateUser(user *models.User, ctx context.Context) (*models.User, error) {
 	rowsAff, err := user.Update(ctx, re.Database.Conn, boil.Infer())
@@ -73,20 +159,72 @@ func (re *UserRepository) UpdateUser(user *models.User, ctx context.Context) (*m
 	return user, nil
 }
 
-// Checks if a user is a manager
-func (re *UserRepository) IsManager(ctx context.Context, email string) (bool, error) {
-	count, err := models.Users(Where("email = ?", email), Where("role = ?", "manager")).Count(ctx, re.Database.Conn)
+// Get all users whose role grants a permission
+func (re *UserRepository) GetUsersWithPermission(permission internalModels.Permission, ctx context.Context) (models.UserSlice, error) {
+	users, err := models.Users(Where("role IN (SELECT role FROM role_permissions WHERE permission = ?)", permission), Where("deleted_at IS NULL")).All(ctx, re.Database.Conn)
 	if err != nil {
-		return false, err
+		return users, err
+	}
+	return users, err
+}
+
+// Checks whether a user has verified their email
+func (re *UserRepository) IsEmailVerified(userID int, ctx context.Context) (bool, error) {
+	var verified bool
+	err := re.Database.Conn.QueryRowContext(ctx, `SELECT email_verified_at IS NOT NULL FROM users WHERE id=$1 AND deleted_at IS NULL;`, userID).Scan(&verified)
+	if err == sql.ErrNoRows {
+		return false, ErrNoMatch
 	}
-	return count > 0, nil
+	return verified, err
 }
 
-// Get all users who have the role of manager
-func (re *UserRepository) GetUsersManager(ctx context.Context) (models.UserSlice, error) {
-	users, err := models.Users(Where("role = ?", "manager")).All(ctx, re.Database.Conn)
+// Marks the email of a user as verified, if it is still the given address. A verified email stays verified, and
+// a pending email replaces the email of the user once it is verified, unless another user has taken it meanwhile.
+func (re *UserRepository) VerifyEmail(userID int, email string, ctx context.Context) error {
+	query := `UPDATE users SET email=$2,
+			pending_email=CASE WHEN pending_email=$2 THEN NULL ELSE pending_email END,
+			email_verified_at=CASE WHEN email=$2 THEN COALESCE(email_verified_at, NOW()) ELSE NOW() END
+		WHERE id=$1 AND (email=$2 OR pending_email=$2) AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM users other WHERE other.email=$2 AND other.id<>$1);`
+	result, err := re.Database.Conn.ExecContext(ctx, query, userID, email)
 	if err != nil {
-		return users, err
+		return err
 	}
-	return users, err
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// Gets the users who have not verified their email
+func (re *UserRepository) GetUnverifiedUsers(ctx context.Context) (models.UserSlice, error) {
+	return models.Users(Where("email_verified_at IS NULL"), Where("deleted_at IS NULL"), OrderBy("id")).All(ctx, re.Database.Conn)
+}
+
+// Returns an error when one of the given users does not exist or is in the trash, and an error wrapping
+// ErrEmailNotVerified when one of them has not verified their email, since they cannot be assigned tasks
+func (re *UserRepository) CheckAssignable(userIDs []int, ctx context.Context) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	var userID int
+	var exists bool
+	query := `SELECT ids.id, users.id IS NOT NULL FROM unnest($1::bigint[]) AS ids(id)
+		LEFT JOIN users ON users.id = ids.id AND users.deleted_at IS NULL
+		WHERE users.id IS NULL OR users.email_verified_at IS NULL ORDER BY ids.id LIMIT 1;`
+	err := re.Database.Conn.QueryRowContext(ctx, query, toInt64Array(userIDs)).Scan(&userID, &exists)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("user %d does not exist", userID)
+	}
+	return fmt.Errorf("user %d cannot be assigned tasks: %w", userID, ErrEmailNotVerified)
 }
@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/models"
+	"github.com/volatiletech/null/v8"
+)
+
+var ErrRefreshTokenReused = errors.New("the refresh token was already used, the session is revoked")
+
+type UserSessionRepository struct {
+	Database *Database
+}
+
+func NewUserSessionRepository(database *Database) *UserSessionRepository {
+	return &UserSessionRepository{Database: database}
+}
+
+const userSessionColumns = `id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at`
+
+func scanUserSession(row rowScanner) (models.UserSession, error) {
+	var session models.UserSession
+	err := row.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IPAddress, &session.CreatedAt, &session.LastSeenAt, &session.ExpiresAt)
+	return session, err
+}
+
+// Adds a session with its first refresh token
+func (re *UserSessionRepository) AddUserSession(session models.UserSession, refreshTokenHash string, ctx context.Context) (models.UserSession, error) {
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		query := `INSERT INTO user_sessions(user_id, user_agent, ip_address, expires_at) VALUES($1, $2, $3, $4) RETURNING ` + userSessionColumns + `;`
+		added, err := scanUserSession(tx.QueryRowContext(ctx, query, session.UserID, session.UserAgent, session.IPAddress, session.ExpiresAt))
+		if err != nil {
+			return err
+		}
+		session = added
+		_, err = tx.ExecContext(ctx, `INSERT INTO user_session_refresh_tokens(token_hash, session_id) VALUES($1, $2);`, refreshTokenHash, session.ID)
+		return err
+	})
+	return session, err
+}
+
+// Replaces a refresh token with a new one and extends its session. A token that was already used revokes
+// its session and returns ErrRefreshTokenReused, unknown tokens and inactive sessions return ErrNoMatch.
+func (re *UserSessionRepository) RotateRefreshToken(refreshTokenHash, newRefreshTokenHash, userAgent, ipAddress string, expiresAt time.Time, ctx context.Context) (models.UserSession, error) {
+	var session models.UserSession
+	reused := false
+	err := re.Database.WithTx(ctx, func(tx *sql.Tx) error {
+		var sessionID int
+		var usedAt null.Time
+		err := tx.QueryRowContext(ctx, `SELECT session_id, used_at FROM user_session_refresh_tokens WHERE token_hash=$1 FOR UPDATE;`, refreshTokenHash).Scan(&sessionID, &usedAt)
+		if err == sql.ErrNoRows {
+			return ErrNoMatch
+		}
+		if err != nil {
+			return err
+		}
+		if usedAt.Valid {
+			// The token was stolen or the client was replayed, the thief and the user are both logged out
+			reused = true
+			session.ID = sessionID
+			_, err := tx.ExecContext(ctx, `UPDATE user_sessions SET revoked_at=NOW(), revoked_reason=$2 WHERE id=$1 AND revoked_at IS NULL;`, sessionID, models.SessionRevokedRefreshReuse)
+			return err
+		}
+		query := `UPDATE user_sessions s SET last_seen_at=NOW(), expires_at=$2, user_agent=$3, ip_address=$4
+			FROM users u
+			WHERE s.id=$1 AND u.id=s.user_id AND s.revoked_at IS NULL AND s.expires_at > NOW() AND u.deleted_at IS NULL
+			RETURNING s.id, s.user_id, s.user_agent, s.ip_address, s.created_at, s.last_seen_at, s.expires_at;`
+		session, err = scanUserSession(tx.QueryRowContext(ctx, query, sessionID, expiresAt, userAgent, ipAddress))
+		if err == sql.ErrNoRows {
+			return ErrNoMatch
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE user_session_refresh_tokens SET used_at=NOW() WHERE token_hash=$1;`, refreshTokenHash); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO user_session_refresh_tokens(token_hash, session_id) VALUES($1, $2);`, newRefreshTokenHash, sessionID)
+		return err
+	})
+	if err == nil && reused {
+		return session, ErrRefreshTokenReused
+	}
+	return session, err
+}
+
+// Gets the user of a session if it is neither revoked nor expired and its user is not deleted
+func (re *UserSessionRepository) GetActiveSessionUserID(sessionID int, ctx context.Context) (int, error) {
+	var userID int
+	query := `SELECT s.user_id FROM user_sessions s
+		INNER JOIN users u ON u.id = s.user_id
+		WHERE s.id=$1 AND s.revoked_at IS NULL AND s.expires_at > NOW() AND u.deleted_at IS NULL;`
+	err := re.Database.Conn.QueryRowContext(ctx, query, sessionID).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return 0, ErrNoMatch
+	}
+	return userID, err
+}
+
+// Gets the sessions of a user that are neither revoked nor expired, the most recently seen first
+func (re *UserSessionRepository) GetActiveSessionsOfUser(userID int, ctx context.Context) ([]models.UserSession, error) {
+	list := []models.UserSession{}
+	query := `SELECT ` + userSessionColumns + ` FROM user_sessions
+		WHERE user_id=$1 AND revoked_at IS NULL AND expires_at > NOW()
+		ORDER BY last_seen_at DESC, id DESC;`
+	rows, err := re.Database.Conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return list, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		session, err := scanUserSession(rows)
+		if err != nil {
+			return list, err
+		}
+		list = append(list, session)
+	}
+	return list, rows.Err()
+}
+
+func (re *UserSessionRepository) TouchUserSession(sessionID int, ctx context.Context) error {
+	_, err := re.Database.Conn.ExecContext(ctx, `UPDATE user_sessions SET last_seen_at=NOW() WHERE id=$1;`, sessionID)
+	return err
+}
+
+// Revokes a session of a user, its tokens stop working
+func (re *UserSessionRepository) RevokeUserSession(sessionID, userID int, reason string, ctx context.Context) error {
+	result, err := re.Database.Conn.ExecContext(ctx, `UPDATE user_sessions SET revoked_at=NOW(), revoked_reason=$3 WHERE id=$1 AND user_id=$2 AND revoked_at IS NULL;`, sessionID, userID, reason)
+	if err != nil {
+		return err
+	}
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAff == 0 {
+		return ErrNoMatch
+	}
+	return nil
+}
+
+// Revokes the session of a refresh token that was not used yet, and returns it
+func (re *UserSessionRepository) RevokeUserSessionByRefreshToken(refreshTokenHash, reason string, ctx context.Context) (models.UserSession, error) {
+	query := `UPDATE user_sessions s SET revoked_at=NOW(), revoked_reason=$2
+		FROM user_session_refresh_tokens t
+		WHERE t.token_hash=$1 AND t.session_id=s.id AND t.used_at IS NULL AND s.revoked_at IS NULL
+		RETURNING s.id, s.user_id, s.user_agent, s.ip_address, s.created_at, s.last_seen_at, s.expires_at;`
+	session, err := scanUserSession(re.Database.Conn.QueryRowContext(ctx, query, refreshTokenHash, reason))
+	if err == sql.ErrNoRows {
+		return session, ErrNoMatch
+	}
+	return session, err
+}
+
+// Revokes every session of a user
+func (re *UserSessionRepository) RevokeAllUserSessions(userID int, reason string, ctx context.Context) (int64, error) {
+	result, err := re.Database.Conn.ExecContext(ctx, `UPDATE user_sessions SET revoked_at=NOW(), revoked_reason=$2 WHERE user_id=$1 AND revoked_at IS NULL;`, userID, reason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
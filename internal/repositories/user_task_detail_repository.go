@@ -52,7 +52,7 @@ func (re *UserTaskDetailRepository) DeleteUserFromTask(userID int, taskID int) e
 // Get all the users that are assigned to the task
 func (re *UserTaskDetailRepository) GetAllUsersAssignedToTask(taskID int) ([]models.User, error) {
 	users := []models.User{}
-	query := `SELECT id, name, email, role FROM user_task_details d INNER JOIN users u ON d.user_id = u.id WHERE task_id=$1;`
+	query := `SELECT id, name, email, role FROM user_task_details d INNER JOIN users u ON d.user_id = u.id WHERE task_id=$1 AND u.deleted_at IS NULL;`
 	stmt, err := re.Database.Conn.Prepare(query)
 	if err != nil {
 		return users, err
@@ -80,7 +80,7 @@ func (re *UserTaskDetailRepository) GetAllUsersAssignedToTask(taskID int) ([]mod
 // Get all the tasks that are assigned to the user
 func (re *UserTaskDetailRepository) GetAllTaskAssignedToUser(userID int) ([]models.Task, error) {
 	list := []models.Task{}
-	query := `SELECT id, name, description, start_date, end_date, status, author_id, created_at, updated_at, task_category_id FROM user_task_details d INNER JOIN tasks t ON d.task_id = t.id WHERE user_id=$1;`
+	query := `SELECT id, name, description, start_date, end_date, status, author_id, created_at, updated_at, task_category_id FROM user_task_details d INNER JOIN tasks t ON d.task_id = t.id WHERE user_id=$1 AND t.deleted_at IS NULL;`
 	stmt, err := re.Database.Conn.Prepare(query)
 	if err != nil {
 		return list, err
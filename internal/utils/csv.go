@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A row of a CSV file with its values by field name
+type CSVRow struct {
+	Number int
+	Values map[string]string
+}
+
+// Reads a CSV file with a header row and gets the values of the given fields in every row.
+// The mapping gives the header of the column of a field, other fields are read from the column
+// with the same name. Headers are compared ignoring case and surrounding spaces.
+func ReadCSVRows(reader io.Reader, fields []string, mapping map[string]string) ([]CSVRow, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	// Rows can have a different number of columns, missing columns are read as empty values
+	csvReader.FieldsPerRecord = -1
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the CSV file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel adds a byte order mark before the first header
+		name = strings.TrimPrefix(name, "\uFEFF")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	fieldColumns := make(map[string]int, len(fields))
+	for _, field := range fields {
+		name := field
+		if mapped, ok := mapping[field]; ok {
+			name = mapped
+		}
+		if i, ok := columns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			fieldColumns[field] = i
+		} else if _, ok := mapping[field]; ok {
+			return nil, fmt.Errorf("column %q of field %q is not in the header", name, field)
+		}
+	}
+	for field := range mapping {
+		if !containsString(fields, field) {
+			return nil, fmt.Errorf("unknown field %q in the mapping", field)
+		}
+	}
+
+	rows := []CSVRow{}
+	for number := 1; ; number++ {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := CSVRow{Number: number, Values: make(map[string]string, len(fieldColumns))}
+		for field, i := range fieldColumns {
+			if i < len(record) {
+				row.Values[field] = strings.TrimSpace(record[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
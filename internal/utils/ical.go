@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	// Time zones of calendar clients must resolve even when the host has no zoneinfo database
+	_ "time/tzdata"
+)
+
+// Builds an iCalendar (RFC 5545) document line by line
+type ICalendar struct {
+	builder strings.Builder
+}
+
+// Adds a content line, folding it so no line is longer than 75 octets
+func (c *ICalendar) Property(name, value string) {
+	line := name + ":" + value
+	for len(line) > 75 {
+		cut := 75
+		// Do not split a multi-byte character
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		c.builder.WriteString(line[:cut] + "\r\n")
+		line = " " + line[cut:]
+	}
+	c.builder.WriteString(line + "\r\n")
+}
+
+// Adds a content line with a text value
+func (c *ICalendar) Text(name, value string) {
+	c.Property(name, EscapeICalText(value))
+}
+
+// Adds a content line with a date-time value in UTC
+func (c *ICalendar) Time(name string, value time.Time) {
+	c.Property(name, FormatICalTime(value))
+}
+
+func (c *ICalendar) Begin(component string) {
+	c.Property("BEGIN", component)
+}
+
+func (c *ICalendar) End(component string) {
+	c.Property("END", component)
+}
+
+func (c *ICalendar) String() string {
+	return c.builder.String()
+}
+
+// Escapes the characters that have a meaning in iCalendar text values
+func EscapeICalText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(value)
+}
+
+func FormatICalTime(value time.Time) string {
+	return value.UTC().Format("20060102T150405Z")
+}
+
+// A content line of an iCalendar document
+type ICalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Gets the properties of every component with the given name in an iCalendar document,
+// the properties of the components nested inside them (such as VALARM) are left out
+func ParseICalComponents(data, component string) ([][]ICalProperty, error) {
+	// Unfold the lines that were split to fit in 75 octets
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\n ", "")
+	data = strings.ReplaceAll(data, "\n\t", "")
+
+	components := [][]ICalProperty{}
+	var (
+		stack   []string
+		current []ICalProperty
+	)
+	for number, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, err := parseICalLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		switch prop.Name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(prop.Value))
+			if len(stack) > 0 && stack[len(stack)-1] == component {
+				current = []ICalProperty{}
+			}
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1] != strings.ToUpper(prop.Value) {
+				return nil, fmt.Errorf("line %d: unexpected END:%s", number+1, prop.Value)
+			}
+			if stack[len(stack)-1] == component {
+				components = append(components, current)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) > 0 && stack[len(stack)-1] == component {
+				current = append(current, prop)
+			}
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1])
+	}
+	return components, nil
+}
+
+func parseICalLine(line string) (ICalProperty, error) {
+	prop := ICalProperty{Params: map[string]string{}}
+	// The value starts at the first colon that is not inside a quoted parameter value
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, errors.New("missing colon in content line")
+	}
+	prop.Value = line[colon+1:]
+	parts := strings.Split(line[:colon], ";")
+	prop.Name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.Params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+// Reverses EscapeICalText
+func UnescapeICalText(value string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+	return replacer.Replace(value)
+}
+
+// Parses a DATE or DATE-TIME value, floating times and unknown time zones are read as UTC
+func ParseICalTime(prop ICalProperty) (time.Time, error) {
+	location := time.UTC
+	if tzid, ok := prop.Params["TZID"]; ok {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+	switch {
+	case prop.Params["VALUE"] == "DATE" || len(prop.Value) == len("20060102"):
+		return time.ParseInLocation("20060102", prop.Value, location)
+	case strings.HasSuffix(prop.Value, "Z"):
+		return time.Parse("20060102T150405Z", prop.Value)
+	default:
+		return time.ParseInLocation("20060102T150405", prop.Value, location)
+	}
+}
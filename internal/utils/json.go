@@ -15,3 +15,16 @@ func RenderJson(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(jsonBytes)
 }
+
+// Same as RenderJson but also writes the given status code
+func RenderJsonStatus(w http.ResponseWriter, statusCode int, data interface{}) {
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(jsonBytes)
+}
@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A plain text email
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sends the emails of the app
+type Mailer interface {
+	Send(mail Mail, ctx context.Context) error
+}
+
+// Sends emails through an SMTP server, with PLAIN authentication when a username is set
+type SMTPMailer struct {
+	// host:port of the server
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+func NewSMTPMailer(addr, from, username, password string) *SMTPMailer {
+	return &SMTPMailer{Addr: addr, From: from, Username: username, Password: password}
+}
+
+// Sends an email, the connection is closed when the context ends so a slow server cannot hold the caller
+func (m *SMTPMailer) Send(mail Mail, ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.Addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-stop:
+		}
+	}()
+
+	host, _, _ := strings.Cut(m.Addr, ":")
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return contextError(ctx, err)
+	}
+	defer client.Close()
+	if err := m.send(client, host, mail); err != nil {
+		return contextError(ctx, err)
+	}
+	return nil
+}
+
+// Runs the SMTP commands sending an email, with STARTTLS when the server supports it as smtp.SendMail does
+func (m *SMTPMailer) send(client *smtp.Client, host string, mail Mail) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if m.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.Username, m.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(m.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(mail.To); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(formatMail(m.From, mail, time.Now())); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// Reports the end of the context instead of the error of the connection it closed
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// Writes the emails to a writer instead of sending them, such as a log or a file, for development and tests
+type LogMailer struct {
+	mu     sync.Mutex
+	Writer io.Writer
+	From   string
+}
+
+func NewLogMailer(writer io.Writer, from string) *LogMailer {
+	return &LogMailer{Writer: writer, From: from}
+}
+
+func (m *LogMailer) Send(mail Mail, ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := m.Writer.Write(append(formatMail(m.From, mail, time.Now()), '\n'))
+	return err
+}
+
+// Formats an email as a message, header lines cannot be injected through the recipient or the subject
+func formatMail(from string, mail Mail, now time.Time) []byte {
+	header := func(value string) string {
+		return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+	}
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", header(from))
+	fmt.Fprintf(&message, "To: %s\r\n", header(mail.To))
+	fmt.Fprintf(&message, "Subject: %s\r\n", header(mail.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	message.WriteString("\r\n")
+	return []byte(message.String())
+}
+
+// Makes the mailer from the configuration. With MAILER=smtp the emails are sent through the SMTP server of
+// SMTP_ADDR, otherwise they are written to MAIL_FILE, or to the log when it is not set.
+func NewMailerFromEnv() (Mailer, error) {
+	from := os.Getenv("MAIL_FROM")
+	if from == "" {
+		from = "no-reply@localhost"
+	}
+	switch mailer := os.Getenv("MAILER"); mailer {
+	case "smtp":
+		addr := os.Getenv("SMTP_ADDR")
+		if addr == "" {
+			return nil, errors.New("SMTP_ADDR is required for the smtp mailer")
+		}
+		return NewSMTPMailer(addr, from, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD")), nil
+	case "log", "":
+		path := os.Getenv("MAIL_FILE")
+		if path == "" {
+			return NewLogMailer(log.Writer(), from), nil
+		}
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		return NewLogMailer(file, from), nil
+	default:
+		return nil, fmt.Errorf("unknown MAILER %q, expected smtp or log", mailer)
+	}
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/qthuy2k1/task-management-app/internal/utils"
+)
+
+func TestLogMailer(t *testing.T) {
+	var buffer bytes.Buffer
+	mailer := utils.NewLogMailer(&buffer, "no-reply@example.com")
+	mail := utils.Mail{
+		To:      "john.doe@example.com\r\nBcc: eve@example.com",
+		Subject: "Reset your password",
+		Body:    "Hello John,\nOpen this link: http://localhost:3000/password/reset?token=abc",
+	}
+	if err := mailer.Send(mail, context.Background()); err != nil {
+		t.Fatalf("expected the mail to be written, got %v", err)
+	}
+	message := buffer.String()
+	for _, expected := range []string{
+		"From: no-reply@example.com\r\n",
+		"To: john.doe@example.comBcc: eve@example.com\r\n",
+		"Subject: Reset your password\r\n",
+		"\r\n\r\nHello John,\r\nOpen this link: http://localhost:3000/password/reset?token=abc\r\n",
+	} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("expected the message to contain %q, got %q", expected, message)
+		}
+	}
+	if strings.Contains(message, "\r\nBcc:") {
+		t.Errorf("expected no header to be injected, got %q", message)
+	}
+}
+
+func TestSMTPMailerContextDeadline(t *testing.T) {
+	// A server that accepts the connection but never greets the client
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on a local port: %v", err)
+	}
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+	defer func() {
+		select {
+		case conn := <-accepted:
+			conn.Close()
+		default:
+		}
+	}()
+
+	mailer := utils.NewSMTPMailer(listener.Addr().String(), "no-reply@example.com", "", "")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = mailer.Send(utils.Mail{To: "john.doe@example.com", Subject: "Hello", Body: "Hello"}, ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the mailer to give up at the deadline, it took %v", elapsed)
+	}
+}